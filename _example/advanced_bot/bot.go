@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/diamondburned/arikawa/api"
 	"github.com/diamondburned/arikawa/bot"
 	"github.com/diamondburned/arikawa/bot/extras/arguments"
 	"github.com/diamondburned/arikawa/bot/extras/middlewares"
@@ -26,8 +27,8 @@ func (bot *Bot) Setup(sub *bot.Subcommand) {
 }
 
 // Help prints the default help message.
-func (bot *Bot) Help(m *gateway.MessageCreateEvent) (string, error) {
-	return bot.Ctx.Help(), nil
+func (bot *Bot) Help(m *gateway.MessageCreateEvent) (*api.SendMessageData, error) {
+	return bot.Ctx.HelpMessage(), nil
 }
 
 // Add demonstrates the usage of typed arguments. Run it with "~add 1 2".
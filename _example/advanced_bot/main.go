@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/diamondburned/arikawa/bot"
+	"github.com/diamondburned/arikawa/gateway"
 )
 
 // To run, do `BOT_TOKEN="TOKEN HERE" go run .`
@@ -21,6 +22,10 @@ func main() {
 		ctx.HasPrefix = bot.NewPrefix("!", "~")
 		ctx.EditableCommands = true
 
+		// Read commands in all messages. This privileged intent has to be
+		// enabled in the developer portal.
+		ctx.Gateway.Identifier.Intents |= gateway.IntentMessageContent
+
 		// Subcommand demo, but this can be in another package.
 		ctx.MustRegisterSubcommand(&Debug{})
 
@@ -5,15 +5,23 @@ package api
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/diamondburned/arikawa/api/rate"
 	"github.com/diamondburned/arikawa/utils/httputil"
 	"github.com/diamondburned/arikawa/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/utils/json"
 )
 
+// Version is the version of the Discord API that this package and the gateway
+// are written against. APIVersion is the same version as a string, which can be
+// changed to target another version at the user's own risk.
+const Version = 10
+
 var (
 	BaseEndpoint = "https://discord.com"
-	APIVersion   = "6"
+	APIVersion   = strconv.Itoa(Version)
 	APIPath      = "/api/v" + APIVersion
 
 	Endpoint           = BaseEndpoint + APIPath + "/"
@@ -26,27 +34,99 @@ var UserAgent = "DiscordBot (https://github.com/diamondburned/arikawa, v0.0.1)"
 type Client struct {
 	*httputil.Client
 	Session
+
+	// ValidateRequests, if true, makes methods validate their request data
+	// locally before sending it. Invalid data will return a *ValidationError.
+	// This is false by default.
+	ValidateRequests bool
 }
 
-func NewClient(token string) *Client {
-	return NewCustomClient(token, httputil.NewClient())
+// ClientOption is an option for NewClient and NewCustomClient. The options are
+// applied in order after the Client is created.
+type ClientOption func(*Client)
+
+// WithHTTPClient makes the Client use the given standard library HTTP client,
+// such as one with a proxy, custom TLS configuration or timeouts in its
+// Transport.
+func WithHTTPClient(httpClient http.Client) ClientOption {
+	return func(c *Client) {
+		var driver httpdriver.Client = httpdriver.WrapClient(httpClient)
+		// Keep the base URL if WithBaseURL came first.
+		if rebased, ok := c.Client.Client.(rebasedDriver); ok {
+			rebased.Client = driver
+			driver = rebased
+		}
+		c.Client.Client = driver
+	}
 }
 
-func NewCustomClient(token string, httpClient *httputil.Client) *Client {
-	ses := Session{
-		Limiter:   rate.NewLimiter(APIPath),
-		Token:     token,
-		UserAgent: UserAgent,
+// WithBaseURL makes the Client send its requests to the given base URL instead
+// of BaseEndpoint. Refer to Client's WithBaseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.Client.Client = rebase(c.Client.Client, baseURL)
 	}
+}
 
-	hcl := httpClient.Copy()
-	hcl.OnRequest = append(hcl.OnRequest, ses.InjectRequest)
-	hcl.OnResponse = append(hcl.OnResponse, ses.OnResponse)
+// WithUserAgent makes the Client send the given User-Agent instead of
+// UserAgent. Discord asks bots to use the format in UserAgent.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.Session.UserAgent = userAgent
+	}
+}
 
-	return &Client{
-		Client:  hcl,
-		Session: ses,
+// WithRetry sets the number of times the Client retries a failed request. If
+// retries is smaller than 1, requests will retry forever.
+func WithRetry(retries uint) ClientOption {
+	return func(c *Client) {
+		c.Client.Retries = retries
+	}
+}
+
+// WithRateLimiter makes the Client use the given rate limiter instead of an
+// in-process *rate.DefaultLimiter, such as one that is shared across
+// processes.
+func WithRateLimiter(limiter rate.Limiter) ClientOption {
+	return func(c *Client) {
+		c.Session.Limiter = limiter
+	}
+}
+
+// NewClient creates a new Client with the given token and options:
+//
+//    c := api.NewClient("Bot "+token,
+//        api.WithUserAgent("DiscordBot (https://example.com, v1.0.0)"),
+//        api.WithRetry(2),
+//    )
+func NewClient(token string, opts ...ClientOption) *Client {
+	return NewCustomClient(token, httputil.NewClient(), opts...)
+}
+
+// NewCustomClient creates a new Client with a copy of the given httputil
+// Client, then applies the options.
+func NewCustomClient(token string, httpClient *httputil.Client, opts ...ClientOption) *Client {
+	c := &Client{
+		Client: httpClient.Copy(),
+		Session: Session{
+			Limiter:   rate.NewLimiter(APIPath),
+			Token:     token,
+			UserAgent: UserAgent,
+		},
+	}
+
+	// Bind the hooks to the Client's own Session, so that changing its fields,
+	// such as the Limiter, affects the requests. The rate limit is acquired
+	// after all other hooks, so that a failing hook can't keep it acquired.
+	c.Client.OnRequest = append(c.Client.OnRequest, c.Session.InjectRequest)
+	c.Client.OnSend = append(c.Client.OnSend, c.Session.AcquireRequest)
+	c.Client.OnResponse = append(c.Client.OnResponse, c.Session.OnResponse)
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
 // WithContext returns a shallow copy of Client with the given context. It's
@@ -55,28 +135,136 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	return &Client{
 		Client:  c.Client.WithContext(ctx),
 		Session: c.Session,
+
+		ValidateRequests: c.ValidateRequests,
 	}
 }
 
+// NewHTTPClient creates a new client that uses the given standard library HTTP
+// client. It's the same as NewClient with WithHTTPClient.
+func NewHTTPClient(token string, httpClient http.Client) *Client {
+	return NewClient(token, WithHTTPClient(httpClient))
+}
+
+// WithBaseURL returns a shallow copy of Client that sends its requests to the
+// given base URL instead of BaseEndpoint, such as "http://localhost:3000" for
+// an HTTP proxy or a mock server. The API path is kept.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	hcl := c.Client.Copy()
+	hcl.Client = rebase(hcl.Client, baseURL)
+
+	return &Client{
+		Client:  hcl,
+		Session: c.Session,
+
+		ValidateRequests: c.ValidateRequests,
+	}
+}
+
+// WithJSON returns a shallow copy of Client that decodes responses with the
+// given JSON driver instead of json.Default, such as a json.CompatDriver of a
+// faster package.
+func (c *Client) WithJSON(driver json.Driver) *Client {
+	return &Client{
+		Client:  c.Client.WithJSON(driver),
+		Session: c.Session,
+
+		ValidateRequests: c.ValidateRequests,
+	}
+}
+
+// rebasedDriver replaces the base URL of all requests.
+type rebasedDriver struct {
+	httpdriver.Client
+	from, to string
+}
+
+func rebase(client httpdriver.Client, baseURL string) rebasedDriver {
+	return rebasedDriver{
+		Client: client,
+		from:   BaseEndpoint,
+		to:     strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (d rebasedDriver) NewRequest(ctx context.Context, method, url string) (httpdriver.Request, error) {
+	if strings.HasPrefix(url, d.from) {
+		url = d.to + url[len(d.from):]
+	}
+	return d.Client.NewRequest(ctx, method, url)
+}
+
+// WithHooks returns a shallow copy of Client that also calls the given hooks on
+// each request, such as for logging or metrics. The hooks of the original
+// Client are kept. Use the embedded httputil.Client's OnRequest and
+// OnResponse to add hooks to the Client itself.
+func (c *Client) WithHooks(req []httputil.RequestOption, resp []httputil.ResponseFunc) *Client {
+	return &Client{
+		Client:  c.Client.WithRequestOptions(req...).WithResponseFuncs(resp...),
+		Session: c.Session,
+
+		ValidateRequests: c.ValidateRequests,
+	}
+}
+
+// WithAuditLogReason returns a shallow copy of Client that sets the given
+// audit log reason on all requests:
+//
+//    c.WithAuditLogReason("Spamming").Kick(guildID, userID)
+func (c *Client) WithAuditLogReason(reason string) *Client {
+	return c.WithHooks([]httputil.RequestOption{httputil.WithAuditLogReason(reason)}, nil)
+}
+
+// WithResponseMeta returns a shallow copy of Client that stores the status and
+// headers of its last response into meta, such as the rate limit headers or
+// the ray ID asked for by Discord support:
+//
+//    var meta httputil.ResponseMeta
+//    _, err := c.WithResponseMeta(&meta).SendText(channelID, "Hello")
+//    log.Println("Sent with ray ID", meta.RayID())
+//
+// The returned Client should not be used concurrently.
+func (c *Client) WithResponseMeta(meta *httputil.ResponseMeta) *Client {
+	return c.WithHooks(nil, []httputil.ResponseFunc{httputil.StoreResponseMeta(meta)})
+}
+
 // Session keeps a single session. This is typically wrapped around Client.
 type Session struct {
-	Limiter *rate.Limiter
+	// Limiter is the rate limiter used for all requests. It defaults to an
+	// in-process *rate.DefaultLimiter, but it can be replaced before any
+	// request is made, such as with one that is shared across processes.
+	Limiter rate.Limiter
 
 	Token     string
 	UserAgent string
 }
 
+// InjectRequest adds the authorization and the User-Agent to the request.
 func (s *Session) InjectRequest(r httpdriver.Request) error {
 	r.AddHeader(http.Header{
 		"Authorization":         {s.Token},
 		"User-Agent":            {s.UserAgent},
 		"X-RateLimit-Precision": {"millisecond"},
 	})
+	return nil
+}
 
-	// Rate limit stuff
+// AcquireRequest waits for the rate limit of the request. It's released by
+// OnResponse.
+func (s *Session) AcquireRequest(r httpdriver.Request) error {
 	return s.Limiter.Acquire(r.GetContext(), r.GetPath())
 }
 
 func (s *Session) OnResponse(r httpdriver.Request, resp httpdriver.Response) error {
 	return s.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
 }
+
+// ErrCode returns the Discord JSON error code of err, or 0 if err isn't an
+// error returned by Discord. It's a shortcut for httputil.ErrCode:
+//
+//    if api.ErrCode(err) == httputil.ErrUnknownMessage {
+//        // The message was already deleted.
+//    }
+func ErrCode(err error) httputil.ErrorCode {
+	return httputil.ErrCode(err)
+}
@@ -3,7 +3,14 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/api/rate"
+	"github.com/diamondburned/arikawa/utils/httputil"
+	"github.com/diamondburned/arikawa/utils/httputil/httpdriver"
 )
 
 func TestContext(t *testing.T) {
@@ -18,3 +25,130 @@ func TestContext(t *testing.T) {
 		t.Fatal("Unexpected error:", err)
 	}
 }
+
+func TestWithHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1337"}`))
+	}))
+	defer srv.Close()
+
+	var requests, responses int
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+	hooked := client.WithHooks(
+		[]httputil.RequestOption{func(httpdriver.Request) error {
+			requests++
+			return nil
+		}},
+		[]httputil.ResponseFunc{func(httpdriver.Request, httpdriver.Response) error {
+			responses++
+			return nil
+		}},
+	)
+
+	if _, err := hooked.Me(); err != nil {
+		t.Fatal("Failed to get me:", err)
+	}
+
+	if requests != 1 || responses != 1 {
+		t.Fatal("Hooks not called once:", requests, responses)
+	}
+
+	// The original client shouldn't have the hooks.
+	if _, err := client.Me(); err != nil {
+		t.Fatal("Failed to get me:", err)
+	}
+
+	if requests != 1 || responses != 1 {
+		t.Fatal("Hooks leaked into the original client:", requests, responses)
+	}
+}
+
+func TestWithHooksError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1337"}`))
+	}))
+	defer srv.Close()
+
+	var hookErr = errors.New("hook failed")
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+	hooked := client.WithHooks(
+		[]httputil.RequestOption{func(httpdriver.Request) error { return hookErr }},
+		nil,
+	)
+
+	if _, err := hooked.Me(); !errors.Is(err, hookErr) {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// The failed request shouldn't have kept the rate limit of the route.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := client.WithContext(ctx).Me(); err != nil {
+		t.Fatal("Failed to get me after a hook error:", err)
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != APIPath+"/users/@me" {
+			t.Error("Unexpected path:", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "no. 3-chan" {
+			t.Error("Unexpected Authorization:", auth)
+		}
+
+		w.Write([]byte(`{"id":"1337","username":"3-chan"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient("no. 3-chan", http.Client{}).WithBaseURL(srv.URL + "/")
+
+	u, err := client.Me()
+	if err != nil {
+		t.Fatal("Failed to get me:", err)
+	}
+
+	if u.ID != 1337 || u.Username != "3-chan" {
+		t.Fatal("Unexpected user:", u)
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if ua := r.Header.Get("User-Agent"); ua != "3-chan" {
+			t.Error("Unexpected User-Agent:", ua)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	limiter := rate.NewLimiter(APIPath)
+
+	client := NewClient("no. 3-chan",
+		WithBaseURL(srv.URL),
+		WithHTTPClient(http.Client{}),
+		WithUserAgent("3-chan"),
+		WithRetry(2),
+		WithRateLimiter(limiter),
+	)
+	client.Backoff = nil
+
+	if client.Limiter != limiter {
+		t.Fatal("Unexpected limiter:", client.Limiter)
+	}
+
+	if _, err := client.Me(); err == nil {
+		t.Fatal("Unexpected success")
+	}
+
+	if requests != 2 {
+		t.Fatal("Unexpected requests:", requests)
+	}
+}
@@ -0,0 +1,153 @@
+// Package apitest provides a mock Discord REST server for testing code that
+// uses api.Client. The server replies with canned responses and records every
+// request it receives:
+//
+//    srv := apitest.NewServer()
+//    defer srv.Close()
+//
+//    srv.Handle("GET", "/users/@me", http.StatusOK, discord.User{ID: 1})
+//
+//    c := srv.Client("Bot token")
+//    me, err := c.Me()
+//
+// Paths are relative to the API path, e.g. "/channels/1/messages".
+package apitest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/diamondburned/arikawa/api"
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+// Request is a request recorded by the Server.
+type Request struct {
+	Method string
+	// Path is relative to the API path.
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// UnmarshalBody unmarshals the JSON body of the request into v.
+func (r Request) UnmarshalBody(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Server is a mock Discord REST server.
+type Server struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a new Server. It should be closed after use.
+func NewServer() *Server {
+	s := &Server{
+		handlers: map[string]http.HandlerFunc{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns an api.Client that sends its requests to the server. Failed
+// requests are not retried, so that errors are returned right away.
+func (s *Server) Client(token string) *api.Client {
+	c := api.NewClient(token).WithBaseURL(s.URL)
+	c.Retries = 1
+	return c
+}
+
+// Handle makes the server reply to requests to the method and path with the
+// given status and JSON body. A nil body replies without a body. If status is
+// 0, it defaults to 204 No Content for a nil body and 200 OK otherwise. Later
+// calls replace earlier ones for the same method and path.
+func (s *Server) Handle(method, path string, status int, body interface{}) {
+	var b []byte
+	if body != nil {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			panic("apitest: failed to marshal body: " + err.Error())
+		}
+	}
+
+	if status == 0 {
+		status = http.StatusOK
+		if b == nil {
+			status = http.StatusNoContent
+		}
+	}
+
+	s.HandleFunc(method, path, func(w http.ResponseWriter, r *http.Request) {
+		if b == nil {
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(b)
+	})
+}
+
+// HandleError makes the server reply with a Discord JSON error.
+func (s *Server) HandleError(method, path string, status int, code int, message string) {
+	s.Handle(method, path, status, struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{code, message})
+}
+
+// HandleFunc makes the server call fn for requests to the method and path.
+func (s *Server) HandleFunc(method, path string, fn http.HandlerFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handlers[method+" "+path] = fn
+}
+
+// Requests returns all requests the server received, in order.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset forgets all recorded requests. The handlers are kept.
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	path := strings.TrimPrefix(r.URL.Path, api.APIPath)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Header: r.Header,
+		Body:   body,
+	})
+	fn, ok := s.handlers[r.Method+" "+path]
+	s.mutex.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code": 0, "message": "404: Not Found"}`))
+		return
+	}
+
+	fn(w, r)
+}
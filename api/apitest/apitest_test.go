@@ -0,0 +1,77 @@
+package apitest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/diamondburned/arikawa/api"
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/httputil"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Handle("POST", "/channels/1/messages", http.StatusOK, discord.Message{
+		ID:        2,
+		ChannelID: 1,
+		Content:   "hi",
+	})
+	srv.HandleError("DELETE", "/channels/1/messages/3", http.StatusNotFound,
+		int(httputil.ErrUnknownMessage), "Unknown Message")
+
+	c := srv.Client("Bot token")
+
+	m, err := c.SendMessage(1, "hi", nil)
+	if err != nil {
+		t.Fatal("Failed to send message:", err)
+	}
+
+	if m.ID != 2 || m.Content != "hi" {
+		t.Fatal("Unexpected message:", m)
+	}
+
+	err = c.DeleteMessage(1, 3)
+	if code := api.ErrCode(err); code != httputil.ErrUnknownMessage {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 2 {
+		t.Fatal("Unexpected requests:", reqs)
+	}
+
+	var body api.SendMessageData
+	if err := reqs[0].UnmarshalBody(&body); err != nil {
+		t.Fatal("Failed to unmarshal body:", err)
+	}
+
+	if body.Content != "hi" {
+		t.Fatal("Unexpected body content:", body.Content)
+	}
+
+	if auth := reqs[0].Header.Get("Authorization"); auth != "Bot token" {
+		t.Fatal("Unexpected Authorization:", auth)
+	}
+}
+
+func TestServerNoBody(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Handle("DELETE", "/channels/1/messages/2", 0, nil)
+	srv.Handle("DELETE", "/channels/1/messages/3", http.StatusNotFound, nil)
+
+	c := srv.Client("Bot token")
+
+	if err := c.DeleteMessage(1, 2); err != nil {
+		t.Fatal("Failed to delete message:", err)
+	}
+
+	var httpErr *httputil.HTTPError
+	if err := c.DeleteMessage(1, 3); !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotFound {
+		t.Fatal("Unexpected error:", err)
+	}
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json/option"
+)
+
+// ChannelBlueprint describes a guild channel that should exist. Channels are
+// matched to existing ones by name, type and category, so renaming a channel
+// in the blueprint creates a new channel instead of renaming the old one.
+type ChannelBlueprint struct {
+	Name  string
+	Type  discord.ChannelType
+	Topic string
+	NSFW  bool
+
+	// Permissions are the channel's permission overwrites. A nil slice leaves
+	// the overwrites of existing channels as-is, while an empty slice requires
+	// the channel to have no overwrites.
+	Permissions []discord.Overwrite
+
+	// Children are the channels in this category. They're ignored if Type is
+	// not discord.GuildCategory.
+	Children []ChannelBlueprint
+}
+
+// Blueprint is a declarative layout of a guild's channels and categories:
+//
+//    bp := api.Blueprint{
+//        {Name: "general", Type: discord.GuildText},
+//        {Name: "Staff", Type: discord.GuildCategory, Children: []api.ChannelBlueprint{
+//            {Name: "mod-log", Type: discord.GuildText, Topic: "Bot logs."},
+//        }},
+//    }
+//
+//    report, err := client.ApplyBlueprint(guildID, bp, api.ApplyBlueprintData{})
+type Blueprint []ChannelBlueprint
+
+// ApplyBlueprintData contains the options of ApplyBlueprint.
+type ApplyBlueprintData struct {
+	// DryRun, if true, only reports the channels that would be created and the
+	// drift, without changing anything.
+	DryRun bool
+	// FixDrift, if true, modifies existing channels to match the blueprint.
+	// Otherwise, the drift is only reported.
+	FixDrift bool
+}
+
+// BlueprintDrift is a difference between an existing channel and its
+// blueprint.
+type BlueprintDrift struct {
+	ChannelID discord.ChannelID
+	Name      string
+	// Field is the name of the field that differs, such as "topic".
+	Field string
+	Want  string
+	Have  string
+}
+
+func (d BlueprintDrift) String() string {
+	return fmt.Sprintf("#%s: %s is %q, want %q", d.Name, d.Field, d.Have, d.Want)
+}
+
+// BlueprintReport is the result of ApplyBlueprint.
+type BlueprintReport struct {
+	// Created contains the channels that were created. In a dry run, the
+	// channels don't have IDs.
+	Created []discord.Channel
+	// Drift contains the differences between the existing channels and the
+	// blueprint. If FixDrift is true, these were fixed.
+	Drift []BlueprintDrift
+}
+
+// ApplyBlueprint creates the channels of the blueprint that don't exist in the
+// guild yet, and reports or fixes the existing channels that differ from it.
+// Channels that are not in the blueprint are left alone. Applying the same
+// blueprint again does nothing, so it's safe to apply on every startup.
+//
+// Requires the MANAGE_CHANNELS permission, and MANAGE_ROLES if the blueprint
+// has overwrites.
+func (c *Client) ApplyBlueprint(
+	guildID discord.GuildID, bp Blueprint, data ApplyBlueprintData) (*BlueprintReport, error) {
+
+	channels, err := c.Channels(guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get channels")
+	}
+
+	var apply = blueprintApply{
+		guildID:  guildID,
+		data:     data,
+		channels: channels,
+		planned:  map[string][]discord.Channel{},
+	}
+
+	if err := c.applyChannels(&apply, nil, bp); err != nil {
+		return &apply.report, err
+	}
+
+	return &apply.report, nil
+}
+
+// blueprintApply is the state of an ApplyBlueprint call.
+type blueprintApply struct {
+	guildID discord.GuildID
+	data    ApplyBlueprintData
+	report  BlueprintReport
+
+	// channels contains the guild's channels, including the ones created so
+	// far.
+	channels []discord.Channel
+	// planned maps the names of the categories that would be created in a dry
+	// run to the channels that would be created in them. These categories
+	// have no IDs, so their children can't be matched by category ID.
+	planned map[string][]discord.Channel
+}
+
+// applyChannels applies the blueprints of the channels in the category, which
+// is nil for channels outside of categories.
+func (c *Client) applyChannels(
+	apply *blueprintApply, category *discord.Channel, bps []ChannelBlueprint) error {
+
+	var categoryID discord.ChannelID
+	if category != nil {
+		categoryID = category.ID
+	}
+
+	// A category that would be created in a dry run has no ID.
+	var planned = category != nil && !category.ID.Valid()
+
+	for _, bp := range bps {
+		var ch discord.Channel
+
+		var siblings = apply.channels
+		if planned {
+			siblings = apply.planned[category.Name]
+		}
+
+		if found := findBlueprintChannel(siblings, bp, categoryID); found != nil {
+			ch = *found
+
+			drift, modify := blueprintDrift(ch, bp)
+			apply.report.Drift = append(apply.report.Drift, drift...)
+
+			if len(drift) > 0 && apply.data.FixDrift && !apply.data.DryRun {
+				if err := c.ModifyChannel(ch.ID, modify); err != nil {
+					return errors.Wrap(err, "failed to fix channel "+bp.Name)
+				}
+			}
+		} else {
+			created, err := c.createBlueprintChannel(apply.guildID, categoryID, bp, apply.data.DryRun)
+			if err != nil {
+				return errors.Wrap(err, "failed to create channel "+bp.Name)
+			}
+
+			ch = *created
+			apply.report.Created = append(apply.report.Created, ch)
+
+			// Keep the channel, so that it's matched if the blueprint has it
+			// again.
+			if planned {
+				apply.planned[category.Name] = append(siblings, ch)
+			} else {
+				apply.channels = append(apply.channels, ch)
+			}
+		}
+
+		if bp.Type == discord.GuildCategory && len(bp.Children) > 0 {
+			if err := c.applyChannels(apply, &ch, bp.Children); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) createBlueprintChannel(
+	guildID discord.GuildID, categoryID discord.ChannelID,
+	bp ChannelBlueprint, dryRun bool) (*discord.Channel, error) {
+
+	var create = CreateChannelData{
+		Name:        bp.Name,
+		Type:        bp.Type,
+		Topic:       bp.Topic,
+		NSFW:        bp.NSFW,
+		Permissions: bp.Permissions,
+		CategoryID:  categoryID,
+	}
+
+	if dryRun {
+		return &discord.Channel{
+			GuildID:     guildID,
+			CategoryID:  categoryID,
+			Name:        create.Name,
+			Type:        create.Type,
+			Topic:       create.Topic,
+			NSFW:        create.NSFW,
+			Permissions: create.Permissions,
+		}, nil
+	}
+
+	return c.CreateChannel(guildID, create)
+}
+
+func findBlueprintChannel(
+	channels []discord.Channel, bp ChannelBlueprint, categoryID discord.ChannelID) *discord.Channel {
+
+	for i, ch := range channels {
+		if ch.Name == bp.Name && ch.Type == bp.Type && ch.CategoryID == categoryID {
+			return &channels[i]
+		}
+	}
+	return nil
+}
+
+// blueprintDrift returns the differences between the channel and its
+// blueprint, as well as the data to fix them.
+func blueprintDrift(ch discord.Channel, bp ChannelBlueprint) ([]BlueprintDrift, ModifyChannelData) {
+	var drift []BlueprintDrift
+	var modify ModifyChannelData
+
+	var add = func(field, want, have string) {
+		drift = append(drift, BlueprintDrift{
+			ChannelID: ch.ID,
+			Name:      ch.Name,
+			Field:     field,
+			Want:      want,
+			Have:      have,
+		})
+	}
+
+	if ch.Topic != bp.Topic {
+		add("topic", bp.Topic, ch.Topic)
+		modify.Topic = &option.NullableStringData{Val: bp.Topic, Init: true}
+	}
+
+	if ch.NSFW != bp.NSFW {
+		add("nsfw", strconv.FormatBool(bp.NSFW), strconv.FormatBool(ch.NSFW))
+		modify.NSFW = &option.NullableBoolData{Val: bp.NSFW, Init: true}
+	}
+
+	if bp.Permissions != nil && !overwritesEqual(ch.Permissions, bp.Permissions) {
+		add("permissions",
+			fmt.Sprint(len(bp.Permissions), " overwrites"),
+			fmt.Sprint(len(ch.Permissions), " overwrites"))
+
+		perms := bp.Permissions
+		modify.Permissions = &perms
+	}
+
+	return drift, modify
+}
+
+func overwritesEqual(a, b []discord.Overwrite) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	var overwrites = make(map[discord.Snowflake]discord.Overwrite, len(a))
+	for _, o := range a {
+		overwrites[o.ID] = o
+	}
+
+	for _, o := range b {
+		if overwrites[o.ID] != o {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestBlueprintDrift(t *testing.T) {
+	var ch = discord.Channel{
+		ID:    1,
+		Name:  "rules",
+		Type:  discord.GuildText,
+		Topic: "Read these.",
+		Permissions: []discord.Overwrite{
+			{ID: 2, Type: discord.OverwriteRole, Deny: discord.PermissionSendMessages},
+		},
+	}
+
+	var bp = ChannelBlueprint{
+		Name:  "rules",
+		Type:  discord.GuildText,
+		Topic: "Read these.",
+		Permissions: []discord.Overwrite{
+			{ID: 2, Type: discord.OverwriteRole, Deny: discord.PermissionSendMessages},
+		},
+	}
+
+	if drift, _ := blueprintDrift(ch, bp); len(drift) > 0 {
+		t.Fatal("Unexpected drift:", drift)
+	}
+
+	bp.Topic = "Read these first."
+	bp.NSFW = true
+	bp.Permissions = []discord.Overwrite{}
+
+	drift, modify := blueprintDrift(ch, bp)
+	if len(drift) != 3 {
+		t.Fatal("Unexpected drift:", drift)
+	}
+
+	if modify.Topic == nil || modify.Topic.Val != bp.Topic {
+		t.Fatal("Topic is not fixed:", modify.Topic)
+	}
+	if modify.NSFW == nil || !modify.NSFW.Val {
+		t.Fatal("NSFW is not fixed:", modify.NSFW)
+	}
+	if modify.Permissions == nil || len(*modify.Permissions) != 0 {
+		t.Fatal("Permissions are not fixed:", modify.Permissions)
+	}
+
+	// Nil permissions should leave the overwrites alone.
+	bp.Permissions = nil
+
+	if drift, _ := blueprintDrift(ch, bp); len(drift) != 2 {
+		t.Fatal("Unexpected drift:", drift)
+	}
+}
+
+func TestBlueprintDryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != APIPath+"/guilds/1/channels" {
+			t.Error("Unexpected request:", r.Method, r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode([]discord.Channel{
+			{ID: 2, Name: "general", Type: discord.GuildText, Topic: "Chat."},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	var bp = Blueprint{
+		{Name: "Staff", Type: discord.GuildCategory, Children: []ChannelBlueprint{
+			{Name: "general", Type: discord.GuildText, Topic: "Staff chat."},
+		}},
+		{Name: "Staff", Type: discord.GuildCategory, Children: []ChannelBlueprint{
+			{Name: "general", Type: discord.GuildText, Topic: "Staff chat."},
+			{Name: "mod-log", Type: discord.GuildText},
+		}},
+	}
+
+	report, err := client.ApplyBlueprint(1, bp, ApplyBlueprintData{DryRun: true})
+	if err != nil {
+		t.Fatal("Failed to apply:", err)
+	}
+
+	// The category's general channel isn't the top-level one, and the second
+	// category matches the planned one.
+	if len(report.Drift) > 0 {
+		t.Fatal("Unexpected drift:", report.Drift)
+	}
+
+	var names []string
+	for _, ch := range report.Created {
+		names = append(names, ch.Name)
+	}
+
+	if strings.Join(names, ",") != "Staff,general,mod-log" {
+		t.Fatal("Unexpected created channels:", names)
+	}
+}
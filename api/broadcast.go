@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/pkg/errors"
+)
+
+// BroadcastInterval is the default interval between each message sent by a
+// Broadcast.
+var BroadcastInterval = time.Second
+
+// Broadcast sends the same message to many channels, usually across many
+// guilds, such as a bot-wide announcement. Messages are paced globally, and
+// failing to send to a channel does not stop the rest of the broadcast.
+//
+// A Broadcast keeps track of which channels it has already sent to, so calling
+// Send again after an error or a cancellation resumes the broadcast and retries
+// failed channels.
+type Broadcast struct {
+	// ChannelIDs contains the channels to send to, in order.
+	ChannelIDs []discord.ChannelID
+	// Data is the message to send. Files are not supported, as their readers
+	// can only be consumed once.
+	Data SendMessageData
+	// Interval is the time to wait between each message. It defaults to
+	// BroadcastInterval.
+	Interval time.Duration
+
+	mutex  sync.Mutex
+	sent   map[discord.ChannelID]discord.MessageID // channelID:messageID
+	failed map[discord.ChannelID]error
+}
+
+// NewBroadcast creates a new Broadcast of the given message into the given
+// channels.
+func NewBroadcast(data SendMessageData, channelIDs ...discord.ChannelID) *Broadcast {
+	return &Broadcast{
+		ChannelIDs: channelIDs,
+		Data:       data,
+		Interval:   BroadcastInterval,
+		sent:       map[discord.ChannelID]discord.MessageID{},
+		failed:     map[discord.ChannelID]error{},
+	}
+}
+
+// Send sends the message into all channels that it hasn't been sent to yet. It
+// blocks until all channels are done or until the context is canceled, in
+// which case the context's error is returned. Errors from individual channels
+// are not returned; use Failed instead.
+func (b *Broadcast) Send(ctx context.Context, c *Client) error {
+	if len(b.Data.Files) > 0 {
+		return errors.New("broadcasts cannot send files")
+	}
+
+	var interval = b.Interval
+	if interval <= 0 {
+		interval = BroadcastInterval
+	}
+
+	// Allow Broadcasts created without NewBroadcast.
+	b.mutex.Lock()
+	if b.sent == nil {
+		b.sent = map[discord.ChannelID]discord.MessageID{}
+	}
+	if b.failed == nil {
+		b.failed = map[discord.ChannelID]error{}
+	}
+	b.mutex.Unlock()
+
+	var cc = c.WithContext(ctx)
+	var first = true
+
+	for _, channelID := range b.Remaining() {
+		// Pace the messages, except for the first one.
+		if !first {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		first = false
+
+		m, err := cc.SendMessageComplex(channelID, b.Data)
+
+		b.mutex.Lock()
+		if err != nil {
+			b.failed[channelID] = err
+		} else {
+			b.sent[channelID] = m.ID
+			delete(b.failed, channelID)
+		}
+		b.mutex.Unlock()
+
+		// The context error takes precedence, as the channel wasn't actually
+		// at fault.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remaining returns the channels that the message hasn't been sent to yet,
+// including failed ones. Channels listed twice in ChannelIDs are only returned
+// once.
+func (b *Broadcast) Remaining() []discord.ChannelID {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var remaining []discord.ChannelID
+	var seen = make(map[discord.ChannelID]struct{}, len(b.ChannelIDs))
+
+	for _, id := range b.ChannelIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if _, ok := b.sent[id]; !ok {
+			remaining = append(remaining, id)
+		}
+	}
+
+	return remaining
+}
+
+// Sent returns a copy of the channels that the message was sent to, mapped to
+// the IDs of the sent messages.
+func (b *Broadcast) Sent() map[discord.ChannelID]discord.MessageID {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var sent = make(map[discord.ChannelID]discord.MessageID, len(b.sent))
+	for k, v := range b.sent {
+		sent[k] = v
+	}
+
+	return sent
+}
+
+// Failed returns a copy of the channels that the message failed to be sent to,
+// mapped to their errors. Channels that succeed on a retry are removed.
+func (b *Broadcast) Failed() map[discord.ChannelID]error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var failed = make(map[discord.ChannelID]error, len(b.failed))
+	for k, v := range b.failed {
+		failed[k] = v
+	}
+
+	return failed
+}
+
+// Summary returns a short human-readable report of the broadcast, such as
+// "sent 98/100 messages, 2 failed". Channels listed twice in ChannelIDs are
+// only counted once.
+func (b *Broadcast) Summary() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var total, sent, failed int
+	var seen = make(map[discord.ChannelID]struct{}, len(b.ChannelIDs))
+
+	for _, id := range b.ChannelIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		total++
+
+		if _, ok := b.sent[id]; ok {
+			sent++
+		}
+		if _, ok := b.failed[id]; ok {
+			failed++
+		}
+	}
+
+	return "sent " + strconv.Itoa(sent) + "/" + strconv.Itoa(total) +
+		" messages, " + strconv.Itoa(failed) + " failed"
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestBroadcast(t *testing.T) {
+	var mutex sync.Mutex
+	var forbidden = true
+	var sent []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Path == APIPath+"/channels/2/messages" && forbidden {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":50013,"message":"Missing Permissions"}`))
+			return
+		}
+
+		sent = append(sent, r.URL.Path)
+		w.Write([]byte(`{"id":"10"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+	client.Retries = 1
+
+	// Channel 3 is listed twice, but should only be sent to once.
+	b := &Broadcast{
+		ChannelIDs: []discord.ChannelID{1, 2, 3, 3},
+		Data:       SendMessageData{Content: "Hello"},
+		Interval:   1,
+	}
+
+	if err := b.Send(context.Background(), client); err != nil {
+		t.Fatal("Failed to send:", err)
+	}
+
+	if len(sent) != 2 {
+		t.Fatal("Unexpected messages:", sent)
+	}
+	if failed := b.Failed(); len(failed) != 1 || ErrCode(failed[2]) != 50013 {
+		t.Fatal("Unexpected failed channels:", failed)
+	}
+	if s := b.Summary(); s != "sent 2/3 messages, 1 failed" {
+		t.Fatal("Unexpected summary:", s)
+	}
+
+	mutex.Lock()
+	forbidden = false
+	mutex.Unlock()
+
+	// Sending again only retries the failed channel.
+	if err := b.Send(context.Background(), client); err != nil {
+		t.Fatal("Failed to resume:", err)
+	}
+
+	if len(sent) != 3 || sent[2] != APIPath+"/channels/2/messages" {
+		t.Fatal("Unexpected messages:", sent)
+	}
+	if len(b.Failed()) != 0 || len(b.Sent()) != 3 {
+		t.Fatal("Unexpected broadcast:", b.Summary())
+	}
+	if s := b.Summary(); s != "sent 3/3 messages, 0 failed" {
+		t.Fatal("Unexpected summary:", s)
+	}
+
+	// Sent can have more channels than ChannelIDs after they're changed.
+	b.ChannelIDs = []discord.ChannelID{4}
+
+	if remaining := b.Remaining(); len(remaining) != 1 || remaining[0] != 4 {
+		t.Fatal("Unexpected remaining channels:", remaining)
+	}
+}
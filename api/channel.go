@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/utils/httputil"
 	"github.com/diamondburned/arikawa/utils/json/option"
@@ -9,7 +12,7 @@ import (
 var EndpointChannels = Endpoint + "channels/"
 
 // Channels returns a list of guild channel objects.
-func (c *Client) Channels(guildID discord.Snowflake) ([]discord.Channel, error) {
+func (c *Client) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
 	var chs []discord.Channel
 	return chs, c.RequestJSON(&chs, "GET", EndpointGuilds+guildID.String()+"/channels")
 }
@@ -38,6 +41,15 @@ type CreateChannelData struct {
 	//
 	// Channel Types: Voice
 	VoiceUserLimit uint `json:"user_limit,omitempty"`
+	// VoiceRTCRegion is the ID of the voice region of the voice channel, as
+	// returned by VoiceRegions. If empty, the region is picked automatically.
+	//
+	// Channel Types: Voice
+	VoiceRTCRegion string `json:"rtc_region,omitempty"`
+	// VoiceQualityMode is the camera video quality of the voice channel.
+	//
+	// Channel Types: Voice
+	VoiceQualityMode discord.VideoQualityMode `json:"video_quality_mode,omitempty"`
 	// UserRateLimit is the amount of seconds a user has to wait before sending
 	// another message (0-21600).
 	// Bots, as well as users with the permission manage_messages or
@@ -53,10 +65,10 @@ type CreateChannelData struct {
 	//
 	// Channel Types: All
 	Permissions []discord.Overwrite `json:"permission_overwrites,omitempty"`
-	// CategoryID is the 	id of the parent category for a channel.
+	// CategoryID is the id of the parent category for a channel.
 	//
 	// Channel Types: Text, News, Store, Voice
-	CategoryID discord.Snowflake `json:"parent_id,string,omitempty"`
+	CategoryID discord.ChannelID `json:"parent_id,omitempty"`
 	// NSFW specifies whether the channel is nsfw.
 	//
 	// Channel Types: Text, News, Store.
@@ -68,7 +80,11 @@ type CreateChannelData struct {
 // Requires the MANAGE_CHANNELS permission.
 // Fires a Channel Create Gateway event.
 func (c *Client) CreateChannel(
-	guildID discord.Snowflake, data CreateChannelData) (*discord.Channel, error) {
+	guildID discord.GuildID, data CreateChannelData) (*discord.Channel, error) {
+	if err := c.validate(data); err != nil {
+		return nil, err
+	}
+
 	var ch *discord.Channel
 	return ch, c.RequestJSON(
 		&ch, "POST",
@@ -79,7 +95,7 @@ func (c *Client) CreateChannel(
 
 type MoveChannelData struct {
 	// ID is the channel id.
-	ID discord.Snowflake `json:"id"`
+	ID discord.ChannelID `json:"id"`
 	// Position is the sorting position of the channel
 	Position option.Int `json:"position"`
 }
@@ -87,7 +103,7 @@ type MoveChannelData struct {
 // MoveChannel modifies the position of channels in the guild.
 //
 // Requires MANAGE_CHANNELS.
-func (c *Client) MoveChannel(guildID discord.Snowflake, datum []MoveChannelData) error {
+func (c *Client) MoveChannel(guildID discord.GuildID, datum []MoveChannelData) error {
 	return c.FastRequest(
 		"PATCH",
 		EndpointGuilds+guildID.String()+"/channels", httputil.WithJSONBody(datum),
@@ -95,7 +111,7 @@ func (c *Client) MoveChannel(guildID discord.Snowflake, datum []MoveChannelData)
 }
 
 // Channel gets a channel by ID. Returns a channel object.
-func (c *Client) Channel(channelID discord.Snowflake) (*discord.Channel, error) {
+func (c *Client) Channel(channelID discord.ChannelID) (*discord.Channel, error) {
 	var channel *discord.Channel
 	return channel, c.RequestJSON(&channel, "GET", EndpointChannels+channelID.String())
 }
@@ -141,19 +157,34 @@ type ModifyChannelData struct {
 	//
 	// Channel Types: Voice
 	VoiceUserLimit option.NullableUint `json:"user_limit,omitempty"`
+	// VoiceRTCRegion is the ID of the voice region of the voice channel, as
+	// returned by VoiceRegions. Set it to null to pick the region
+	// automatically.
+	//
+	// Channel Types: Voice
+	VoiceRTCRegion option.NullableString `json:"rtc_region,omitempty"`
+	// VoiceQualityMode is the camera video quality of the voice channel.
+	//
+	// Channel Types: Voice
+	VoiceQualityMode *discord.VideoQualityMode `json:"video_quality_mode,omitempty"`
 	// Permissions are the channel or category-specific permissions.
 	//
 	// Channel Types: All
 	Permissions *[]discord.Overwrite `json:"permission_overwrites,omitempty"`
-	// CategoryID is the id of the new parent category for a channel.
+	// CategoryID is the id of the new parent category for a channel. Use
+	// option.NullSnowflake to move the channel out of its category.
+	//
 	// Channel Types: Text, News, Store, Voice
-	CategoryID discord.Snowflake `json:"parent_id,string,omitempty"`
+	CategoryID option.NullableSnowflake `json:"parent_id,omitempty"`
 }
 
 // ModifyChannel updates a channel's settings.
 //
 // Requires the MANAGE_CHANNELS permission for the guild.
-func (c *Client) ModifyChannel(channelID discord.Snowflake, data ModifyChannelData) error {
+func (c *Client) ModifyChannel(channelID discord.ChannelID, data ModifyChannelData) error {
+	if err := c.validate(data); err != nil {
+		return err
+	}
 	return c.FastRequest("PATCH", EndpointChannels+channelID.String(), httputil.WithJSONBody(data))
 }
 
@@ -163,7 +194,7 @@ func (c *Client) ModifyChannel(channelID discord.Snowflake, data ModifyChannelDa
 // Channel Update Gateway event will fire for each of them.
 //
 // Fires a Channel Delete Gateway event.
-func (c *Client) DeleteChannel(channelID discord.Snowflake) error {
+func (c *Client) DeleteChannel(channelID discord.ChannelID) error {
 	return c.FastRequest("DELETE", EndpointChannels+channelID.String())
 }
 
@@ -172,7 +203,7 @@ func (c *Client) DeleteChannel(channelID discord.Snowflake) error {
 //
 // Requires the MANAGE_ROLES permission.
 func (c *Client) EditChannelPermission(
-	channelID discord.Snowflake, overwrite discord.Overwrite) error {
+	channelID discord.ChannelID, overwrite discord.Overwrite) error {
 
 	url := EndpointChannels + channelID.String() + "/permissions/" + overwrite.ID.String()
 	overwrite.ID = 0
@@ -184,7 +215,9 @@ func (c *Client) EditChannelPermission(
 // role in a channel. Only usable for guild channels.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) DeleteChannelPermission(channelID, overwriteID discord.Snowflake) error {
+func (c *Client) DeleteChannelPermission(
+	channelID discord.ChannelID, overwriteID discord.Snowflake) error {
+
 	return c.FastRequest(
 		"DELETE",
 		EndpointChannels+channelID.String()+"/permissions/"+overwriteID.String(),
@@ -193,13 +226,54 @@ func (c *Client) DeleteChannelPermission(channelID, overwriteID discord.Snowflak
 
 // Typing posts a typing indicator to the channel. Undocumented, but the client
 // usually clears the typing indicator after 8-10 seconds (or after a message).
-func (c *Client) Typing(channelID discord.Snowflake) error {
+func (c *Client) Typing(channelID discord.ChannelID) error {
 	return c.FastRequest("POST", EndpointChannels+channelID.String()+"/typing")
 }
 
+// TypingInterval is how often StartTyping refreshes the typing indicator,
+// which is a bit sooner than the client clears it.
+var TypingInterval = 8 * time.Second
+
+// StartTyping posts a typing indicator to the channel, then refreshes it every
+// TypingInterval in the background until the context is canceled, such as for
+// a command that takes a while:
+//
+//    ctx, cancel := context.WithCancel(context.Background())
+//    defer cancel()
+//
+//    c.StartTyping(ctx, channelID)
+//
+// The error of the first request is returned. It stops refreshing if a later
+// request fails.
+func (c *Client) StartTyping(ctx context.Context, channelID discord.ChannelID) error {
+	c = c.WithContext(ctx)
+
+	if err := c.Typing(channelID); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(TypingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Typing(channelID); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
 // PinnedMessages returns all pinned messages in the channel as an array of
 // message objects.
-func (c *Client) PinnedMessages(channelID discord.Snowflake) ([]discord.Message, error) {
+func (c *Client) PinnedMessages(channelID discord.ChannelID) ([]discord.Message, error) {
 	var pinned []discord.Message
 	return pinned, c.RequestJSON(&pinned, "GET", EndpointChannels+channelID.String()+"/pins")
 }
@@ -207,14 +281,14 @@ func (c *Client) PinnedMessages(channelID discord.Snowflake) ([]discord.Message,
 // PinMessage pins a message in a channel.
 //
 // Requires the MANAGE_MESSAGES permission.
-func (c *Client) PinMessage(channelID, messageID discord.Snowflake) error {
+func (c *Client) PinMessage(channelID discord.ChannelID, messageID discord.MessageID) error {
 	return c.FastRequest("PUT", EndpointChannels+channelID.String()+"/pins/"+messageID.String())
 }
 
 // UnpinMessage deletes a pinned message in a channel.
 //
 // Requires the MANAGE_MESSAGES permission.
-func (c *Client) UnpinMessage(channelID, messageID discord.Snowflake) error {
+func (c *Client) UnpinMessage(channelID discord.ChannelID, messageID discord.MessageID) error {
 	return c.FastRequest("DELETE", EndpointChannels+channelID.String()+"/pins/"+messageID.String())
 }
 
@@ -222,7 +296,7 @@ func (c *Client) UnpinMessage(channelID, messageID discord.Snowflake) error {
 // clearly this endpoint should only be used for OAuth. AccessToken can be
 // obtained with the "gdm.join" scope.
 func (c *Client) AddRecipient(
-	channelID, userID discord.Snowflake, accessToken, nickname string) error {
+	channelID discord.ChannelID, userID discord.UserID, accessToken, nickname string) error {
 
 	var params struct {
 		AccessToken string `json:"access_token"`
@@ -240,7 +314,7 @@ func (c *Client) AddRecipient(
 }
 
 // RemoveRecipient removes a user from a group direct message.
-func (c *Client) RemoveRecipient(channelID, userID discord.Snowflake) error {
+func (c *Client) RemoveRecipient(channelID discord.ChannelID, userID discord.UserID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointChannels+channelID.String()+"/recipients/"+userID.String(),
@@ -255,7 +329,7 @@ type Ack struct {
 // Ack marks the read state of a channel. This is undocumented. The method will
 // write to the ack variable passed in. If this method is called asynchronously,
 // then ack should be mutex guarded.
-func (c *Client) Ack(channelID, messageID discord.Snowflake, ack *Ack) error {
+func (c *Client) Ack(channelID discord.ChannelID, messageID discord.MessageID, ack *Ack) error {
 	return c.RequestJSON(
 		ack, "POST",
 		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/ack",
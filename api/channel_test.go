@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStartTyping(t *testing.T) {
+	var typed = make(chan struct{}, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != APIPath+"/channels/1/typing" {
+			t.Error("Unexpected request:", r.Method, r.URL.Path)
+		}
+
+		typed <- struct{}{}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	interval := TypingInterval
+	TypingInterval = 10 * time.Millisecond
+	defer func() { TypingInterval = interval }()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := client.StartTyping(ctx, 1); err != nil {
+		t.Fatal("Failed to start typing:", err)
+	}
+
+	// The first request and two refreshes.
+	for i := 0; i < 3; i++ {
+		select {
+		case <-typed:
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for typing", i)
+		}
+	}
+
+	cancel()
+
+	// Drain a refresh that may have been in flight when canceling.
+	time.Sleep(50 * time.Millisecond)
+	for len(typed) > 0 {
+		<-typed
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if len(typed) > 0 {
+		t.Fatal("Typing was refreshed after canceling")
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/httputil"
+)
+
+// SendDirectMessage sends a message to the user's DMs. If the user can't be
+// messaged, which Discord reports with error code 50007, the message is sent
+// to the fallback channel instead, with the user mentioned in front of the
+// content. This is useful for welcome messages, as many users don't accept
+// DMs from server members.
+//
+// The returned boolean is true if the message was sent as a DM. The fallback
+// is not used if fallbackChannelID is invalid. Since files can only be read
+// once, data should not contain files if a fallback is used.
+func (c *Client) SendDirectMessage(
+	userID discord.UserID, fallbackChannelID discord.ChannelID,
+	data SendMessageData) (msg *discord.Message, dm bool, err error) {
+
+	ch, err := c.CreatePrivateChannel(userID)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create DM channel")
+	}
+
+	msg, err = c.SendMessageComplex(ch.ID, data)
+	if err == nil {
+		return msg, true, nil
+	}
+
+	if !fallbackChannelID.Valid() || ErrCode(err) != httputil.ErrCannotMessageUser {
+		return nil, false, err
+	}
+
+	mention := "<@" + userID.String() + ">"
+	if data.Content == "" {
+		data.Content = mention
+	} else {
+		data.Content = mention + " " + data.Content
+	}
+
+	// Only ping the user that couldn't be messaged.
+	data.AllowedMentions = &AllowedMentions{
+		Parse: []AllowedMentionType{},
+		Users: []discord.UserID{userID},
+	}
+
+	msg, err = c.SendMessageComplex(fallbackChannelID, data)
+	return msg, false, err
+}
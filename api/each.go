@@ -0,0 +1,154 @@
+package api
+
+import "github.com/diamondburned/arikawa/discord"
+
+// The Each functions below go through everything an endpoint returns one page
+// at a time, and call the given function for every item. Unlike the functions
+// that paginate into a slice, only one page is kept in memory, so they can go
+// through millions of members or messages. The function returns false to stop
+// early, in which case no more requests are made.
+//
+// If a page fails to be fetched, the error is returned, and the items already
+// passed to the function stay valid.
+
+// EachMember calls fn for every member of the guild, from the smallest user ID
+// to the highest. Pages of 1000 members are fetched.
+//
+// Requires the GUILD_MEMBERS privileged intent to be enabled for the bot.
+func (c *Client) EachMember(guildID discord.GuildID, fn func(discord.Member) bool) error {
+	const hardLimit int = 1000
+
+	var after discord.UserID
+
+	for {
+		m, err := c.membersAfter(guildID, after, uint(hardLimit))
+		if err != nil {
+			return err
+		}
+
+		for _, mem := range m {
+			if !fn(mem) {
+				return nil
+			}
+		}
+
+		if len(m) < hardLimit {
+			return nil
+		}
+
+		after = m[hardLimit-1].User.ID
+	}
+}
+
+// EachMessage calls fn for every message in the channel within the range of
+// the query, in the order of IterateMessages. For more documentation, refer
+// to IterateMessages.
+func (c *Client) EachMessage(
+	channelID discord.ChannelID, query MessageQuery, fn func(discord.Message) bool) error {
+
+	it := c.IterateMessages(channelID, query)
+	for it.Next() {
+		if !fn(it.Message()) {
+			return nil
+		}
+	}
+
+	return it.Err()
+}
+
+// EachBan calls fn for every ban of the guild, from the smallest user ID to
+// the highest. Pages of 1000 bans are fetched.
+//
+// Requires the BAN_MEMBERS permission.
+func (c *Client) EachBan(guildID discord.GuildID, fn func(discord.Ban) bool) error {
+	const hardLimit int = 1000
+
+	var after discord.UserID
+
+	for {
+		b, err := c.bansRange(guildID, 0, after, uint(hardLimit))
+		if err != nil {
+			return err
+		}
+
+		for _, ban := range b {
+			if !fn(ban) {
+				return nil
+			}
+		}
+
+		if len(b) < hardLimit {
+			return nil
+		}
+
+		after = b[hardLimit-1].User.ID
+	}
+}
+
+// EachReaction calls fn for every user that reacted to the message with the
+// passed Emoji, from the smallest user ID to the highest. Pages of 100 users
+// are fetched.
+func (c *Client) EachReaction(
+	channelID discord.ChannelID, messageID discord.MessageID, emoji Emoji,
+	fn func(discord.User) bool) error {
+
+	const hardLimit int = 100
+
+	var after discord.UserID
+
+	for {
+		r, err := c.reactionsRange(channelID, messageID, 0, after, uint(hardLimit), emoji)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range r {
+			if !fn(u) {
+				return nil
+			}
+		}
+
+		if len(r) < hardLimit {
+			return nil
+		}
+
+		after = r[hardLimit-1].ID
+	}
+}
+
+// EachAuditLogEntry calls fn for every entry in the audit log of the guild
+// that matches the filters of data, from the newest entry to the oldest,
+// starting before data.Before if it's valid. data.Limit is ignored, and pages
+// of 100 entries are fetched.
+//
+// Only the entries are passed to fn. Use AuditLog instead to also get the
+// users, webhooks and integrations that the entries refer to.
+//
+// Requires the VIEW_AUDIT_LOG permission.
+func (c *Client) EachAuditLogEntry(
+	guildID discord.GuildID, data AuditLogData, fn func(discord.AuditLogEntry) bool) error {
+
+	const hardLimit int = 100
+
+	data.Limit = uint(hardLimit)
+
+	for {
+		log, err := c.AuditLog(guildID, data)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range log.Entries {
+			if !fn(entry) {
+				return nil
+			}
+		}
+
+		if len(log.Entries) < hardLimit {
+			return nil
+		}
+
+		// Discord returns the newest entries first.
+		data.Before = log.Entries[hardLimit-1].ID
+	}
+}
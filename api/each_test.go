@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+// memberServer serves the members with the user IDs 1 to n like Discord does:
+// up to limit members right after the given ID, smallest ID first. It counts
+// the requests made.
+func memberServer(t *testing.T, n int, requests *int) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		var q = r.URL.Query()
+
+		limit, err := strconv.Atoi(q.Get("limit"))
+		if err != nil {
+			t.Error("Invalid limit:", err)
+		}
+
+		var after int
+		if q.Get("after") != "" {
+			if after, err = strconv.Atoi(q.Get("after")); err != nil {
+				t.Error("Invalid after:", err)
+			}
+		}
+
+		var mems []discord.Member
+		for id := after + 1; id <= n && len(mems) < limit; id++ {
+			mems = append(mems, discord.Member{User: discord.User{ID: discord.UserID(id)}})
+		}
+
+		b, err := json.Marshal(mems)
+		if err != nil {
+			t.Error("Failed to marshal members:", err)
+		}
+		w.Write(b)
+	}))
+
+	return NewClient("no. 3-chan").WithBaseURL(srv.URL), srv.Close
+}
+
+func TestMembersAfter(t *testing.T) {
+	var requests int
+
+	client, done := memberServer(t, 2500, &requests)
+	defer done()
+
+	mems, err := client.Members(1, 0)
+	if err != nil {
+		t.Fatal("Failed to get members:", err)
+	}
+
+	if len(mems) != 2500 || mems[2499].User.ID != 2500 {
+		t.Fatal("Unexpected members:", len(mems))
+	}
+
+	if requests != 3 {
+		t.Fatal("Unexpected number of requests:", requests)
+	}
+}
+
+func TestEachMember(t *testing.T) {
+	var requests int
+
+	client, done := memberServer(t, 2500, &requests)
+	defer done()
+
+	var next = discord.UserID(1)
+
+	err := client.EachMember(1, func(m discord.Member) bool {
+		if m.User.ID != next {
+			t.Fatalf("Got member %d, expected %d", m.User.ID, next)
+		}
+		next++
+		return true
+	})
+	if err != nil {
+		t.Fatal("Failed to get members:", err)
+	}
+
+	if next != 2501 || requests != 3 {
+		t.Fatal("Unexpected last member or requests:", next-1, requests)
+	}
+
+	requests = 0
+
+	err = client.EachMember(1, func(m discord.Member) bool {
+		return m.User.ID < 1500
+	})
+	if err != nil {
+		t.Fatal("Failed to get members:", err)
+	}
+
+	if requests != 2 {
+		t.Fatal("Stopping early made unexpected requests:", requests)
+	}
+}
+
+func TestEachMessage(t *testing.T) {
+	client, done := messageServer(t, 250)
+	defer done()
+
+	var msgs []discord.Message
+
+	err := client.EachMessage(1, MessageQuery{After: 100}, func(m discord.Message) bool {
+		msgs = append(msgs, m)
+		return m.ID < 120
+	})
+	if err != nil {
+		t.Fatal("Failed to get messages:", err)
+	}
+
+	expectIDs(t, msgs, 101, 120)
+}
@@ -12,18 +12,18 @@ type Emoji = string
 // NewCustomEmoji creates a new Emoji using a custom guild emoji as
 // base.
 // Unicode emojis should be directly passed to the function using Emoji.
-func NewCustomEmoji(id discord.Snowflake, name string) Emoji {
+func NewCustomEmoji(id discord.EmojiID, name string) Emoji {
 	return name + ":" + id.String()
 }
 
 // Emojis returns a list of emoji objects for the given guild.
-func (c *Client) Emojis(guildID discord.Snowflake) ([]discord.Emoji, error) {
+func (c *Client) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
 	var emjs []discord.Emoji
 	return emjs, c.RequestJSON(&emjs, "GET", EndpointGuilds+guildID.String()+"/emojis")
 }
 
 // Emoji returns an emoji object for the given guild and emoji IDs.
-func (c *Client) Emoji(guildID, emojiID discord.Snowflake) (*discord.Emoji, error) {
+func (c *Client) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
 	var emj *discord.Emoji
 	return emj, c.RequestJSON(&emj, "GET",
 		EndpointGuilds+guildID.String()+"/emojis/"+emojiID.String())
@@ -36,7 +36,7 @@ type CreateEmojiData struct {
 	// Image is the the 128x128 emoji image.
 	Image Image `json:"image"`
 	// Roles are the roles for which this emoji will be whitelisted.
-	Roles *[]discord.Snowflake `json:"roles,omitempty"`
+	Roles *[]discord.RoleID `json:"roles,omitempty"`
 }
 
 // CreateEmoji creates a new emoji in the guild. This endpoint requires
@@ -45,7 +45,7 @@ type CreateEmojiData struct {
 // (though shouldn't be relied on).
 // Emojis and animated emojis have a maximum file size of 256kb.
 func (c *Client) CreateEmoji(
-	guildID discord.Snowflake, data CreateEmojiData) (*discord.Emoji, error) {
+	guildID discord.GuildID, data CreateEmojiData) (*discord.Emoji, error) {
 
 	// Max 256KB
 	if err := data.Image.Validate(256 * 1000); err != nil {
@@ -65,14 +65,14 @@ type ModifyEmojiData struct {
 	// Name is the name of the emoji.
 	Name string `json:"name,omitempty"`
 	// Roles are the roles to which this emoji will be whitelisted.
-	Roles *[]discord.Snowflake `json:"roles,omitempty"`
+	Roles *[]discord.RoleID `json:"roles,omitempty"`
 }
 
 // ModifyEmoji changes an existing emoji. This requires MANAGE_EMOJIS. Name and
 // roles are optional fields (though you'd want to change either though).
 //
 // Fires a Guild Emojis Update Gateway event.
-func (c *Client) ModifyEmoji(guildID, emojiID discord.Snowflake, data ModifyEmojiData) error {
+func (c *Client) ModifyEmoji(guildID discord.GuildID, emojiID discord.EmojiID, data ModifyEmojiData) error {
 	return c.FastRequest(
 		"PATCH",
 		EndpointGuilds+guildID.String()+"/emojis/"+emojiID.String(),
@@ -84,6 +84,6 @@ func (c *Client) ModifyEmoji(guildID, emojiID discord.Snowflake, data ModifyEmoj
 //
 // Requires the MANAGE_EMOJIS permission.
 // Fires a Guild Emojis Update Gateway event.
-func (c *Client) DeleteEmoji(guildID, emojiID discord.Snowflake) error {
+func (c *Client) DeleteEmoji(guildID discord.GuildID, emojiID discord.EmojiID) error {
 	return c.FastRequest("DELETE", EndpointGuilds+guildID.String()+"/emojis/"+emojiID.String())
 }
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// CopyEmojisData contains the options of CopyEmojis.
+type CopyEmojisData struct {
+	// DryRun, if true, only reports what would be copied without downloading
+	// or uploading anything.
+	DryRun bool
+	// Interval is the time to wait between each upload, on top of the rate
+	// limiter. Emoji uploads have a low rate limit that's shared across the
+	// whole guild, so a longer interval keeps other emoji operations usable.
+	Interval time.Duration
+}
+
+// CopyEmojisSkip is an emoji that was not copied.
+type CopyEmojisSkip struct {
+	Emoji  discord.Emoji
+	Reason string
+}
+
+// CopyEmojisReport is the result of CopyEmojis.
+type CopyEmojisReport struct {
+	// Copied contains the emojis that were created in the destination guild.
+	// In a dry run, it contains the source emojis that would be copied.
+	Copied []discord.Emoji
+	// Skipped contains the source emojis that were not copied.
+	Skipped []CopyEmojisSkip
+}
+
+// CopyEmojis copies the emojis of one guild to another with the same names,
+// which requires the MANAGE_EMOJIS permission in the destination guild.
+// Emojis whose names already exist in the destination are skipped, as well as
+// emojis that don't fit in the destination's boost tier's limit. Managed
+// emojis, such as the ones of Twitch integrations, can't be copied.
+//
+// If an emoji fails to copy, the report of the emojis copied so far is
+// returned along with the error.
+func (c *Client) CopyEmojis(
+	fromGuildID, toGuildID discord.GuildID,
+	data CopyEmojisData) (*CopyEmojisReport, error) {
+
+	src, err := c.Emojis(fromGuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get source emojis")
+	}
+
+	dst, err := c.Guild(toGuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get destination guild")
+	}
+
+	var names = make(map[string]struct{}, len(dst.Emojis))
+	var static, animated int
+
+	for _, emoji := range dst.Emojis {
+		names[emoji.Name] = struct{}{}
+
+		if emoji.Animated {
+			animated++
+		} else {
+			static++
+		}
+	}
+
+	var limit = dst.NitroBoost.EmojiLimit()
+	var report CopyEmojisReport
+
+	for _, emoji := range src {
+		var count = &static
+		if emoji.Animated {
+			count = &animated
+		}
+
+		switch _, exists := names[emoji.Name]; {
+		case emoji.Managed:
+			report.Skipped = append(report.Skipped, CopyEmojisSkip{emoji, "managed"})
+			continue
+		case exists:
+			report.Skipped = append(report.Skipped, CopyEmojisSkip{emoji, "name exists"})
+			continue
+		case *count >= limit:
+			report.Skipped = append(report.Skipped, CopyEmojisSkip{emoji, "no slots left"})
+			continue
+		}
+
+		*count++
+		names[emoji.Name] = struct{}{}
+
+		if data.DryRun {
+			report.Copied = append(report.Copied, emoji)
+			continue
+		}
+
+		if len(report.Copied) > 0 && data.Interval > 0 {
+			select {
+			case <-time.After(data.Interval):
+			case <-c.Context().Done():
+				return &report, c.Context().Err()
+			}
+		}
+
+		created, err := c.copyEmoji(toGuildID, emoji)
+		if err != nil {
+			return &report, errors.Wrap(err, "failed to copy emoji "+emoji.Name)
+		}
+
+		report.Copied = append(report.Copied, *created)
+	}
+
+	return &report, nil
+}
+
+func (c *Client) copyEmoji(guildID discord.GuildID, emoji discord.Emoji) (*discord.Emoji, error) {
+	b, err := c.downloadAsset(emoji.EmojiURL())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download emoji")
+	}
+
+	return c.CreateEmoji(guildID, CreateEmojiData{
+		Name: emoji.Name,
+		// CreateEmoji checks the content type before the image is encoded, so
+		// it has to be detected here.
+		Image: Image{ContentType: http.DetectContentType(b), Content: b},
+	})
+}
+
+// CopyStickersData contains the options of CopyStickers. They work the same as
+// the ones of CopyEmojis.
+type CopyStickersData = CopyEmojisData
+
+// CopyStickersSkip is a sticker that was not copied.
+type CopyStickersSkip struct {
+	Sticker discord.Sticker
+	Reason  string
+}
+
+// CopyStickersReport is the result of CopyStickers.
+type CopyStickersReport struct {
+	// Copied contains the stickers that were created in the destination
+	// guild. In a dry run, it contains the source stickers that would be
+	// copied.
+	Copied []discord.Sticker
+	// Skipped contains the source stickers that were not copied.
+	Skipped []CopyStickersSkip
+}
+
+// CopyStickers copies the custom stickers of one guild to another with the
+// same names, descriptions and tags, which requires the MANAGE_EMOJIS
+// permission in the destination guild. Stickers whose names already exist in
+// the destination are skipped, as well as stickers that don't fit in the
+// destination's boost tier's limit. Lottie stickers are skipped too, since
+// only verified and partnered guilds can upload them.
+//
+// If a sticker fails to copy, the report of the stickers copied so far is
+// returned along with the error.
+func (c *Client) CopyStickers(
+	fromGuildID, toGuildID discord.GuildID,
+	data CopyStickersData) (*CopyStickersReport, error) {
+
+	src, err := c.Stickers(fromGuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get source stickers")
+	}
+
+	dst, err := c.Guild(toGuildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get destination guild")
+	}
+
+	var names = make(map[string]struct{}, len(dst.Stickers))
+	for _, sticker := range dst.Stickers {
+		names[sticker.Name] = struct{}{}
+	}
+
+	var count = len(dst.Stickers)
+	var limit = dst.NitroBoost.StickerLimit()
+	var report CopyStickersReport
+
+	for _, sticker := range src {
+		switch _, exists := names[sticker.Name]; {
+		case sticker.Format == discord.LottieSticker:
+			report.Skipped = append(report.Skipped, CopyStickersSkip{sticker, "lottie"})
+			continue
+		case exists:
+			report.Skipped = append(report.Skipped, CopyStickersSkip{sticker, "name exists"})
+			continue
+		case count >= limit:
+			report.Skipped = append(report.Skipped, CopyStickersSkip{sticker, "no slots left"})
+			continue
+		}
+
+		count++
+		names[sticker.Name] = struct{}{}
+
+		if data.DryRun {
+			report.Copied = append(report.Copied, sticker)
+			continue
+		}
+
+		if len(report.Copied) > 0 && data.Interval > 0 {
+			select {
+			case <-time.After(data.Interval):
+			case <-c.Context().Done():
+				return &report, c.Context().Err()
+			}
+		}
+
+		created, err := c.copySticker(toGuildID, sticker)
+		if err != nil {
+			return &report, errors.Wrap(err, "failed to copy sticker "+sticker.Name)
+		}
+
+		report.Copied = append(report.Copied, *created)
+	}
+
+	return &report, nil
+}
+
+func (c *Client) copySticker(
+	guildID discord.GuildID, sticker discord.Sticker) (*discord.Sticker, error) {
+
+	b, err := c.downloadAsset(sticker.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download sticker")
+	}
+
+	var ext = ".png"
+	if sticker.Format == discord.GIFSticker {
+		ext = ".gif"
+	}
+
+	return c.CreateSticker(guildID, CreateStickerData{
+		Name:        sticker.Name,
+		Description: sticker.Description,
+		Tags:        sticker.Tags,
+		File: SendMessageFile{
+			Name:   sticker.Name + ext,
+			Reader: bytes.NewReader(b),
+		},
+	})
+}
+
+// downloadAsset downloads the file at the given CDN URL.
+func (c *Client) downloadAsset(url discord.URL) ([]byte, error) {
+	// The CDN doesn't need authorization, so the request is made with the
+	// underlying driver directly. This skips the OnRequest options, which keeps
+	// the token from being sent elsewhere and the API's rate limits from being
+	// used up.
+	q, err := c.Client.Client.NewRequest(c.Context(), "GET", url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	r, err := c.Client.Client.Do(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var body = r.GetBody()
+	defer body.Close()
+
+	if status := r.GetStatus(); status < 200 || status > 299 {
+		return nil, errors.Errorf("status %d", status)
+	}
+
+	return ioutil.ReadAll(body)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestCopyEmojis(t *testing.T) {
+	const png = "\x89PNG\r\n\x1a\n"
+
+	var src = []discord.Emoji{
+		{ID: 10, Name: "blob"},
+		{ID: 11, Name: "party", Animated: true},
+		{ID: 12, Name: "twitch", Managed: true},
+		{ID: 13, Name: "taken"},
+	}
+
+	var dst = discord.Guild{
+		ID:     2,
+		Emojis: []discord.Emoji{{ID: 20, Name: "taken"}},
+	}
+
+	var created []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, APIPath); {
+		case r.Method == "GET" && path == "/guilds/1/emojis":
+			json.NewEncoder(w).Encode(src)
+		case r.Method == "GET" && path == "/guilds/2":
+			json.NewEncoder(w).Encode(dst)
+		case r.Method == "GET" && strings.HasPrefix(path, "/emojis/"):
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				t.Error("Token was sent to the CDN:", auth)
+			}
+
+			switch path {
+			case "/emojis/10.png":
+				w.Write([]byte(png))
+			case "/emojis/11.gif":
+				w.Write([]byte("GIF89a"))
+			default:
+				t.Error("Unexpected download:", path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == "POST" && path == "/guilds/2/emojis":
+			var data struct {
+				Name  string `json:"name"`
+				Image string `json:"image"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+				t.Error("Failed to decode emoji:", err)
+			}
+
+			created = append(created, data.Name+" "+data.Image)
+
+			json.NewEncoder(w).Encode(discord.Emoji{ID: 21, Name: data.Name})
+		default:
+			t.Error("Unexpected request:", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var cdn = discord.CDNURL
+	discord.CDNURL = srv.URL + "/"
+	defer func() { discord.CDNURL = cdn }()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	report, err := client.CopyEmojis(1, 2, CopyEmojisData{})
+	if err != nil {
+		t.Fatal("Failed to copy emojis:", err)
+	}
+
+	if len(report.Copied) != 2 {
+		t.Fatal("Unexpected copied emojis:", report.Copied)
+	}
+	if len(report.Skipped) != 2 ||
+		report.Skipped[0].Reason != "managed" || report.Skipped[1].Reason != "name exists" {
+
+		t.Fatal("Unexpected skipped emojis:", report.Skipped)
+	}
+
+	var expect = []string{
+		"blob data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte(png)),
+		"party data:image/gif;base64," + base64.StdEncoding.EncodeToString([]byte("GIF89a")),
+	}
+	if strings.Join(created, "\n") != strings.Join(expect, "\n") {
+		t.Fatal("Unexpected created emojis:", created)
+	}
+}
+
+func TestCopyStickers(t *testing.T) {
+	var src = []discord.Sticker{
+		{ID: 10, Name: "wave", Tags: "wave", Format: discord.PNGSticker},
+		{ID: 11, Name: "dance", Tags: "dance", Format: discord.LottieSticker},
+		{ID: 12, Name: "cat", Description: "a cat", Tags: "cat", Format: discord.GIFSticker},
+	}
+
+	var dst = discord.Guild{
+		ID:       2,
+		Stickers: []discord.Sticker{{ID: 20, Name: "wave"}},
+	}
+
+	var created []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, APIPath); {
+		case r.Method == "GET" && path == "/guilds/1/stickers":
+			json.NewEncoder(w).Encode(src)
+		case r.Method == "GET" && path == "/guilds/2":
+			json.NewEncoder(w).Encode(dst)
+		case r.Method == "GET" && path == "/stickers/12.gif":
+			w.Write([]byte("GIF89a"))
+		case r.Method == "POST" && path == "/guilds/2/stickers":
+			f, h, err := r.FormFile("file")
+			if err != nil {
+				t.Error("Missing sticker file:", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			b, _ := ioutil.ReadAll(f)
+
+			created = append(created, strings.Join([]string{
+				r.FormValue("name"), r.FormValue("description"), r.FormValue("tags"),
+				h.Filename, string(b),
+			}, " "))
+
+			json.NewEncoder(w).Encode(discord.Sticker{ID: 21, Name: r.FormValue("name")})
+		default:
+			t.Error("Unexpected request:", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	var cdn = discord.CDNURL
+	discord.CDNURL = srv.URL + "/"
+	defer func() { discord.CDNURL = cdn }()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	report, err := client.CopyStickers(1, 2, CopyStickersData{})
+	if err != nil {
+		t.Fatal("Failed to copy stickers:", err)
+	}
+
+	if len(report.Copied) != 1 || report.Copied[0].ID != 21 {
+		t.Fatal("Unexpected copied stickers:", report.Copied)
+	}
+	if len(report.Skipped) != 2 ||
+		report.Skipped[0].Reason != "name exists" || report.Skipped[1].Reason != "lottie" {
+
+		t.Fatal("Unexpected skipped stickers:", report.Skipped)
+	}
+	if len(created) != 1 || created[0] != "cat a cat cat cat.gif GIF89a" {
+		t.Fatal("Unexpected created stickers:", created)
+	}
+}
@@ -39,7 +39,7 @@ func (c *Client) InviteWithCounts(code string) (*discord.Invite, error) {
 // the channel. Only usable for guild channels.
 //
 // Requires the MANAGE_CHANNELS permission.
-func (c *Client) ChannelInvites(channelID discord.Snowflake) ([]discord.Invite, error) {
+func (c *Client) ChannelInvites(channelID discord.ChannelID) ([]discord.Invite, error) {
 	var invs []discord.Invite
 	return invs, c.RequestJSON(&invs, "GET",
 		EndpointChannels+channelID.String()+"/invites")
@@ -49,7 +49,7 @@ func (c *Client) ChannelInvites(channelID discord.Snowflake) ([]discord.Invite,
 // guild.
 //
 // Requires the MANAGE_GUILD permission.
-func (c *Client) GuildInvites(guildID discord.Snowflake) ([]discord.Invite, error) {
+func (c *Client) GuildInvites(guildID discord.GuildID) ([]discord.Invite, error) {
 	var invs []discord.Invite
 	return invs, c.RequestJSON(&invs, "GET",
 		EndpointGuilds+guildID.String()+"/invites")
@@ -75,6 +75,18 @@ type CreateInviteData struct {
 	//
 	// Default:	false
 	Unique bool `json:"unique,omitempty"`
+
+	// TargetType is the type of target for this voice channel invite.
+	TargetType discord.InviteUserType `json:"target_type,omitempty"`
+	// TargetUserID is the ID of the user whose stream to display for this
+	// invite. It is required if TargetType is InviteUserStream; the user must
+	// be streaming in the channel.
+	TargetUserID discord.UserID `json:"target_user_id,omitempty"`
+	// TargetApplicationID is the ID of the embedded application to open for
+	// this invite. It is required if TargetType is InviteEmbeddedApplication;
+	// the application must have the EMBEDDED flag. Known activities are
+	// listed in the discord package, such as discord.YouTubeTogetherActivity.
+	TargetApplicationID discord.AppID `json:"target_application_id,omitempty"`
 }
 
 // CreateInvite creates a new invite object for the channel. Only usable for
@@ -82,7 +94,7 @@ type CreateInviteData struct {
 //
 // Requires the CREATE_INSTANT_INVITE permission.
 func (c *Client) CreateInvite(
-	channelID discord.Snowflake, data CreateInviteData) (*discord.Invite, error) {
+	channelID discord.ChannelID, data CreateInviteData) (*discord.Invite, error) {
 	var inv *discord.Invite
 	return inv, c.RequestJSON(
 		&inv, "POST",
@@ -91,6 +103,20 @@ func (c *Client) CreateInvite(
 	)
 }
 
+// CreateActivityInvite creates a new invite that launches the embedded
+// application with the given ID in the voice channel. This is a convenient
+// wrapper around CreateInvite.
+//
+// Requires the CREATE_INSTANT_INVITE permission.
+func (c *Client) CreateActivityInvite(
+	channelID discord.ChannelID, applicationID discord.AppID) (*discord.Invite, error) {
+
+	return c.CreateInvite(channelID, CreateInviteData{
+		TargetType:          discord.InviteEmbeddedApplication,
+		TargetApplicationID: applicationID,
+	})
+}
+
 // DeleteInvite deletes a channel permission overwrite for a user or role in a
 // channel. Only usable for guild channels.
 //
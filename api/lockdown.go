@@ -0,0 +1,189 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// LockdownPermissions are the permissions that Lockdown denies @everyone.
+var LockdownPermissions = discord.PermissionSendMessages
+
+// LockdownSnapshot is the state of a guild's @everyone overwrites before a
+// Lockdown. It can be marshaled to JSON, so that a lockdown can be lifted after
+// the bot restarts.
+type LockdownSnapshot struct {
+	GuildID discord.GuildID `json:"guild_id"`
+	// Deny is the permissions that were denied by the lockdown.
+	Deny discord.Permissions `json:"deny"`
+	// Overwrites maps the ID of every locked channel to its @everyone overwrite
+	// before the lockdown, or nil if the channel had none.
+	Overwrites map[discord.ChannelID]*discord.Overwrite `json:"overwrites"`
+	// LastChannelID is the newest channel of the guild when it was locked.
+	// Channels with larger IDs were created during the lockdown.
+	LastChannelID discord.ChannelID `json:"last_channel_id"`
+}
+
+// Lockdown denies @everyone the LockdownPermissions in every text, news and
+// category channel of the guild, and returns a snapshot of the overwrites it
+// replaced to be given to RestoreLockdown later. This requires the
+// MANAGE_ROLES permission.
+//
+// Channels that already deny @everyone the permissions are left untouched, but
+// they're still recorded in the snapshot. All channels are recorded before any
+// of them is locked, so if a channel fails to be locked, the returned snapshot
+// can still be given to RestoreLockdown to undo the ones that were.
+func (c *Client) Lockdown(guildID discord.GuildID) (*LockdownSnapshot, error) {
+	chs, err := c.Channels(guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get channels")
+	}
+
+	var snapshot = &LockdownSnapshot{
+		GuildID:    guildID,
+		Deny:       LockdownPermissions,
+		Overwrites: make(map[discord.ChannelID]*discord.Overwrite, len(chs)),
+	}
+
+	for _, ch := range chs {
+		if ch.ID > snapshot.LastChannelID {
+			snapshot.LastChannelID = ch.ID
+		}
+		if lockable(ch) {
+			snapshot.Overwrites[ch.ID] = everyoneOverwrite(ch, guildID)
+		}
+	}
+
+	for _, ch := range chs {
+		old, ok := snapshot.Overwrites[ch.ID]
+		if !ok {
+			continue
+		}
+
+		locked, changed := lockOverwrite(old, guildID, snapshot.Deny)
+		if !changed {
+			continue
+		}
+
+		if err := c.EditChannelPermission(ch.ID, locked); err != nil {
+			return snapshot, errors.Wrapf(err, "failed to lock channel %d", ch.ID)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// RestoreLockdown lifts a lockdown by restoring the exact @everyone overwrites
+// from the snapshot. Channels deleted since the lockdown are ignored.
+//
+// Channels created during the lockdown aren't in the snapshot, but they may
+// have copied the denied permissions from their category. The snapshot's
+// denied permissions are removed from their @everyone overwrite, and the
+// overwrite is deleted if nothing else is left in it. Other channels that
+// aren't in the snapshot are left alone.
+//
+// RestoreLockdown stops at the first channel that fails to be restored. As
+// channels that are already restored are skipped, it is safe to call again
+// with the same snapshot.
+func (c *Client) RestoreLockdown(snapshot LockdownSnapshot) error {
+	chs, err := c.Channels(snapshot.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get channels")
+	}
+
+	for _, ch := range chs {
+		if !lockable(ch) {
+			continue
+		}
+
+		current := everyoneOverwrite(ch, snapshot.GuildID)
+
+		want, ok := snapshot.Overwrites[ch.ID]
+		if !ok {
+			if ch.ID <= snapshot.LastChannelID {
+				continue
+			}
+			want = unlockOverwrite(current, snapshot.Deny)
+		}
+
+		if err := c.restoreOverwrite(ch.ID, current, want); err != nil {
+			return errors.Wrapf(err, "failed to restore channel %d", ch.ID)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) restoreOverwrite(
+	channelID discord.ChannelID, current, want *discord.Overwrite) error {
+
+	switch {
+	case want == nil && current == nil:
+		return nil
+	case want == nil:
+		return c.DeleteChannelPermission(channelID, current.ID)
+	case current != nil && *current == *want:
+		return nil
+	default:
+		return c.EditChannelPermission(channelID, *want)
+	}
+}
+
+func lockable(ch discord.Channel) bool {
+	switch ch.Type {
+	case discord.GuildText, discord.GuildNews, discord.GuildCategory:
+		return true
+	default:
+		return false
+	}
+}
+
+// everyoneOverwrite returns a copy of the channel's @everyone overwrite, or nil
+// if it has none.
+func everyoneOverwrite(ch discord.Channel, guildID discord.GuildID) *discord.Overwrite {
+	for _, o := range ch.Permissions {
+		if o.Type == discord.OverwriteRole && o.ID == discord.Snowflake(guildID) {
+			return &o
+		}
+	}
+	return nil
+}
+
+// lockOverwrite returns the @everyone overwrite with the permissions denied. It
+// returns false if the permissions were already denied.
+func lockOverwrite(
+	o *discord.Overwrite, guildID discord.GuildID,
+	deny discord.Permissions) (discord.Overwrite, bool) {
+
+	var locked = discord.Overwrite{
+		ID:   discord.Snowflake(guildID),
+		Type: discord.OverwriteRole,
+	}
+	if o != nil {
+		locked = *o
+	}
+
+	if locked.Deny.Has(deny) {
+		return locked, false
+	}
+
+	locked.Allow &^= deny
+	locked.Deny |= deny
+	return locked, true
+}
+
+// unlockOverwrite returns the @everyone overwrite without the denied
+// permissions, or nil if the overwrite would be empty.
+func unlockOverwrite(o *discord.Overwrite, deny discord.Permissions) *discord.Overwrite {
+	if o == nil {
+		return nil
+	}
+
+	var unlocked = *o
+	unlocked.Deny &^= deny
+
+	if unlocked.Allow == 0 && unlocked.Deny == 0 {
+		return nil
+	}
+	return &unlocked
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestLockdownOverwrite(t *testing.T) {
+	const guildID discord.GuildID = 1
+
+	var ch = discord.Channel{
+		ID: 2,
+		Permissions: []discord.Overwrite{
+			{ID: 3, Type: discord.OverwriteRole, Allow: discord.PermissionSendMessages},
+			{ID: 1, Type: discord.OverwriteRole, Allow: discord.PermissionSendMessages | discord.PermissionAddReactions},
+		},
+	}
+
+	old := everyoneOverwrite(ch, guildID)
+	if old == nil || old.ID != 1 {
+		t.Fatal("Unexpected @everyone overwrite:", old)
+	}
+
+	locked, changed := lockOverwrite(old, guildID, discord.PermissionSendMessages)
+	if !changed {
+		t.Fatal("Overwrite is not locked")
+	}
+	if locked.Allow != discord.PermissionAddReactions || locked.Deny != discord.PermissionSendMessages {
+		t.Fatal("Unexpected locked overwrite:", locked)
+	}
+	if _, changed := lockOverwrite(&locked, guildID, discord.PermissionSendMessages); changed {
+		t.Fatal("Locked overwrite is locked again")
+	}
+
+	// A channel created during the lockdown should lose the overwrite if it
+	// only has the denied permissions.
+	created, _ := lockOverwrite(nil, guildID, discord.PermissionSendMessages)
+	if o := unlockOverwrite(&created, discord.PermissionSendMessages); o != nil {
+		t.Fatal("Unexpected unlocked overwrite:", o)
+	}
+	if o := unlockOverwrite(&locked, discord.PermissionSendMessages); o == nil || o.Deny != 0 {
+		t.Fatal("Unexpected unlocked overwrite:", o)
+	}
+}
+
+func TestLockdownPartialFailure(t *testing.T) {
+	var everyone = discord.Overwrite{
+		ID:   1,
+		Type: discord.OverwriteRole,
+		Deny: discord.PermissionSendMessages,
+	}
+
+	var chs = []discord.Channel{
+		{ID: 2, Type: discord.GuildText},
+		{ID: 3, Type: discord.GuildText},
+		// An announcement channel that was read-only before the lockdown.
+		{ID: 4, Type: discord.GuildNews, Permissions: []discord.Overwrite{everyone}},
+	}
+
+	var edited []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, APIPath); {
+		case r.Method == "GET" && path == "/guilds/1/channels":
+			json.NewEncoder(w).Encode(chs)
+		case path == "/channels/2/permissions/1":
+			edited = append(edited, r.Method+" "+path)
+			w.WriteHeader(http.StatusNoContent)
+		case path == "/channels/3/permissions/1":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Error("Unexpected request:", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+	client.Retries = 1
+
+	snapshot, err := client.Lockdown(1)
+	if err == nil {
+		t.Fatal("Unexpected success locking channel 3")
+	}
+	if len(snapshot.Overwrites) != 3 || snapshot.LastChannelID != 4 {
+		t.Fatal("Snapshot is missing channels:", snapshot)
+	}
+	if o := snapshot.Overwrites[4]; o == nil || *o != everyone {
+		t.Fatal("Unexpected overwrite of channel 4:", o)
+	}
+
+	// Channel 2 was locked, so Discord now has its overwrite.
+	chs[0].Permissions = []discord.Overwrite{everyone}
+	edited = nil
+
+	if err := client.RestoreLockdown(*snapshot); err != nil {
+		t.Fatal("Failed to restore:", err)
+	}
+
+	// Only channel 2 should be unlocked. Channel 4 keeps its deny.
+	if len(edited) != 1 || edited[0] != "DELETE /channels/2/permissions/1" {
+		t.Fatal("Unexpected edits:", edited)
+	}
+}
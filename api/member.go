@@ -1,13 +1,18 @@
 package api
 
 import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
 	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/utils/httputil"
 	"github.com/diamondburned/arikawa/utils/json/option"
 )
 
 // Member returns a guild member object for the specified user..
-func (c *Client) Member(guildID, userID discord.Snowflake) (*discord.Member, error) {
+func (c *Client) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	var m *discord.Member
 	return m, c.RequestJSON(&m, "GET", EndpointGuilds+guildID.String()+"/members/"+userID.String())
 }
@@ -21,7 +26,7 @@ func (c *Client) Member(guildID, userID discord.Snowflake) (*discord.Member, err
 // they may be less, if no more members are available.
 //
 // When fetching the members, those with the smallest ID will be fetched first.
-func (c *Client) Members(guildID discord.Snowflake, limit uint) ([]discord.Member, error) {
+func (c *Client) Members(guildID discord.GuildID, limit uint) ([]discord.Member, error) {
 	return c.MembersAfter(guildID, 0, limit)
 }
 
@@ -33,7 +38,7 @@ func (c *Client) Members(guildID discord.Snowflake, limit uint) ([]discord.Membe
 // maximum a total of limit/1000 rounded up requests will be made, although
 // they may be less, if no more members are available.
 func (c *Client) MembersAfter(
-	guildID, after discord.Snowflake, limit uint) ([]discord.Member, error) {
+	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Member, error) {
 
 	var mems []discord.Member
 
@@ -56,18 +61,18 @@ func (c *Client) MembersAfter(
 		mems = append(mems, m...)
 
 		// There aren't any to fetch, even if this is less than limit.
-		if len(mems) < hardLimit {
+		if len(m) < hardLimit {
 			break
 		}
 
-		after = mems[hardLimit-1].User.ID
+		after = m[hardLimit-1].User.ID
 	}
 
 	return mems, nil
 }
 
 func (c *Client) membersAfter(
-	guildID, after discord.Snowflake, limit uint) ([]discord.Member, error) {
+	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Member, error) {
 
 	switch {
 	case limit == 0:
@@ -77,8 +82,8 @@ func (c *Client) membersAfter(
 	}
 
 	var param struct {
-		After discord.Snowflake `schema:"after,omitempty"`
-		Limit uint              `schema:"limit"`
+		After discord.UserID `schema:"after,omitempty"`
+		Limit uint           `schema:"limit"`
 	}
 
 	param.Limit = limit
@@ -92,6 +97,39 @@ func (c *Client) membersAfter(
 	)
 }
 
+// SearchMembers returns up to limit members of the guild whose username or
+// nickname starts with the query. The limit is between 1 and 1000, and it
+// defaults to 1 if it's 0.
+//
+// Unlike Members, this doesn't require the GUILD_MEMBERS privileged intent,
+// which makes it the way for bots without it to look up members by name
+// besides the gateway's RequestGuildMembers.
+func (c *Client) SearchMembers(
+	guildID discord.GuildID, query string, limit uint) ([]discord.Member, error) {
+
+	switch {
+	case limit == 0:
+		limit = 1
+	case limit > 1000:
+		limit = 1000
+	}
+
+	var param struct {
+		Query string `schema:"query"`
+		Limit uint   `schema:"limit"`
+	}
+
+	param.Query = query
+	param.Limit = limit
+
+	var mems []discord.Member
+	return mems, c.RequestJSON(
+		&mems, "GET",
+		EndpointGuilds+guildID.String()+"/members/search",
+		httputil.WithSchema(c, param),
+	)
+}
+
 // https://discord.com/developers/docs/resources/guild#add-guild-member-json-params
 type AddMemberData struct {
 	// Token is an oauth2 access token granted with the guilds.join to the
@@ -104,7 +142,7 @@ type AddMemberData struct {
 	// Roles is an array of role ids the member is assigned.
 	//
 	// Requires MANAGE_ROLES.
-	Roles *[]discord.Snowflake `json:"roles,omitempty"`
+	Roles *[]discord.RoleID `json:"roles,omitempty"`
 	// Mute specifies whether the user is muted in voice channels.
 	//
 	// Requires MUTE_MEMBERS.
@@ -126,7 +164,7 @@ type AddMemberData struct {
 // application used for authorization), and the bot must be a member of the
 // guild with CREATE_INSTANT_INVITE permission.
 func (c *Client) AddMember(
-	guildID, userID discord.Snowflake, data AddMemberData) (*discord.Member, error) {
+	guildID discord.GuildID, userID discord.UserID, data AddMemberData) (*discord.Member, error) {
 	var mem *discord.Member
 	return mem, c.RequestJSON(
 		&mem, "PUT",
@@ -135,7 +173,7 @@ func (c *Client) AddMember(
 	)
 }
 
-// https://discord.com/developers/docs/resources/guild#add-guild-member-json-params
+// https://discord.com/developers/docs/resources/guild#modify-guild-member-json-params
 type ModifyMemberData struct {
 	// Nick is the value to set users nickname to.
 	//
@@ -144,7 +182,7 @@ type ModifyMemberData struct {
 	// Roles is an array of role ids the member is assigned.
 	//
 	// Requires MANAGE_ROLES.
-	Roles *[]discord.Snowflake `json:"roles,omitempty"`
+	Roles *[]discord.RoleID `json:"roles,omitempty"`
 	// Mute specifies whether the user is muted in voice channels.
 	//
 	// Requires MUTE_MEMBERS.
@@ -155,17 +193,30 @@ type ModifyMemberData struct {
 	Deaf option.Bool `json:"deaf,omitempty"`
 
 	// Voice channel is the id of channel to move user to (if they are
-	// connected to voice).
+	// connected to voice). Use option.NullSnowflake to disconnect the user
+	// from voice.
 	//
 	// Requires MOVE_MEMBER
-	VoiceChannel discord.Snowflake `json:"channel_id,omitempty"`
+	VoiceChannel option.NullableSnowflake `json:"channel_id,omitempty"`
+
+	// CommunicationDisabledUntil is when the member's timeout ends, up to
+	// MaxTimeout in the future. An invalid timestamp removes the timeout.
+	//
+	// Requires MODERATE_MEMBERS.
+	CommunicationDisabledUntil *discord.Timestamp `json:"communication_disabled_until,omitempty"`
 }
 
+// MaxTimeout is the longest that a member can be timed out for.
+const MaxTimeout = 28 * 24 * time.Hour
+
 // ModifyMember modifies attributes of a guild member. If the channel_id is set
 // to null, this will force the target user to be disconnected from voice.
 //
 // Fires a Guild Member Update Gateway event.
-func (c *Client) ModifyMember(guildID, userID discord.Snowflake, data ModifyMemberData) error {
+func (c *Client) ModifyMember(guildID discord.GuildID, userID discord.UserID, data ModifyMemberData) error {
+	if err := c.validate(data); err != nil {
+		return err
+	}
 
 	return c.FastRequest(
 		"PATCH",
@@ -174,20 +225,53 @@ func (c *Client) ModifyMember(guildID, userID discord.Snowflake, data ModifyMemb
 	)
 }
 
+// TimeoutMember times out the member until the given time, during which they
+// can't send messages, react or speak. The time can be up to MaxTimeout in the
+// future, and the zero time removes the timeout.
+//
+// Requires the MODERATE_MEMBERS permission. The member can't be the guild
+// owner, nor have a role above the bot's highest role.
+//
+// Fires a Guild Member Update Gateway event.
+func (c *Client) TimeoutMember(
+	guildID discord.GuildID, userID discord.UserID, until time.Time) error {
+
+	var ts = discord.NewTimestamp(until)
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		CommunicationDisabledUntil: &ts,
+	})
+}
+
+// https://discord.com/developers/docs/resources/guild#get-guild-prune-count-query-string-params
+type PruneCountData struct {
+	// Days is the number of days of inactivity after which a member is pruned
+	// (1-30). It defaults to 7 if it's 0.
+	Days uint
+	// IncludedRoles are the roles whose members are pruned as well. By
+	// default, only members without roles are pruned.
+	IncludedRoles []discord.RoleID
+}
+
 // PruneCount returns the number of members that would be removed in a prune
-// operation. Days must be 1 or more, default 7.
+// operation.
 //
 // Requires KICK_MEMBERS.
-func (c *Client) PruneCount(guildID discord.Snowflake, days uint) (uint, error) {
-	if days == 0 {
-		days = 7
+func (c *Client) PruneCount(guildID discord.GuildID, data PruneCountData) (uint, error) {
+	if err := c.validate(data); err != nil {
+		return 0, err
+	}
+
+	if data.Days == 0 {
+		data.Days = 7
 	}
 
 	var param struct {
-		Days uint `schema:"days"`
+		Days         uint   `schema:"days"`
+		IncludeRoles string `schema:"include_roles,omitempty"`
 	}
 
-	param.Days = days
+	param.Days = data.Days
+	param.IncludeRoles = joinRoleIDs(data.IncludedRoles)
 
 	var resp struct {
 		Pruned uint `json:"pruned"`
@@ -200,46 +284,42 @@ func (c *Client) PruneCount(guildID discord.Snowflake, days uint) (uint, error)
 	)
 }
 
-// Prune begins a prune. Days must be 1 or more, default 7.
-//
-// Requires KICK_MEMBERS.
-func (c *Client) Prune(guildID discord.Snowflake, days uint) error {
-	if days == 0 {
-		days = 7
+// joinRoleIDs joins the IDs with commas, as query strings take lists.
+func joinRoleIDs(ids []discord.RoleID) string {
+	var strs = make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
 	}
+	return strings.Join(strs, ",")
+}
 
-	var param struct {
-		Days     uint `schema:"days"`
-		RetCount bool `schema:"compute_prune_count"`
-	}
-
-	param.Days = days
-	param.RetCount = false
-
-	return c.FastRequest(
-		"POST",
-		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, param),
-	)
+// https://discord.com/developers/docs/resources/guild#begin-guild-prune-json-params
+type PruneData struct {
+	// Days is the number of days of inactivity after which a member is pruned
+	// (1-30). It defaults to 7 if it's 0.
+	Days uint `json:"days"`
+	// ReturnCount specifies whether the number of pruned members is returned.
+	// This is discouraged for large guilds.
+	ReturnCount bool `json:"compute_prune_count"`
+	// IncludedRoles are the roles whose members are pruned as well. By
+	// default, only members without roles are pruned.
+	IncludedRoles []discord.RoleID `json:"include_roles,omitempty"`
 }
 
-// PruneWithCounts returns the number of members that is removed. Days must be 1 or more,
-// default 7.
+// Prune begins a prune, and returns the number of members that were removed
+// if data.ReturnCount is true, or 0 otherwise.
 //
 // Requires KICK_MEMBERS.
-func (c *Client) PruneWithCount(guildID discord.Snowflake, days uint) (uint, error) {
-	if days == 0 {
-		days = 7
+// Fires multiple Guild Member Remove Gateway events.
+func (c *Client) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
+	if err := c.validate(data); err != nil {
+		return 0, err
 	}
 
-	var param struct {
-		Days     uint `schema:"days"`
-		RetCount bool `schema:"compute_prune_count"`
+	if data.Days == 0 {
+		data.Days = 7
 	}
 
-	param.Days = days
-	param.RetCount = true
-
 	var resp struct {
 		Pruned uint `json:"pruned"`
 	}
@@ -247,15 +327,23 @@ func (c *Client) PruneWithCount(guildID discord.Snowflake, days uint) (uint, err
 	return resp.Pruned, c.RequestJSON(
 		&resp, "POST",
 		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, param),
+		httputil.WithJSONBody(data),
 	)
 }
 
+// PruneWithCount begins a prune like Prune, and returns the number of members
+// that were removed.
+//
+// Deprecated: Use Prune with ReturnCount instead.
+func (c *Client) PruneWithCount(guildID discord.GuildID, days uint) (uint, error) {
+	return c.Prune(guildID, PruneData{Days: days, ReturnCount: true})
+}
+
 // Kick removes a member from a guild.
 //
 // Requires KICK_MEMBERS permission.
 // Fires a Guild Member Remove Gateway event.
-func (c *Client) Kick(guildID, userID discord.Snowflake) error {
+func (c *Client) Kick(guildID discord.GuildID, userID discord.UserID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointGuilds+guildID.String()+"/members/"+userID.String(),
@@ -263,20 +351,137 @@ func (c *Client) Kick(guildID, userID discord.Snowflake) error {
 }
 
 // Bans returns a list of ban objects for the users banned from this guild.
+// This method automatically paginates until it has fetched all bans.
+//
+// Requires the BAN_MEMBERS permission.
+func (c *Client) Bans(guildID discord.GuildID) ([]discord.Ban, error) {
+	return c.BansAfter(guildID, 0, 0)
+}
+
+// BansBefore returns a list of ban objects for the users banned from this
+// guild with a user ID smaller than the passed one. This method automatically
+// paginates until it reaches the passed limit, or, if the limit is set to 0,
+// has fetched all bans within the passed range.
+//
+// As the underlying endpoint has a maximum of 1000 bans per request, at
+// maximum a total of limit/1000 rounded up requests will be made, although
+// they may be less, if no more bans are available.
+//
+// The bans right before the passed ID are fetched first, but they are sorted
+// by user ID.
+//
+// Requires the BAN_MEMBERS permission.
+func (c *Client) BansBefore(
+	guildID discord.GuildID, before discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	var bans []discord.Ban
+
+	const hardLimit int = 1000
+
+	unlimited := limit == 0
+
+	for fetch := uint(hardLimit); limit > 0 || unlimited; fetch = uint(hardLimit) {
+		if limit > 0 {
+			if fetch > limit {
+				fetch = limit
+			}
+			limit -= fetch
+		}
+
+		b, err := c.bansRange(guildID, before, 0, fetch)
+		if err != nil {
+			return bans, err
+		}
+		bans = append(b, bans...)
+
+		if len(b) < hardLimit {
+			break
+		}
+
+		before = b[0].User.ID
+	}
+
+	return bans, nil
+}
+
+// BansAfter returns a list of ban objects for the users banned from this guild
+// with a user ID higher than the passed one. This method automatically
+// paginates until it reaches the passed limit, or, if the limit is set to 0,
+// has fetched all bans within the passed range.
+//
+// As the underlying endpoint has a maximum of 1000 bans per request, at
+// maximum a total of limit/1000 rounded up requests will be made, although
+// they may be less, if no more bans are available.
+//
+// When fetching the bans, those with the smallest user ID will be fetched
+// first.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) Bans(guildID discord.Snowflake) ([]discord.Ban, error) {
+func (c *Client) BansAfter(
+	guildID discord.GuildID, after discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	var bans []discord.Ban
+
+	const hardLimit int = 1000
+
+	unlimited := limit == 0
+
+	for fetch := uint(hardLimit); limit > 0 || unlimited; fetch = uint(hardLimit) {
+		if limit > 0 {
+			if fetch > limit {
+				fetch = limit
+			}
+			limit -= fetch
+		}
+
+		b, err := c.bansRange(guildID, 0, after, fetch)
+		if err != nil {
+			return bans, err
+		}
+		bans = append(bans, b...)
+
+		if len(b) < hardLimit {
+			break
+		}
+
+		after = b[hardLimit-1].User.ID
+	}
+
+	return bans, nil
+}
+
+// bansRange gets the bans of users before and after IDs. Before, after, and
+// limit are optional. A maximum limit of only 1000 bans could be returned.
+func (c *Client) bansRange(
+	guildID discord.GuildID, before, after discord.UserID, limit uint) ([]discord.Ban, error) {
+
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	var param struct {
+		Before discord.UserID `schema:"before,omitempty"`
+		After  discord.UserID `schema:"after,omitempty"`
+
+		Limit uint `schema:"limit,omitempty"`
+	}
+
+	param.Before = before
+	param.After = after
+	param.Limit = limit
+
 	var bans []discord.Ban
 	return bans, c.RequestJSON(
 		&bans, "GET",
 		EndpointGuilds+guildID.String()+"/bans",
+		httputil.WithSchema(c, param),
 	)
 }
 
 // GetBan returns a ban object for the given user.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) GetBan(guildID, userID discord.Snowflake) (*discord.Ban, error) {
+func (c *Client) GetBan(guildID discord.GuildID, userID discord.UserID) (*discord.Ban, error) {
 	var ban *discord.Ban
 	return ban, c.RequestJSON(
 		&ban, "GET",
@@ -284,34 +489,136 @@ func (c *Client) GetBan(guildID, userID discord.Snowflake) (*discord.Ban, error)
 	)
 }
 
-// https://discord.com/developers/docs/resources/guild#create-guild-ban-query-string-params
+// https://discord.com/developers/docs/resources/guild#create-guild-ban-json-params
 type BanData struct {
-	// DeleteDays is the number of days to delete messages for (0-7).
-	DeleteDays option.Uint `schema:"delete_message_days,omitempty"`
-	// Reason is the reason for the ban.
-	Reason option.String `schema:"reason,omitempty"`
+	// DeleteDays is the number of days to delete messages for (0-7). Only
+	// one of DeleteDays and DeleteSeconds may be set. It's sent as
+	// DeleteSeconds.
+	//
+	// Deprecated: API v10 only accepts DeleteSeconds.
+	DeleteDays option.Uint `json:"-"`
+	// DeleteSeconds is the number of seconds to delete messages for, up to 7
+	// days (0-604800).
+	DeleteSeconds option.Seconds `json:"delete_message_seconds,omitempty"`
+	// Reason is the reason for the ban. It's sent as the audit log reason.
+	Reason option.String `json:"-"`
 }
 
+// maxBanDeleteSeconds is the longest that the messages of a banned user can
+// be deleted for, which is 7 days.
+const maxBanDeleteSeconds discord.Seconds = 7 * 24 * 60 * 60
+
 // Ban creates a guild ban, and optionally delete previous messages sent by the
-// banned user.
+// banned user. If ValidateRequests is false, out of range durations are clamped
+// to 0-7 days instead of being rejected.
 //
 // Requires the BAN_MEMBERS permission.
-func (c *Client) Ban(guildID, userID discord.Snowflake, data BanData) error {
-	if *data.DeleteDays > 7 {
-		*data.DeleteDays = 7
+func (c *Client) Ban(guildID discord.GuildID, userID discord.UserID, data BanData) error {
+	if err := c.validate(data); err != nil {
+		return err
+	}
+
+	// Out of range values are clamped into a new option, so that the caller's
+	// pointers are never written to.
+	if data.DeleteDays != nil && data.DeleteSeconds == nil {
+		var days = *data.DeleteDays
+		if days > 7 {
+			days = 7
+		}
+		data.DeleteSeconds = option.NewSeconds(discord.Seconds(days) * 24 * 60 * 60)
+	}
+	if data.DeleteSeconds != nil {
+		switch seconds := *data.DeleteSeconds; {
+		case seconds < 0:
+			data.DeleteSeconds = nil
+		case seconds > maxBanDeleteSeconds:
+			data.DeleteSeconds = option.NewSeconds(maxBanDeleteSeconds)
+		}
+	}
+
+	var opts = []httputil.RequestOption{httputil.WithJSONBody(data)}
+	if data.Reason != nil {
+		opts = append(opts, httputil.WithAuditLogReason(*data.Reason))
 	}
 
 	return c.FastRequest(
 		"PUT",
 		EndpointGuilds+guildID.String()+"/bans/"+userID.String(),
-		httputil.WithSchema(c, data),
+		opts...,
 	)
 }
 
+// BulkBanLimit is the maximum number of users that can be banned in one bulk
+// ban request.
+const BulkBanLimit = 200
+
+// https://discord.com/developers/docs/resources/guild#bulk-guild-ban-json-params
+type BulkBanData struct {
+	// DeleteSeconds is the number of seconds to delete messages for, up to 7
+	// days (0-604800).
+	DeleteSeconds option.Seconds `json:"delete_message_seconds,omitempty"`
+}
+
+// BulkBanResponse is the result of a BulkBan.
+type BulkBanResponse struct {
+	// Banned are the users that were banned.
+	Banned []discord.UserID `json:"banned_users"`
+	// Failed are the users that couldn't be banned, such as users that were
+	// already banned or who are above the bot in the role hierarchy.
+	Failed []discord.UserID `json:"failed_users"`
+}
+
+// BulkBan bans all the users, and optionally deletes their previous messages.
+// The users are banned in requests of BulkBanLimit users, and the results of
+// every request are merged. If a request fails, the results of the requests
+// made so far are returned along with the error, and the rest of the users
+// are neither banned nor failed.
+//
+// Requires the BAN_MEMBERS and MANAGE_GUILD permissions.
+// Fires a Guild Ban Add Gateway event for every banned user.
+func (c *Client) BulkBan(
+	guildID discord.GuildID, userIDs []discord.UserID,
+	data BulkBanData) (*BulkBanResponse, error) {
+
+	if err := c.validate(data); err != nil {
+		return nil, err
+	}
+
+	var resp = &BulkBanResponse{}
+
+	for start := 0; start < len(userIDs); start += BulkBanLimit {
+		var end = start + BulkBanLimit
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+
+		var body = struct {
+			UserIDs []discord.UserID `json:"user_ids"`
+			BulkBanData
+		}{userIDs[start:end], data}
+
+		var chunk BulkBanResponse
+
+		err := c.RequestJSON(
+			&chunk, "POST",
+			EndpointGuilds+guildID.String()+"/bulk-ban",
+			httputil.WithJSONBody(body),
+		)
+		if err != nil {
+			return resp, errors.Wrapf(err, "failed to ban users %d to %d", start, end-1)
+		}
+
+		resp.Banned = append(resp.Banned, chunk.Banned...)
+		resp.Failed = append(resp.Failed, chunk.Failed...)
+	}
+
+	return resp, nil
+}
+
 // Unban removes the ban for a user.
 //
 // Requires the BAN_MEMBERS permissions.
 // Fires a Guild Ban Remove Gateway event.
-func (c *Client) Unban(guildID, userID discord.Snowflake) error {
+func (c *Client) Unban(guildID discord.GuildID, userID discord.UserID) error {
 	return c.FastRequest("DELETE", EndpointGuilds+guildID.String()+"/bans/"+userID.String())
 }
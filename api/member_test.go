@@ -0,0 +1,330 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json"
+	"github.com/diamondburned/arikawa/utils/json/option"
+)
+
+// banServer serves the bans of the user IDs 1 to n like Discord does: up to
+// limit bans right before or after the given ID, sorted by user ID.
+func banServer(t *testing.T, n int) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q = r.URL.Query()
+		var param = func(name string, def int) int {
+			if q.Get(name) == "" {
+				return def
+			}
+			v, err := strconv.Atoi(q.Get(name))
+			if err != nil {
+				t.Error("Invalid "+name+":", err)
+			}
+			return v
+		}
+
+		var limit = param("limit", 1000)
+		var before = param("before", n+1)
+		var after = param("after", 0)
+
+		var bans []discord.Ban
+		if q.Get("before") != "" {
+			for id := before - 1; id > 0 && len(bans) < limit; id-- {
+				bans = append([]discord.Ban{{User: discord.User{ID: discord.UserID(id)}}}, bans...)
+			}
+		} else {
+			for id := after + 1; id <= n && len(bans) < limit; id++ {
+				bans = append(bans, discord.Ban{User: discord.User{ID: discord.UserID(id)}})
+			}
+		}
+
+		b, err := json.Marshal(bans)
+		if err != nil {
+			t.Error("Failed to marshal bans:", err)
+		}
+		w.Write(b)
+	}))
+
+	return NewClient("no. 3-chan").WithBaseURL(srv.URL), srv.Close
+}
+
+func expectBans(t *testing.T, bans []discord.Ban, first, last int) {
+	t.Helper()
+
+	if len(bans) != last-first+1 {
+		t.Fatalf("Got %d bans, expected %d", len(bans), last-first+1)
+	}
+
+	for i, ban := range bans {
+		if ban.User.ID != discord.UserID(first+i) {
+			t.Fatalf("Ban %d is of user %d, expected %d", i, ban.User.ID, first+i)
+		}
+	}
+}
+
+func TestBansPagination(t *testing.T) {
+	client, done := banServer(t, 2500)
+	defer done()
+
+	bans, err := client.Bans(1)
+	if err != nil {
+		t.Fatal("Failed to get bans:", err)
+	}
+	expectBans(t, bans, 1, 2500)
+
+	bans, err = client.BansAfter(1, 100, 1500)
+	if err != nil {
+		t.Fatal("Failed to get bans after:", err)
+	}
+	expectBans(t, bans, 101, 1600)
+
+	bans, err = client.BansBefore(1, 2401, 2000)
+	if err != nil {
+		t.Fatal("Failed to get bans before:", err)
+	}
+	expectBans(t, bans, 401, 2400)
+}
+
+func TestSearchMembers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != APIPath+"/guilds/1/members/search" {
+			t.Error("Unexpected path:", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("query") != "ari kawa" || q.Get("limit") != "1" {
+			t.Error("Unexpected query:", r.URL.RawQuery)
+		}
+
+		w.Write([]byte(`[{"user":{"id":"1337","username":"ari kawa"}}]`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	mems, err := client.SearchMembers(1, "ari kawa", 0)
+	if err != nil {
+		t.Fatal("Failed to search members:", err)
+	}
+
+	if len(mems) != 1 || mems[0].User.ID != 1337 {
+		t.Fatal("Unexpected members:", mems)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if q := r.URL.Query(); q.Get("days") != "7" || q.Get("include_roles") != "2,3" {
+				t.Error("Unexpected query:", r.URL.RawQuery)
+			}
+
+		case "POST":
+			var data PruneData
+			if err := json.DecodeStream(r.Body, &data); err != nil {
+				t.Error("Failed to decode body:", err)
+			}
+
+			if data.Days != 14 || !data.ReturnCount ||
+				len(data.IncludedRoles) != 1 || data.IncludedRoles[0] != 2 {
+
+				t.Error("Unexpected body:", data)
+			}
+		}
+
+		w.Write([]byte(`{"pruned":42}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	n, err := client.PruneCount(1, PruneCountData{IncludedRoles: []discord.RoleID{2, 3}})
+	if err != nil || n != 42 {
+		t.Fatal("Unexpected prune count:", n, err)
+	}
+
+	n, err = client.Prune(1, PruneData{Days: 14, ReturnCount: true, IncludedRoles: []discord.RoleID{2}})
+	if err != nil || n != 42 {
+		t.Fatal("Unexpected pruned members:", n, err)
+	}
+}
+
+func TestBulkBan(t *testing.T) {
+	var requests []int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			UserIDs       []discord.UserID `json:"user_ids"`
+			DeleteSeconds discord.Seconds  `json:"delete_message_seconds"`
+		}
+		if err := json.DecodeStream(r.Body, &body); err != nil {
+			t.Error("Failed to decode body:", err)
+		}
+		if body.DeleteSeconds != 3600 {
+			t.Error("Unexpected delete seconds:", body.DeleteSeconds)
+		}
+
+		requests = append(requests, len(body.UserIDs))
+
+		// Ban the even user IDs only.
+		var resp BulkBanResponse
+		for _, id := range body.UserIDs {
+			if id%2 == 0 {
+				resp.Banned = append(resp.Banned, id)
+			} else {
+				resp.Failed = append(resp.Failed, id)
+			}
+		}
+
+		b, err := json.Marshal(resp)
+		if err != nil {
+			t.Error("Failed to marshal response:", err)
+		}
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	var ids = make([]discord.UserID, 450)
+	for i := range ids {
+		ids[i] = discord.UserID(i + 1)
+	}
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	resp, err := client.BulkBan(1, ids, BulkBanData{DeleteSeconds: option.NewSeconds(3600)})
+	if err != nil {
+		t.Fatal("Failed to bulk ban:", err)
+	}
+
+	if len(requests) != 3 || requests[0] != 200 || requests[1] != 200 || requests[2] != 50 {
+		t.Fatal("Unexpected requests:", requests)
+	}
+	if len(resp.Banned) != 225 || len(resp.Failed) != 225 || resp.Banned[224] != 450 {
+		t.Fatal("Unexpected result:", len(resp.Banned), len(resp.Failed))
+	}
+}
+
+func TestModifyMemberVoiceChannel(t *testing.T) {
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read body:", err)
+		}
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	var tests = []struct {
+		data   ModifyMemberData
+		expect string
+	}{
+		{ModifyMemberData{Nick: option.NewString("ari")}, `{"nick":"ari"}`},
+		{ModifyMemberData{VoiceChannel: option.NewNullableSnowflake(2)}, `{"channel_id":"2"}`},
+		{ModifyMemberData{VoiceChannel: option.NullSnowflake}, `{"channel_id":null}`},
+	}
+
+	for _, test := range tests {
+		if err := client.ModifyMember(1, 1337, test.data); err != nil {
+			t.Fatal("Failed to modify member:", err)
+		}
+	}
+
+	for i, test := range tests {
+		if body := strings.TrimSpace(bodies[i]); body != test.expect {
+			t.Fatalf("Unexpected body %d: %s", i, body)
+		}
+	}
+}
+
+func TestBan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Error("Unexpected query:", r.URL.RawQuery)
+		}
+		if reason := r.Header.Get("X-Audit-Log-Reason"); reason != "spam" {
+			t.Error("Unexpected reason:", reason)
+		}
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read body:", err)
+		}
+		if body := strings.TrimSpace(string(b)); body != `{"delete_message_seconds":172800}` {
+			t.Error("Unexpected body:", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	err := client.Ban(1, 1337, BanData{
+		DeleteDays: option.NewUint(2),
+		Reason:     option.NewString("spam"),
+	})
+	if err != nil {
+		t.Fatal("Failed to ban:", err)
+	}
+}
+
+func TestBanClamp(t *testing.T) {
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read body:", err)
+		}
+		bodies = append(bodies, strings.TrimSpace(string(b)))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	var tests = []struct {
+		data   BanData
+		expect string
+	}{
+		{BanData{DeleteDays: option.NewUint(8)}, `{"delete_message_seconds":604800}`},
+		{BanData{DeleteSeconds: option.NewSeconds(604801)}, `{"delete_message_seconds":604800}`},
+		{BanData{DeleteSeconds: option.NewSeconds(-1)}, `{}`},
+	}
+
+	for i, test := range tests {
+		var days, seconds = test.data.DeleteDays, test.data.DeleteSeconds
+		if days != nil {
+			days = option.NewUint(*days)
+		}
+		if seconds != nil {
+			seconds = option.NewSeconds(*seconds)
+		}
+
+		if err := client.Ban(1, 1337, test.data); err != nil {
+			t.Fatal("Failed to ban:", err)
+		}
+		if body := bodies[i]; body != test.expect {
+			t.Fatalf("Unexpected body %d: %s", i, body)
+		}
+
+		// The caller's options must not be changed.
+		if days != nil && *days != *test.data.DeleteDays {
+			t.Fatal("DeleteDays was changed to", *test.data.DeleteDays)
+		}
+		if seconds != nil && *seconds != *test.data.DeleteSeconds {
+			t.Fatal("DeleteSeconds was changed to", *test.data.DeleteSeconds)
+		}
+	}
+}
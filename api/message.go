@@ -8,35 +8,39 @@ import (
 
 // Messages returns a list of messages sent in the channel with the passed ID.
 // This method automatically paginates until it reaches the passed limit, or,
-// if the limit is set to 0, has fetched all guilds within the passed ange.
+// if the limit is set to 0, has fetched all messages in the channel.
 //
 // As the underlying endpoint has a maximum of 100 messages per request, at
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more messages are available.
 //
-// When fetching the messages, those with the smallest ID will be fetched
-// first.
-func (c *Client) Messages(channelID discord.Snowflake, limit uint) ([]discord.Message, error) {
-	return c.MessagesAfter(channelID, 0, limit)
+// When fetching the messages, those with the highest ID will be fetched
+// first. The messages are sorted from newest to oldest. To go through many
+// messages without keeping all of them in memory, use IterateMessages.
+func (c *Client) Messages(channelID discord.ChannelID, limit uint) ([]discord.Message, error) {
+	return c.MessagesBefore(channelID, 0, limit)
 }
 
 // MessagesAround returns messages around the ID, with a limit of 100.
 func (c *Client) MessagesAround(
-	channelID, around discord.Snowflake, limit uint) ([]discord.Message, error) {
+	channelID discord.ChannelID, around discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	return c.messagesRange(channelID, 0, 0, around, limit)
 }
 
 // MessagesBefore returns a list messages sent in the channel with the passed
 // ID. This method automatically paginates until it reaches the passed limit,
-// or, if the limit is set to 0, has fetched all guilds within the passed
+// or, if the limit is set to 0, has fetched all messages within the passed
 // range.
 //
 // As the underlying endpoint has a maximum of 100 messages per request, at
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more messages are available.
+//
+// The messages right before the passed ID are fetched first, and they are
+// sorted from newest to oldest.
 func (c *Client) MessagesBefore(
-	channelID, before discord.Snowflake, limit uint) ([]discord.Message, error) {
+	channelID discord.ChannelID, before discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	var msgs []discord.Message
 
@@ -57,13 +61,14 @@ func (c *Client) MessagesBefore(
 		if err != nil {
 			return msgs, err
 		}
-		msgs = append(m, msgs...)
+		msgs = append(msgs, m...)
 
 		if len(m) < hardLimit {
 			break
 		}
 
-		before = m[0].Author.ID
+		// Discord returns the newest messages first.
+		before = m[len(m)-1].ID
 	}
 
 	return msgs, nil
@@ -71,14 +76,17 @@ func (c *Client) MessagesBefore(
 
 // MessagesAfter returns a list messages sent in the channel with the passed
 // ID. This method automatically paginates until it reaches the passed limit,
-// or, if the limit is set to 0, has fetched all guilds within the passed
+// or, if the limit is set to 0, has fetched all messages within the passed
 // range.
 //
 // As the underlying endpoint has a maximum of 100 messages per request, at
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more messages are available.
+//
+// The messages right after the passed ID are fetched first, but like with
+// MessagesBefore, they are sorted from newest to oldest.
 func (c *Client) MessagesAfter(
-	channelID, after discord.Snowflake, limit uint) ([]discord.Message, error) {
+	channelID discord.ChannelID, after discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	var msgs []discord.Message
 
@@ -99,20 +107,21 @@ func (c *Client) MessagesAfter(
 		if err != nil {
 			return msgs, err
 		}
-		msgs = append(msgs, m...)
+		msgs = append(m, msgs...)
 
 		if len(m) < hardLimit {
 			break
 		}
 
-		after = m[hardLimit-1].Author.ID
+		// Discord returns the newest messages first.
+		after = m[0].ID
 	}
 
 	return msgs, nil
 }
 
 func (c *Client) messagesRange(
-	channelID, before, after, around discord.Snowflake, limit uint) ([]discord.Message, error) {
+	channelID discord.ChannelID, before, after, around discord.MessageID, limit uint) ([]discord.Message, error) {
 
 	switch {
 	case limit == 0:
@@ -122,9 +131,9 @@ func (c *Client) messagesRange(
 	}
 
 	var param struct {
-		Before discord.Snowflake `schema:"before,omitempty"`
-		After  discord.Snowflake `schema:"after,omitempty"`
-		Around discord.Snowflake `schema:"around,omitempty"`
+		Before discord.MessageID `schema:"before,omitempty"`
+		After  discord.MessageID `schema:"after,omitempty"`
+		Around discord.MessageID `schema:"around,omitempty"`
 
 		Limit uint `schema:"limit"`
 	}
@@ -146,7 +155,7 @@ func (c *Client) messagesRange(
 //
 // If operating on a guild channel, this endpoint requires the
 // READ_MESSAGE_HISTORY permission to be present on the current user.
-func (c *Client) Message(channelID, messageID discord.Snowflake) (*discord.Message, error) {
+func (c *Client) Message(channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 	var msg *discord.Message
 	return msg, c.RequestJSON(&msg, "GET",
 		EndpointChannels+channelID.String()+"/messages/"+messageID.String())
@@ -158,7 +167,7 @@ func (c *Client) Message(channelID, messageID discord.Snowflake) (*discord.Messa
 // permission to be present on the current user.
 //
 // Fires a Message Create Gateway event.
-func (c *Client) SendText(channelID discord.Snowflake, content string) (*discord.Message, error) {
+func (c *Client) SendText(channelID discord.ChannelID, content string) (*discord.Message, error) {
 	return c.SendMessageComplex(channelID, SendMessageData{
 		Content: content,
 	})
@@ -171,10 +180,10 @@ func (c *Client) SendText(channelID discord.Snowflake, content string) (*discord
 //
 // Fires a Message Create Gateway event.
 func (c *Client) SendEmbed(
-	channelID discord.Snowflake, e discord.Embed) (*discord.Message, error) {
+	channelID discord.ChannelID, e discord.Embed) (*discord.Message, error) {
 
 	return c.SendMessageComplex(channelID, SendMessageData{
-		Embed: &e,
+		Embeds: []discord.Embed{e},
 	})
 }
 
@@ -185,7 +194,7 @@ func (c *Client) SendEmbed(
 //
 // Fires a Message Create Gateway event.
 func (c *Client) SendMessage(
-	channelID discord.Snowflake, content string, embed *discord.Embed) (*discord.Message, error) {
+	channelID discord.ChannelID, content string, embed *discord.Embed) (*discord.Message, error) {
 
 	return c.SendMessageComplex(channelID, SendMessageData{
 		Content: content,
@@ -197,8 +206,13 @@ func (c *Client) SendMessage(
 type EditMessageData struct {
 	// Content is the new message contents (up to 2000 characters).
 	Content option.NullableString `json:"content,omitempty"`
-	// Embed contains embedded rich content.
-	Embed *discord.Embed `json:"embed,omitempty"`
+	// Embed contains embedded rich content. It's sent as the first of Embeds.
+	//
+	// Deprecated: API v10 only accepts Embeds.
+	Embed *discord.Embed `json:"-"`
+	// Embeds replace the embedded rich content of the message. An empty slice
+	// removes all embeds.
+	Embeds *[]discord.Embed `json:"embeds,omitempty"`
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
 	// Flags edits the flags of a message (only SUPPRESS_EMBEDS can currently
@@ -211,7 +225,7 @@ type EditMessageData struct {
 // EditText edits the contents of a previously sent message. For more
 // documentation, refer to EditMessageComplex.
 func (c *Client) EditText(
-	channelID, messageID discord.Snowflake, content string) (*discord.Message, error) {
+	channelID discord.ChannelID, messageID discord.MessageID, content string) (*discord.Message, error) {
 
 	return c.EditMessageComplex(channelID, messageID, EditMessageData{
 		Content: option.NewNullableString(content),
@@ -221,17 +235,17 @@ func (c *Client) EditText(
 // EditEmbed edits the embed of a previously sent message. For more
 // documentation, refer to EditMessageComplex.
 func (c *Client) EditEmbed(
-	channelID, messageID discord.Snowflake, embed discord.Embed) (*discord.Message, error) {
+	channelID discord.ChannelID, messageID discord.MessageID, embed discord.Embed) (*discord.Message, error) {
 
 	return c.EditMessageComplex(channelID, messageID, EditMessageData{
-		Embed: &embed,
+		Embeds: &[]discord.Embed{embed},
 	})
 }
 
 // EditMessage edits a previously sent message. For more documentation, refer to
 // EditMessageComplex.
 func (c *Client) EditMessage(
-	channelID, messageID discord.Snowflake, content string,
+	channelID discord.ChannelID, messageID discord.MessageID, content string,
 	embed *discord.Embed, suppressEmbeds bool) (*discord.Message, error) {
 
 	var data = EditMessageData{
@@ -246,7 +260,7 @@ func (c *Client) EditMessage(
 }
 
 // EditMessageComplex edits a previously sent message. The fields Content,
-// Embed, AllowedMentions and Flags can be edited by the original message
+// Embeds, AllowedMentions and Flags can be edited by the original message
 // author. Other users can only edit flags and only if they have the
 // MANAGE_MESSAGES permission in the corresponding channel. When specifying
 // flags, ensure to include all previously set flags/bits in addition to ones
@@ -256,7 +270,16 @@ func (c *Client) EditMessage(
 //
 // Fires a Message Update Gateway event.
 func (c *Client) EditMessageComplex(
-	channelID, messageID discord.Snowflake, data EditMessageData) (*discord.Message, error) {
+	channelID discord.ChannelID, messageID discord.MessageID, data EditMessageData) (*discord.Message, error) {
+
+	if data.Embed != nil {
+		var embeds = []discord.Embed{*data.Embed}
+		if data.Embeds != nil {
+			embeds = append(embeds, *data.Embeds...)
+		}
+		data.Embeds = &embeds
+		data.Embed = nil
+	}
 
 	var msg *discord.Message
 	return msg, c.RequestJSON(
@@ -269,7 +292,7 @@ func (c *Client) EditMessageComplex(
 // DeleteMessage delete a message. If operating on a guild channel and trying
 // to delete a message that was not sent by the current user, this endpoint
 // requires the MANAGE_MESSAGES permission.
-func (c *Client) DeleteMessage(channelID, messageID discord.Snowflake) error {
+func (c *Client) DeleteMessage(channelID discord.ChannelID, messageID discord.MessageID) error {
 	return c.FastRequest("DELETE", EndpointChannels+channelID.String()+
 		"/messages/"+messageID.String())
 }
@@ -283,9 +306,9 @@ func (c *Client) DeleteMessage(channelID, messageID discord.Snowflake) error {
 // provided.
 //
 // Fires a Message Delete Bulk Gateway event.
-func (c *Client) DeleteMessages(channelID discord.Snowflake, messageIDs []discord.Snowflake) error {
+func (c *Client) DeleteMessages(channelID discord.ChannelID, messageIDs []discord.MessageID) error {
 	var param struct {
-		Messages []discord.Snowflake `json:"messages"`
+		Messages []discord.MessageID `json:"messages"`
 	}
 
 	param.Messages = messageIDs
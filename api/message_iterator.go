@@ -0,0 +1,154 @@
+package api
+
+import "github.com/diamondburned/arikawa/discord"
+
+// MessageQuery is the range of messages that IterateMessages goes through.
+type MessageQuery struct {
+	// Before, if valid, only includes the messages sent before it.
+	Before discord.MessageID
+	// After, if valid, only includes the messages sent after it. If Before
+	// isn't valid, the messages are iterated oldest first, starting right
+	// after this ID.
+	After discord.MessageID
+	// Limit is the maximum number of messages. If it's 0, all messages in the
+	// range are iterated.
+	Limit uint
+}
+
+// MessageIterator goes through the messages of a channel one by one, and only
+// fetches the next page of messages when the current one is used up. It's
+// created with IterateMessages, and used like a bufio.Scanner:
+//
+//    it := client.IterateMessages(channelID, api.MessageQuery{})
+//    for it.Next() {
+//        log.Println(it.Message().Content)
+//    }
+//    if err := it.Err(); err != nil {
+//        return err
+//    }
+//
+// A MessageIterator is not safe to use from multiple goroutines.
+type MessageIterator struct {
+	client    *Client
+	channelID discord.ChannelID
+	query     MessageQuery
+	forward   bool
+
+	page  []discord.Message
+	msg   discord.Message
+	count uint
+	last  bool
+	err   error
+}
+
+// IterateMessages returns an iterator over the messages in the channel within
+// the range of the query. Unless only the After ID is given, the messages are
+// iterated from newest to oldest, starting with the latest message if the
+// Before ID isn't given either.
+//
+// Like Messages, a request for up to 100 messages is made for every page, and
+// it requires the READ_MESSAGE_HISTORY permission in guild channels.
+func (c *Client) IterateMessages(
+	channelID discord.ChannelID, query MessageQuery) *MessageIterator {
+
+	return &MessageIterator{
+		client:    c,
+		channelID: channelID,
+		query:     query,
+		forward:   !query.Before.Valid() && query.After.Valid(),
+	}
+}
+
+// Next advances the iterator to the next message, which is then available
+// through Message. It returns false when there are no more messages, or when a
+// page failed to be fetched, in which case Err returns the error.
+func (it *MessageIterator) Next() bool {
+	if it.err != nil || (it.query.Limit > 0 && it.count >= it.query.Limit) {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.last {
+			return false
+		}
+
+		if it.err = it.fetch(); it.err != nil || len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.msg, it.page = it.page[0], it.page[1:]
+	it.count++
+	return true
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() discord.Message {
+	return it.msg
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *MessageIterator) Err() error {
+	return it.err
+}
+
+func (it *MessageIterator) fetch() error {
+	// this is the limit of max messages per request, as imposed by Discord
+	const hardLimit uint = 100
+
+	var fetch = hardLimit
+	if it.query.Limit > 0 && it.query.Limit-it.count < fetch {
+		fetch = it.query.Limit - it.count
+	}
+
+	var before, after discord.MessageID
+	if it.forward {
+		after = it.query.After
+	} else {
+		before = it.query.Before
+	}
+
+	m, err := it.client.messagesRange(it.channelID, before, after, 0, fetch)
+	if err != nil {
+		return err
+	}
+
+	it.last = uint(len(m)) < fetch
+
+	if len(m) > 0 {
+		// Discord returns the newest messages first.
+		if it.forward {
+			it.query.After = m[0].ID
+			reverseMessages(m)
+		} else {
+			it.query.Before = m[len(m)-1].ID
+			m = it.trimAfter(m)
+		}
+	}
+
+	it.page = m
+	return nil
+}
+
+// trimAfter cuts off the messages that are not after the After ID when
+// iterating backwards, and stops the iterator if any are.
+func (it *MessageIterator) trimAfter(m []discord.Message) []discord.Message {
+	if !it.query.After.Valid() {
+		return m
+	}
+
+	for i, msg := range m {
+		if msg.ID <= it.query.After {
+			it.last = true
+			return m[:i]
+		}
+	}
+
+	return m
+}
+
+func reverseMessages(m []discord.Message) {
+	for i, j := 0, len(m)-1; i < j; i, j = i+1, j-1 {
+		m[i], m[j] = m[j], m[i]
+	}
+}
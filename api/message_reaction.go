@@ -13,7 +13,7 @@ import (
 // the current user. Additionally, if nobody else has reacted to the message
 // using this emoji, this endpoint requires the 'ADD_REACTIONS' permission to
 // be present on the current user.
-func (c *Client) React(channelID, messageID discord.Snowflake, emoji Emoji) error {
+func (c *Client) React(channelID discord.ChannelID, messageID discord.MessageID, emoji Emoji) error {
 	var msgURL = EndpointChannels + channelID.String() +
 		"/messages/" + messageID.String() +
 		"/reactions/" + url.PathEscape(emoji) + "/@me"
@@ -21,7 +21,7 @@ func (c *Client) React(channelID, messageID discord.Snowflake, emoji Emoji) erro
 }
 
 // Unreact removes a reaction the current user has made for the message.
-func (c *Client) Unreact(chID, msgID discord.Snowflake, emoji Emoji) error {
+func (c *Client) Unreact(chID discord.ChannelID, msgID discord.MessageID, emoji Emoji) error {
 	return c.DeleteUserReaction(chID, msgID, 0, emoji)
 }
 
@@ -35,7 +35,7 @@ func (c *Client) Unreact(chID, msgID discord.Snowflake, emoji Emoji) error {
 //
 // When fetching the users, those with the smallest ID will be fetched first.
 func (c *Client) Reactions(
-	channelID, messageID discord.Snowflake, limit uint, emoji Emoji) ([]discord.User, error) {
+	channelID discord.ChannelID, messageID discord.MessageID, limit uint, emoji Emoji) ([]discord.User, error) {
 
 	return c.ReactionsAfter(channelID, messageID, 0, limit, emoji)
 }
@@ -48,7 +48,7 @@ func (c *Client) Reactions(
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more guilds are available.
 func (c *Client) ReactionsBefore(
-	channelID, messageID, before discord.Snowflake,
+	channelID discord.ChannelID, messageID discord.MessageID, before discord.UserID,
 	limit uint, emoji Emoji) ([]discord.User, error) {
 
 	var users []discord.User
@@ -89,7 +89,7 @@ func (c *Client) ReactionsBefore(
 // maximum a total of limit/100 rounded up requests will be made, although they
 // may be less, if no more guilds are available.
 func (c *Client) ReactionsAfter(
-	channelID, messageID, after discord.Snowflake, limit uint, emoji Emoji,
+	channelID discord.ChannelID, messageID discord.MessageID, after discord.UserID, limit uint, emoji Emoji,
 ) ([]discord.User, error) {
 
 	var users []discord.User
@@ -125,7 +125,7 @@ func (c *Client) ReactionsAfter(
 // reactionsRange get users before and after IDs. Before, after, and limit are
 // optional. A maximum limit of only 100 reactions could be returned.
 func (c *Client) reactionsRange(
-	channelID, messageID, before, after discord.Snowflake,
+	channelID discord.ChannelID, messageID discord.MessageID, before, after discord.UserID,
 	limit uint, emoji Emoji) ([]discord.User, error) {
 
 	switch {
@@ -136,8 +136,8 @@ func (c *Client) reactionsRange(
 	}
 
 	var param struct {
-		Before discord.Snowflake `schema:"before,omitempty"`
-		After  discord.Snowflake `schema:"after,omitempty"`
+		Before discord.UserID `schema:"before,omitempty"`
+		After  discord.UserID `schema:"after,omitempty"`
 
 		Limit uint `schema:"limit"`
 	}
@@ -160,7 +160,7 @@ func (c *Client) reactionsRange(
 // This endpoint requires the MANAGE_MESSAGES permission to be present on the
 // current user.
 func (c *Client) DeleteUserReaction(
-	channelID, messageID, userID discord.Snowflake, emoji Emoji) error {
+	channelID discord.ChannelID, messageID discord.MessageID, userID discord.UserID, emoji Emoji) error {
 
 	var user = "@me"
 	if userID > 0 {
@@ -180,7 +180,7 @@ func (c *Client) DeleteUserReaction(
 // current user.
 // Fires a Message Reaction Remove Emoji Gateway event.
 func (c *Client) DeleteReactions(
-	channelId, messageID discord.Snowflake, emoji Emoji) error {
+	channelId discord.ChannelID, messageID discord.MessageID, emoji Emoji) error {
 
 	return c.FastRequest(
 		"DELETE",
@@ -194,7 +194,7 @@ func (c *Client) DeleteReactions(
 // This endpoint requires the MANAGE_MESSAGES permission to be present on the
 // current user.
 // Fires a Message Reaction Remove All Gateway event.
-func (c *Client) DeleteAllReactions(channelID, messageID discord.Snowflake) error {
+func (c *Client) DeleteAllReactions(channelID discord.ChannelID, messageID discord.MessageID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/reactions/",
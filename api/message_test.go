@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+// messageServer serves the messages 1 to n like Discord does: up to limit
+// messages right before or after the given ID, newest first.
+func messageServer(t *testing.T, n int) (*Client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q = r.URL.Query()
+		var param = func(name string, def int) int {
+			if q.Get(name) == "" {
+				return def
+			}
+			v, err := strconv.Atoi(q.Get(name))
+			if err != nil {
+				t.Error("Invalid "+name+":", err)
+			}
+			return v
+		}
+
+		var limit = param("limit", 50)
+		var before = param("before", n+1)
+		var after = param("after", 0)
+
+		var msgs []discord.Message
+		if q.Get("after") != "" {
+			for id := after + 1; id < before && len(msgs) < limit; id++ {
+				msgs = append([]discord.Message{{ID: discord.MessageID(id)}}, msgs...)
+			}
+		} else {
+			for id := before - 1; id > 0 && len(msgs) < limit; id-- {
+				msgs = append(msgs, discord.Message{ID: discord.MessageID(id)})
+			}
+		}
+
+		b, err := json.Marshal(msgs)
+		if err != nil {
+			t.Error("Failed to marshal messages:", err)
+		}
+		w.Write(b)
+	}))
+
+	return NewClient("no. 3-chan").WithBaseURL(srv.URL), srv.Close
+}
+
+// expectIDs checks that the messages have the IDs from first to last, counting
+// up or down.
+func expectIDs(t *testing.T, msgs []discord.Message, first, last int) {
+	t.Helper()
+
+	var step = 1
+	if last < first {
+		step = -1
+	}
+
+	var i int
+	for id := first; ; id += step {
+		if i >= len(msgs) {
+			t.Fatalf("Got %d messages, missing ID %d", len(msgs), id)
+		}
+		if msgs[i].ID != discord.MessageID(id) {
+			t.Fatalf("Message %d has ID %d, expected %d", i, msgs[i].ID, id)
+		}
+		i++
+		if id == last {
+			break
+		}
+	}
+
+	if i != len(msgs) {
+		t.Fatalf("Got %d messages, expected %d", len(msgs), i)
+	}
+}
+
+func TestMessagesPagination(t *testing.T) {
+	client, done := messageServer(t, 250)
+	defer done()
+
+	msgs, err := client.Messages(1, 0)
+	if err != nil {
+		t.Fatal("Failed to get messages:", err)
+	}
+	expectIDs(t, msgs, 250, 1)
+
+	msgs, err = client.MessagesBefore(1, 200, 150)
+	if err != nil {
+		t.Fatal("Failed to get messages before:", err)
+	}
+	expectIDs(t, msgs, 199, 50)
+
+	msgs, err = client.MessagesAfter(1, 20, 0)
+	if err != nil {
+		t.Fatal("Failed to get messages after:", err)
+	}
+	expectIDs(t, msgs, 250, 21)
+}
+
+func TestMessageIterator(t *testing.T) {
+	client, done := messageServer(t, 250)
+	defer done()
+
+	var tests = []struct {
+		name        string
+		query       MessageQuery
+		first, last int
+	}{
+		{"all", MessageQuery{}, 250, 1},
+		{"before", MessageQuery{Before: 200, Limit: 150}, 199, 50},
+		{"after", MessageQuery{After: 10, Limit: 150}, 11, 160},
+		{"after to end", MessageQuery{After: 120}, 121, 250},
+		{"between", MessageQuery{Before: 200, After: 50}, 199, 51},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var msgs []discord.Message
+
+			it := client.IterateMessages(1, test.query)
+			for it.Next() {
+				msgs = append(msgs, it.Message())
+			}
+			if err := it.Err(); err != nil {
+				t.Fatal("Failed to iterate:", err)
+			}
+
+			expectIDs(t, msgs, test.first, test.last)
+		})
+	}
+}
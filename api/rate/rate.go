@@ -21,7 +21,63 @@ const ExtraDelay = 250 * time.Millisecond
 // This makes me suicidal.
 // https://github.com/bwmarrin/discordgo/blob/master/ratelimit.go
 
-type Limiter struct {
+// Limiter is a REST rate limiter. Acquire is called before each request is
+// sent, and Release is called once its response, which might have nil headers
+// if the request failed, is received. Implementations may be shared across
+// processes, e.g. with a Redis-backed store, so that bots running many
+// processes stay under the same limits.
+type Limiter interface {
+	// Acquire blocks until a request to the path can be sent, or until the
+	// context expires.
+	Acquire(ctx context.Context, path string) error
+	// Release updates the limits of the path with the response headers. It
+	// must be called once for every successful Acquire.
+	Release(path string, headers http.Header) error
+}
+
+var _ Limiter = (*DefaultLimiter)(nil)
+
+// RateLimitError is returned by Acquire if the request is rate limited for
+// longer than the context allows. Refer to FailFast and MaxWait.
+type RateLimitError struct {
+	Path string
+	// Reset is when the rate limit is over.
+	Reset time.Time
+}
+
+func (err *RateLimitError) Error() string {
+	return "rate limited on " + err.Path + " for " +
+		time.Until(err.Reset).Round(time.Millisecond).String()
+}
+
+type maxWaitKey struct{}
+
+// MaxWait returns a context that makes Limiters return a *RateLimitError
+// instead of waiting if a request would be rate limited for longer than max.
+// It's used with the API client's WithContext:
+//
+//    c := client.WithContext(rate.MaxWait(ctx, 2*time.Second))
+//
+// Requests within the budget still wait as usual.
+func MaxWait(ctx context.Context, max time.Duration) context.Context {
+	return context.WithValue(ctx, maxWaitKey{}, max)
+}
+
+// FailFast returns a context that makes Limiters return a *RateLimitError
+// instead of waiting for any rate limit. This is useful for interactive code,
+// which would rather tell the user to try again later.
+func FailFast(ctx context.Context) context.Context {
+	return MaxWait(ctx, 0)
+}
+
+func maxWait(ctx context.Context) (time.Duration, bool) {
+	max, ok := ctx.Value(maxWaitKey{}).(time.Duration)
+	return max, ok
+}
+
+// DefaultLimiter is the default in-process Limiter. It keeps a bucket for each
+// route and shares the limits of routes with the same bucket hash.
+type DefaultLimiter struct {
 	// Only 1 per bucket
 	CustomLimits []*CustomRateLimit
 
@@ -30,6 +86,10 @@ type Limiter struct {
 	global     *int64 // atomic guarded, unixnano
 	buckets    sync.Map
 	globalRate time.Duration
+
+	// limits maps X-RateLimit-Bucket hashes and major parameters to the limit
+	// shared by all routes with that hash.
+	limits sync.Map // map[string]*limit
 }
 
 type CustomRateLimit struct {
@@ -37,26 +97,40 @@ type CustomRateLimit struct {
 	Reset    time.Duration
 }
 
+// bucket is the bucket of a route. Requests to the same route are serialized by
+// the bucket's lock.
 type bucket struct {
 	lock   moreatomic.CtxMutex
 	custom *CustomRateLimit
+	major  string
 
-	remaining uint64
-	limit     uint
+	// limit is the rate limit of the bucket. It may be shared with other
+	// buckets once Discord reports that they have the same bucket hash. It is
+	// only changed with lock acquired.
+	limit *limit
 
-	reset     time.Time
 	lastReset time.Time // only for custom
 }
 
-func newBucket() *bucket {
+// limit is the rate limit state of one or more routes.
+type limit struct {
+	mutex     sync.Mutex
+	remaining uint64
+	reset     time.Time
+}
+
+func newBucket(major string) *bucket {
 	return &bucket{
-		lock:      *moreatomic.NewCtxMutex(),
-		remaining: 1,
+		lock:  *moreatomic.NewCtxMutex(),
+		major: major,
+		limit: &limit{remaining: 1},
 	}
 }
 
-func NewLimiter(prefix string) *Limiter {
-	return &Limiter{
+// NewLimiter creates a new in-process DefaultLimiter. The prefix is trimmed
+// from all paths before they're parsed into buckets.
+func NewLimiter(prefix string) *DefaultLimiter {
+	return &DefaultLimiter{
 		Prefix:       prefix,
 		global:       new(int64),
 		buckets:      sync.Map{},
@@ -64,7 +138,7 @@ func NewLimiter(prefix string) *Limiter {
 	}
 }
 
-func (l *Limiter) getBucket(path string, store bool) *bucket {
+func (l *DefaultLimiter) getBucket(path string, store bool) *bucket {
 	path = ParseBucketKey(strings.TrimPrefix(path, l.Prefix))
 
 	bc, ok := l.buckets.Load(path)
@@ -73,7 +147,7 @@ func (l *Limiter) getBucket(path string, store bool) *bucket {
 	}
 
 	if !ok {
-		bc := newBucket()
+		bc := newBucket(majorParameter(path))
 
 		for _, limit := range l.CustomLimits {
 			if strings.Contains(path, limit.Contains) {
@@ -82,14 +156,39 @@ func (l *Limiter) getBucket(path string, store bool) *bucket {
 			}
 		}
 
-		l.buckets.Store(path, bc)
-		return bc
+		// Another goroutine might've stored the same bucket in the meantime.
+		actual, _ := l.buckets.LoadOrStore(path, bc)
+		return actual.(*bucket)
 	}
 
 	return bc.(*bucket)
 }
 
-func (l *Limiter) Acquire(ctx context.Context, path string) error {
+// majorParameter returns the major parameter of a bucket key, such as
+// "channels/123", or an empty string if there's none.
+func majorParameter(key string) string {
+	parts := strings.SplitN(strings.TrimPrefix(key, "/"), "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	for _, root := range MajorRootPaths {
+		if root == parts[0] {
+			return parts[0] + "/" + parts[1]
+		}
+	}
+
+	return ""
+}
+
+// shareLimit makes the bucket use the limit shared by all buckets with the same
+// hash and major parameter. It must be called with the bucket's lock acquired.
+func (l *DefaultLimiter) shareLimit(b *bucket, hash string) {
+	shared, _ := l.limits.LoadOrStore(hash+":"+b.major, b.limit)
+	b.limit = shared.(*limit)
+}
+
+func (l *DefaultLimiter) Acquire(ctx context.Context, path string) error {
 	b := l.getBucket(path, true)
 
 	if err := b.lock.Lock(ctx); err != nil {
@@ -99,9 +198,13 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 	// Time to sleep
 	var sleep time.Duration
 
-	if b.remaining == 0 && b.reset.After(time.Now()) {
+	b.limit.mutex.Lock()
+	remaining, reset := b.limit.remaining, b.limit.reset
+	b.limit.mutex.Unlock()
+
+	if remaining == 0 && reset.After(time.Now()) {
 		// out of turns, gotta wait
-		sleep = b.reset.Sub(time.Now())
+		sleep = reset.Sub(time.Now())
 	} else {
 		// maybe global rate limit has it
 		now := time.Now()
@@ -113,6 +216,11 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 	}
 
 	if sleep > 0 {
+		if max, ok := maxWait(ctx); ok && sleep > max {
+			b.lock.Unlock()
+			return &RateLimitError{Path: path, Reset: time.Now().Add(sleep)}
+		}
+
 		select {
 		case <-ctx.Done():
 			b.lock.Unlock()
@@ -121,16 +229,18 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 		}
 	}
 
-	if b.remaining > 0 {
-		b.remaining--
+	b.limit.mutex.Lock()
+	if b.limit.remaining > 0 {
+		b.limit.remaining--
 	}
+	b.limit.mutex.Unlock()
 
 	return nil
 }
 
 // Release releases the URL from the locks. This doesn't need a context for
 // timing out, it doesn't block that much.
-func (l *Limiter) Release(path string, headers http.Header) error {
+func (l *DefaultLimiter) Release(path string, headers http.Header) error {
 	b := l.getBucket(path, false)
 	if b == nil {
 		return nil
@@ -144,7 +254,10 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 
 		if now.Sub(b.lastReset) >= b.custom.Reset {
 			b.lastReset = now
-			b.reset = now.Add(b.custom.Reset)
+
+			b.limit.mutex.Lock()
+			b.limit.reset = now.Add(b.custom.Reset)
+			b.limit.mutex.Unlock()
 		}
 
 		return nil
@@ -162,41 +275,67 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 		// seconds
 		remaining  = headers.Get("X-RateLimit-Remaining")
 		reset      = headers.Get("X-RateLimit-Reset")
+		resetAfter = headers.Get("X-RateLimit-Reset-After")
 		retryAfter = headers.Get("Retry-After")
+
+		// Routes with the same hash share the same rate limit.
+		hash = headers.Get("X-RateLimit-Bucket")
 	)
 
+	if hash != "" {
+		l.shareLimit(b, hash)
+	}
+
+	b.limit.mutex.Lock()
+	defer b.limit.mutex.Unlock()
+
 	switch {
 	case retryAfter != "":
-		i, err := strconv.Atoi(retryAfter)
+		// Retry-After is in seconds, but may be fractional.
+		f, err := strconv.ParseFloat(retryAfter, 64)
 		if err != nil {
 			return errors.Wrap(err, "invalid retryAfter "+retryAfter)
 		}
 
-		at := time.Now().Add(time.Duration(i) * time.Millisecond)
+		at := time.Now().Add(time.Duration(f * float64(time.Second)))
 
 		if global != "" { // probably true
 			atomic.StoreInt64(l.global, at.UnixNano())
 		} else {
-			b.reset = at
+			b.limit.reset = at
+			b.limit.remaining = 0
 		}
 
+	case resetAfter != "":
+		// Reset-After is relative, so it doesn't depend on the clock being in
+		// sync with Discord's.
+		f, err := strconv.ParseFloat(resetAfter, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid resetAfter "+resetAfter)
+		}
+
+		b.limit.reset = time.Now().
+			Add(time.Duration(f * float64(time.Second))).
+			Add(ExtraDelay)
+
 	case reset != "":
 		unix, err := strconv.ParseFloat(reset, 64)
 		if err != nil {
 			return errors.Wrap(err, "invalid reset "+reset)
 		}
 
-		b.reset = time.Unix(0, int64(unix*float64(time.Second))).
+		b.limit.reset = time.Unix(0, int64(unix*float64(time.Second))).
 			Add(ExtraDelay)
 	}
 
-	if remaining != "" {
+	// A 429 with Retry-After already set remaining to 0.
+	if remaining != "" && retryAfter == "" {
 		u, err := strconv.ParseUint(remaining, 10, 64)
 		if err != nil {
 			return errors.Wrap(err, "invalid remaining "+remaining)
 		}
 
-		b.remaining = u
+		b.limit.remaining = u
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package rate
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
@@ -10,7 +11,7 @@ import (
 
 // https://github.com/bwmarrin/discordgo/blob/master/ratelimit_test.go
 
-func mockRequest(t *testing.T, l *Limiter, path string, headers http.Header) {
+func mockRequest(t *testing.T, l Limiter, path string, headers http.Header) {
 	if err := l.Acquire(context.Background(), path); err != nil {
 		t.Fatal("Failed to acquire lock:", err)
 	}
@@ -57,7 +58,7 @@ func TestRatelimitGlobal(t *testing.T) {
 	headers := http.Header{}
 	headers.Set("X-RateLimit-Global", "1.002")
 	// Reset for approx 1 seconds from now
-	headers.Set("Retry-After", "1000")
+	headers.Set("Retry-After", "1")
 
 	sent := time.Now()
 
@@ -74,3 +75,107 @@ func TestRatelimitGlobal(t *testing.T) {
 		t.Error("did not ratelimit correctly, got:", time.Since(sent))
 	}
 }
+
+// This test takes ~1 seconds to run
+func TestRatelimitBucketHash(t *testing.T) {
+	l := NewLimiter("")
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Bucket", "abcd")
+	headers.Set("X-RateLimit-Remaining", "1")
+	headers.Set("X-RateLimit-Reset-After", "1")
+
+	sent := time.Now()
+	mockRequest(t, l, "/channels/1/messages", headers)
+
+	// The pins route shares its hash with messages, so this exhausts both.
+	headers.Set("X-RateLimit-Remaining", "0")
+	mockRequest(t, l, "/channels/1/pins", headers)
+
+	// Routes with a different major parameter don't share the limit.
+	mockRequest(t, l, "/channels/2/messages", headers)
+
+	if time.Since(sent) >= time.Second {
+		t.Fatal("unexpected ratelimit, got:", time.Since(sent))
+	}
+
+	// This shouldn't go through in less than 1 second.
+	mockRequest(t, l, "/channels/1/messages", headers)
+
+	if time.Since(sent) >= time.Second && time.Since(sent) < time.Second*2 {
+		t.Log("OK", time.Since(sent))
+	} else {
+		t.Error("did not ratelimit correctly, got:", time.Since(sent))
+	}
+}
+
+// This test takes ~1 seconds to run
+func TestRatelimitTooManyRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message":"You are being rate limited.","retry_after":1,"global":false}`))
+	}))
+	defer srv.Close()
+
+	l := NewLimiter("")
+
+	if err := l.Acquire(context.Background(), "/channels/1/messages"); err != nil {
+		t.Fatal("Failed to acquire lock:", err)
+	}
+
+	sent := time.Now()
+
+	r, err := http.Get(srv.URL + "/channels/1/messages")
+	if err != nil {
+		t.Fatal("Failed to send request:", err)
+	}
+	r.Body.Close()
+
+	if err := l.Release("/channels/1/messages", r.Header); err != nil {
+		t.Fatal("Failed to release lock:", err)
+	}
+
+	// The bucket is exhausted for the second in Retry-After.
+	mockRequest(t, l, "/channels/1/messages", nil)
+
+	if time.Since(sent) >= time.Second && time.Since(sent) < time.Second*2 {
+		t.Log("OK", time.Since(sent))
+	} else {
+		t.Error("did not ratelimit correctly, got:", time.Since(sent))
+	}
+}
+
+func TestRatelimitFailFast(t *testing.T) {
+	l := NewLimiter("")
+
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset-After", "1")
+
+	mockRequest(t, l, "/channels/1/messages", headers)
+
+	ctx := FailFast(context.Background())
+
+	err := l.Acquire(ctx, "/channels/1/messages")
+	if err == nil {
+		t.Fatal("Unexpected success acquiring while rate limited")
+	}
+
+	rlErr, ok := err.(*RateLimitError)
+	if !ok || rlErr.Reset.Before(time.Now()) {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// The bucket should've been unlocked, so a request with a large enough
+	// budget can still go through.
+	ctx, cancel := context.WithTimeout(MaxWait(context.Background(), 5*time.Second), 5*time.Second)
+	defer cancel()
+
+	if err := l.Acquire(ctx, "/channels/1/messages"); err != nil {
+		t.Fatal("Failed to acquire within budget:", err)
+	}
+	l.Release("/channels/1/messages", nil)
+}
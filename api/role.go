@@ -1,15 +1,18 @@
 package api
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/utils/httputil"
 	"github.com/diamondburned/arikawa/utils/json/option"
 )
 
-// Adds a role to a guild member.
+// AddRole adds a role to a guild member.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) AddRole(guildID, userID, roleID discord.Snowflake) error {
+// Fires a Guild Member Update Gateway event.
+func (c *Client) AddRole(guildID discord.GuildID, userID discord.UserID, roleID discord.RoleID) error {
 	return c.FastRequest(
 		"PUT",
 		EndpointGuilds+guildID.String()+"/members/"+userID.String()+"/roles/"+roleID.String(),
@@ -20,7 +23,7 @@ func (c *Client) AddRole(guildID, userID, roleID discord.Snowflake) error {
 //
 // Requires the MANAGE_ROLES permission.
 // Fires a Guild Member Update Gateway event.
-func (c *Client) RemoveRole(guildID, userID, roleID discord.Snowflake) error {
+func (c *Client) RemoveRole(guildID discord.GuildID, userID discord.UserID, roleID discord.RoleID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointGuilds+guildID.String()+"/members/"+userID.String()+"/roles/"+roleID.String(),
@@ -28,21 +31,31 @@ func (c *Client) RemoveRole(guildID, userID, roleID discord.Snowflake) error {
 }
 
 // Roles returns a list of role objects for the guild.
-func (c *Client) Roles(guildID discord.Snowflake) ([]discord.Role, error) {
+func (c *Client) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	var roles []discord.Role
 	return roles, c.RequestJSON(&roles, "GET", EndpointGuilds+guildID.String()+"/roles")
 }
 
+// Role returns a role of the guild.
+func (c *Client) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
+	var role *discord.Role
+	return role, c.RequestJSON(
+		&role, "GET",
+		EndpointGuilds+guildID.String()+"/roles/"+roleID.String(),
+	)
+}
+
 // https://discord.com/developers/docs/resources/guild#create-guild-role-json-params
 type CreateRoleData struct {
-	// Name is the 	name of the role.
+	// Name is the name of the role.
 	//
 	// Default: "new role"
 	Name string `json:"name,omitempty"`
 	// Permissions is the bitwise value of the enabled/disabled permissions.
+	// A pointer to 0 creates a role without permissions.
 	//
 	// Default: @everyone permissions in guild
-	Permissions discord.Permissions `json:"permissions,omitempty"`
+	Permissions *discord.Permissions `json:"permissions,omitempty"`
 	// Color is the RGB color value of the role.
 	//
 	// Default: 0
@@ -63,7 +76,11 @@ type CreateRoleData struct {
 // Requires the MANAGE_ROLES permission.
 // Fires a Guild Role Create Gateway event.
 func (c *Client) CreateRole(
-	guildID discord.Snowflake, data CreateRoleData) (*discord.Role, error) {
+	guildID discord.GuildID, data CreateRoleData) (*discord.Role, error) {
+
+	if err := c.validate(data); err != nil {
+		return nil, err
+	}
 
 	var role *discord.Role
 	return role, c.RequestJSON(
@@ -76,16 +93,24 @@ func (c *Client) CreateRole(
 // https://discord.com/developers/docs/resources/guild#modify-guild-role-positions-json-params
 type MoveRoleData struct {
 	// ID is the id of the role.
-	ID discord.Snowflake `json:"id"`
+	ID discord.RoleID `json:"id"`
 	// Position is the sorting position of the role.
 	Position option.NullableInt `json:"position,omitempty"`
 }
 
-// MoveRole modifies the positions of a set of role objects for the guild.
+// MoveRole is the old name of MoveRoles.
+//
+// Deprecated: Use MoveRoles instead.
+func (c *Client) MoveRole(guildID discord.GuildID, data []MoveRoleData) ([]discord.Role, error) {
+	return c.MoveRoles(guildID, data)
+}
+
+// MoveRoles modifies the positions of a set of role objects for the guild, and
+// returns all of the guild's roles.
 //
 // Requires the MANAGE_ROLES permission.
 // Fires multiple Guild Role Update Gateway events.
-func (c *Client) MoveRole(guildID discord.Snowflake, data []MoveRoleData) ([]discord.Role, error) {
+func (c *Client) MoveRoles(guildID discord.GuildID, data []MoveRoleData) ([]discord.Role, error) {
 	var roles []discord.Role
 	return roles, c.RequestJSON(
 		&roles, "PATCH",
@@ -94,13 +119,99 @@ func (c *Client) MoveRole(guildID discord.Snowflake, data []MoveRoleData) ([]dis
 	)
 }
 
+// MoveRoleTo moves the role to the given position, where 1 is right above
+// @everyone, and shifts the roles in between. As the guild's roles are fetched
+// first, the positions of all shifted roles are sent in one MoveRoles call, so
+// that no two roles end up sharing a position. Positions past the highest role
+// move the role to the top.
+//
+// Requires the MANAGE_ROLES permission, and the bot can only move roles below
+// its highest role.
+func (c *Client) MoveRoleTo(
+	guildID discord.GuildID, roleID discord.RoleID, position int) ([]discord.Role, error) {
+
+	roles, err := c.Roles(guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get roles")
+	}
+
+	data, err := moveRoleTo(roles, guildID, roleID, position)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return roles, nil
+	}
+
+	return c.MoveRoles(guildID, data)
+}
+
+// moveRoleTo returns the MoveRoleData of the roles whose positions change when
+// the role is moved.
+func moveRoleTo(
+	roles []discord.Role, guildID discord.GuildID,
+	roleID discord.RoleID, position int) ([]MoveRoleData, error) {
+
+	if roleID == discord.RoleID(guildID) {
+		return nil, errors.New("the @everyone role can't be moved")
+	}
+
+	// Sort the roles from the lowest without @everyone, so that the index of a
+	// role is its position minus 1.
+	var sorted = make([]discord.Role, 0, len(roles))
+	for _, r := range roles {
+		if r.ID != discord.RoleID(guildID) {
+			sorted = append(sorted, r)
+		}
+	}
+	discord.SortRoles(sorted)
+	for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	}
+
+	var index = -1
+	for i, r := range sorted {
+		if r.ID == roleID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.Errorf("unknown role %d", roleID)
+	}
+
+	var role = sorted[index]
+	sorted = append(sorted[:index], sorted[index+1:]...)
+
+	switch {
+	case position < 1:
+		position = 1
+	case position > len(sorted)+1:
+		position = len(sorted) + 1
+	}
+
+	sorted = append(sorted[:position-1], append([]discord.Role{role}, sorted[position-1:]...)...)
+
+	var data []MoveRoleData
+	for i, r := range sorted {
+		if r.Position != i+1 {
+			data = append(data, MoveRoleData{
+				ID:       r.ID,
+				Position: option.NewNullableInt(i + 1),
+			})
+		}
+	}
+
+	return data, nil
+}
+
 // https://discord.com/developers/docs/resources/guild#modify-guild-role-json-params
 type ModifyRoleData struct {
-	// Name is the 	name of the role.
+	// Name is the name of the role.
 	Name option.NullableString `json:"name,omitempty"`
 	// Permissions is the bitwise value of the enabled/disabled permissions.
 	Permissions *discord.Permissions `json:"permissions,omitempty"`
-	// Permissions is the bitwise value of the enabled/disabled permissions.
+	// Color is the RGB color value of the role.
 	Color option.NullableColor `json:"color,omitempty"`
 	// Hoist specifies whether the role should be displayed separately in the
 	// sidebar.
@@ -112,10 +223,15 @@ type ModifyRoleData struct {
 // ModifyRole modifies a guild role.
 //
 // Requires the MANAGE_ROLES permission.
+// Fires a Guild Role Update Gateway event.
 func (c *Client) ModifyRole(
-	guildID, roleID discord.Snowflake,
+	guildID discord.GuildID, roleID discord.RoleID,
 	data ModifyRoleData) (*discord.Role, error) {
 
+	if err := c.validate(data); err != nil {
+		return nil, err
+	}
+
 	var role *discord.Role
 	return role, c.RequestJSON(
 		&role, "PATCH",
@@ -127,7 +243,8 @@ func (c *Client) ModifyRole(
 // DeleteRole deletes a guild role.
 //
 // Requires the MANAGE_ROLES permission.
-func (c *Client) DeleteRole(guildID, roleID discord.Snowflake) error {
+// Fires a Guild Role Delete Gateway event.
+func (c *Client) DeleteRole(guildID discord.GuildID, roleID discord.RoleID) error {
 	return c.FastRequest(
 		"DELETE",
 		EndpointGuilds+guildID.String()+"/roles/"+roleID.String(),
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestMoveRoleTo(t *testing.T) {
+	var roles = []discord.Role{
+		{ID: 1, Position: 0},
+		{ID: 2, Position: 1},
+		{ID: 3, Position: 2},
+		{ID: 4, Position: 3},
+	}
+
+	data, err := moveRoleTo(roles, 1, 4, 1)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var positions = map[discord.RoleID]int{}
+	for _, d := range data {
+		positions[d.ID] = d.Position.Val
+	}
+
+	if len(positions) != 3 || positions[4] != 1 || positions[2] != 2 || positions[3] != 3 {
+		t.Fatal("Unexpected positions:", positions)
+	}
+
+	if data, _ := moveRoleTo(roles, 1, 2, 1); len(data) != 0 {
+		t.Fatal("Unexpected moves for a role in place:", data)
+	}
+	if _, err := moveRoleTo(roles, 1, 1, 2); err == nil {
+		t.Fatal("Expected an error for moving @everyone")
+	}
+}
+
+func TestCreateRoleDataPermissions(t *testing.T) {
+	var none discord.Permissions
+
+	b, err := json.Marshal(CreateRoleData{Name: "muted", Permissions: &none})
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if string(b) != `{"name":"muted","permissions":"0"}` {
+		t.Fatal("Unexpected JSON:", string(b))
+	}
+
+	b, err = json.Marshal(CreateRoleData{Name: "default"})
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if string(b) != `{"name":"default"}` {
+		t.Fatal("Unexpected JSON:", string(b))
+	}
+}
@@ -41,9 +41,9 @@ type AllowedMentions struct {
 	// Parse is an array of allowed mention types to parse from the content.
 	Parse []AllowedMentionType `json:"parse"`
 	// Roles is an array of role_ids to mention (Max size of 100).
-	Roles []discord.Snowflake `json:"roles,omitempty"`
+	Roles []discord.RoleID `json:"roles,omitempty"`
 	// Users is an array of user_ids to mention (Max size of 100).
-	Users []discord.Snowflake `json:"users,omitempty"`
+	Users []discord.UserID `json:"users,omitempty"`
 }
 
 // AllowedMentionType is a constant that tells Discord what is allowed to parse
@@ -105,13 +105,20 @@ type SendMessageData struct {
 
 	// TTS is true if this is a TTS message.
 	TTS bool `json:"tts,omitempty"`
-	// Embed is embedded rich content.
-	Embed *discord.Embed `json:"embed,omitempty"`
+	// Embed is embedded rich content. It's sent as the first of Embeds.
+	//
+	// Deprecated: API v10 only accepts Embeds.
+	Embed *discord.Embed `json:"-"`
+	// Embeds are embedded rich content (up to 10).
+	Embeds []discord.Embed `json:"embeds,omitempty"`
 
 	Files []SendMessageFile `json:"-"`
 
 	// AllowedMentions are the allowed mentions for a message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Flags are the flags of the message. Only SuppressEmbeds and
+	// SuppressNotifications can be set.
+	Flags discord.MessageFlags `json:"flags,omitempty"`
 }
 
 func (data *SendMessageData) WriteMultipart(body *multipart.Writer) error {
@@ -138,9 +145,14 @@ func (data *SendMessageData) WriteMultipart(body *multipart.Writer) error {
 // least one of content, embed or file. For a file attachment, the
 // Content-Disposition subpart header MUST contain a filename parameter.
 func (c *Client) SendMessageComplex(
-	channelID discord.Snowflake, data SendMessageData) (*discord.Message, error) {
+	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
+
+	if data.Embed != nil {
+		data.Embeds = append([]discord.Embed{*data.Embed}, data.Embeds...)
+		data.Embed = nil
+	}
 
-	if data.Content == "" && data.Embed == nil && len(data.Files) == 0 {
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
 		return nil, ErrEmptyMessage
 	}
 
@@ -150,9 +162,9 @@ func (c *Client) SendMessageComplex(
 		}
 	}
 
-	if data.Embed != nil {
-		if err := data.Embed.Validate(); err != nil {
-			return nil, errors.Wrap(err, "embed error")
+	for i, embed := range data.Embeds {
+		if err := embed.Validate(); err != nil {
+			return nil, errors.Wrap(err, "embed error at "+strconv.Itoa(i))
 		}
 	}
 
@@ -176,7 +188,7 @@ func (c *Client) SendMessageComplex(
 	var body = resp.GetBody()
 	defer body.Close()
 
-	return msg, json.DecodeStream(body, &msg)
+	return msg, c.JSONDriver().DecodeStream(body, &msg)
 }
 
 type ExecuteWebhookData struct {
@@ -201,6 +213,9 @@ type ExecuteWebhookData struct {
 
 	// AllowedMentions are the allowed mentions for the message.
 	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	// Flags are the flags of the message. Only SuppressEmbeds and
+	// SuppressNotifications can be set.
+	Flags discord.MessageFlags `json:"flags,omitempty"`
 }
 
 func (data *ExecuteWebhookData) WriteMultipart(body *multipart.Writer) error {
@@ -211,7 +226,7 @@ func (data *ExecuteWebhookData) WriteMultipart(body *multipart.Writer) error {
 // wait for the message to be delivered and will return the message body. This
 // also means the returned message will only be there if wait is true.
 func (c *Client) ExecuteWebhook(
-	webhookID discord.Snowflake,
+	webhookID discord.WebhookID,
 	token string,
 	wait bool, // if false, then nil returned for *Message.
 	data ExecuteWebhookData) (*discord.Message, error) {
@@ -263,7 +278,7 @@ func (c *Client) ExecuteWebhook(
 		return nil, nil
 	}
 
-	return msg, json.DecodeStream(body, &msg)
+	return msg, c.JSONDriver().DecodeStream(body, &msg)
 }
 
 func writeMultipart(body *multipart.Writer, item interface{}, files []SendMessageFile) error {
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// MessageContentLimit is the maximum number of characters in the content of a
+// message.
+const MessageContentLimit = 2000
+
+// LongMessagePolicy decides what SendLongMessage does with content that's
+// over MessageContentLimit.
+type LongMessagePolicy uint8
+
+const (
+	// SplitLongMessage sends the content in as many messages as needed, split
+	// on newlines, or on spaces if a line is too long.
+	SplitLongMessage LongMessagePolicy = iota
+	// AttachLongMessage sends the content as a text file instead.
+	AttachLongMessage
+)
+
+// SendLongMessageData is the data of SendLongMessage.
+type SendLongMessageData struct {
+	SendMessageData
+
+	// Policy is used if the content is too long to fit in one message.
+	Policy LongMessagePolicy
+	// FileName is the name of the file that the content is sent as with
+	// AttachLongMessage. It defaults to "message.txt".
+	FileName string
+}
+
+// SendLongText sends the content to the channel, splitting it into multiple
+// messages if it's too long. For more documentation, refer to
+// SendLongMessage.
+func (c *Client) SendLongText(
+	channelID discord.ChannelID, content string) ([]discord.Message, error) {
+
+	return c.SendLongMessage(channelID, SendLongMessageData{
+		SendMessageData: SendMessageData{Content: content},
+	})
+}
+
+// SendLongMessage sends a message like SendMessageComplex, except the content
+// may be over MessageContentLimit. Content that fits is sent as-is.
+//
+// When the content is split, TTS and AllowedMentions apply to every message,
+// the nonce is only sent with the first message, and the embed and files are
+// sent with the last one. If a message fails to send, the messages sent so far
+// are returned along with the error.
+func (c *Client) SendLongMessage(
+	channelID discord.ChannelID, data SendLongMessageData) ([]discord.Message, error) {
+
+	if utf8.RuneCountInString(data.Content) <= MessageContentLimit {
+		m, err := c.SendMessageComplex(channelID, data.SendMessageData)
+		if err != nil {
+			return nil, err
+		}
+		return []discord.Message{*m}, nil
+	}
+
+	switch data.Policy {
+	case AttachLongMessage:
+		var name = data.FileName
+		if name == "" {
+			name = "message.txt"
+		}
+
+		var msg = data.SendMessageData
+		msg.Files = append([]SendMessageFile{{
+			Name:   name,
+			Reader: strings.NewReader(data.Content),
+		}}, msg.Files...)
+		msg.Content = ""
+
+		m, err := c.SendMessageComplex(channelID, msg)
+		if err != nil {
+			return nil, err
+		}
+		return []discord.Message{*m}, nil
+
+	case SplitLongMessage:
+		var parts = SplitMessage(data.Content, MessageContentLimit)
+		var msgs = make([]discord.Message, 0, len(parts))
+
+		for i, part := range parts {
+			var msg = SendMessageData{
+				Content:         part,
+				TTS:             data.TTS,
+				AllowedMentions: data.AllowedMentions,
+				Flags:           data.Flags,
+			}
+			if i == 0 {
+				msg.Nonce = data.Nonce
+			}
+			if i == len(parts)-1 {
+				msg.Embed = data.Embed
+				msg.Embeds = data.Embeds
+				msg.Files = data.Files
+			}
+
+			m, err := c.SendMessageComplex(channelID, msg)
+			if err != nil {
+				return msgs, errors.Wrapf(err, "failed to send part %d", i+1)
+			}
+			msgs = append(msgs, *m)
+		}
+
+		return msgs, nil
+
+	default:
+		return nil, errors.Errorf("unknown long message policy %d", data.Policy)
+	}
+}
+
+// SplitMessage splits the content into parts of at most limit characters. It
+// splits on the last newline that fits, or the last space if there is none,
+// and only splits in the middle of a word if the word is longer than the
+// limit. Newlines and spaces that the content is split on are dropped. The
+// content isn't split if limit is smaller than 1.
+func SplitMessage(content string, limit int) []string {
+	if limit < 1 {
+		limit = utf8.RuneCountInString(content)
+	}
+
+	var parts []string
+
+	for utf8.RuneCountInString(content) > limit {
+		// Find the byte offset of the first character over the limit.
+		var end, n int
+		for end = range content {
+			if n == limit {
+				break
+			}
+			n++
+		}
+
+		var cut, skip = end, 0
+		if i := strings.LastIndexByte(content[:end+1], '\n'); i > 0 {
+			cut, skip = i, 1
+		} else if i := strings.LastIndexByte(content[:end+1], ' '); i > 0 {
+			cut, skip = i, 1
+		}
+
+		parts = append(parts, content[:cut])
+		content = content[cut+skip:]
+	}
+
+	if content != "" {
+		parts = append(parts, content)
+	}
+
+	return parts
+}
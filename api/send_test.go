@@ -2,6 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -34,7 +37,7 @@ func TestMarshalAllowedMentions(t *testing.T) {
 	t.Run("allow certain user IDs", func(t *testing.T) {
 		var data = SendMessageData{
 			AllowedMentions: &AllowedMentions{
-				Users: []discord.Snowflake{1, 2},
+				Users: []discord.UserID{1, 2},
 			},
 		}
 
@@ -44,11 +47,26 @@ func TestMarshalAllowedMentions(t *testing.T) {
 	})
 }
 
+func TestMarshalMessageFlags(t *testing.T) {
+	var data = SendMessageData{
+		Content: "hime arikawa",
+		Flags:   discord.SuppressEmbeds | discord.SuppressNotifications,
+	}
+
+	if j := mustMarshal(t, data); j != `{"content":"hime arikawa","flags":4100}` {
+		t.Fatal("Unexpected JSON:", j)
+	}
+
+	if !data.Flags.Has(discord.SuppressNotifications) || data.Flags.Has(discord.EphemeralMessage) {
+		t.Fatal("Unexpected flags:", data.Flags)
+	}
+}
+
 func TestVerifyAllowedMentions(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		var am = AllowedMentions{
 			Parse: []AllowedMentionType{AllowEveryoneMention, AllowUserMention},
-			Users: []discord.Snowflake{69, 420},
+			Users: []discord.UserID{69, 420},
 		}
 
 		err := am.Verify()
@@ -57,7 +75,7 @@ func TestVerifyAllowedMentions(t *testing.T) {
 
 	t.Run("users too long", func(t *testing.T) {
 		var am = AllowedMentions{
-			Users: make([]discord.Snowflake, 101),
+			Users: make([]discord.UserID, 101),
 		}
 
 		err := am.Verify()
@@ -66,7 +84,7 @@ func TestVerifyAllowedMentions(t *testing.T) {
 
 	t.Run("roles too long", func(t *testing.T) {
 		var am = AllowedMentions{
-			Roles: make([]discord.Snowflake, 101),
+			Roles: make([]discord.RoleID, 101),
 		}
 
 		err := am.Verify()
@@ -76,8 +94,8 @@ func TestVerifyAllowedMentions(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		var am = AllowedMentions{
 			Parse: []AllowedMentionType{AllowEveryoneMention, AllowUserMention},
-			Roles: []discord.Snowflake{1337},
-			Users: []discord.Snowflake{},
+			Roles: []discord.RoleID{1337},
+			Users: []discord.UserID{},
 		}
 
 		if err := am.Verify(); err != nil {
@@ -125,7 +143,7 @@ func TestSendMessage(t *testing.T) {
 			Content: "hime arikawa",
 			AllowedMentions: &AllowedMentions{
 				Parse: []AllowedMentionType{AllowEveryoneMention, AllowUserMention},
-				Users: []discord.Snowflake{69, 420},
+				Users: []discord.UserID{69, 420},
 			},
 		}
 
@@ -146,6 +164,49 @@ func TestSendMessage(t *testing.T) {
 	})
 }
 
+func TestSendMessageEmbeds(t *testing.T) {
+	var body string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read body:", err)
+		}
+		body = strings.TrimSpace(string(b))
+
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("no. 3-chan").WithBaseURL(srv.URL)
+
+	_, err := client.SendMessageComplex(1, SendMessageData{
+		Embed:  &discord.Embed{Title: "first"},
+		Embeds: []discord.Embed{{Title: "second"}},
+	})
+	if err != nil {
+		t.Fatal("Failed to send message:", err)
+	}
+
+	var sent SendMessageData
+	if err := json.Unmarshal([]byte(body), &sent); err != nil {
+		t.Fatal("Failed to decode body:", err)
+	}
+
+	if len(sent.Embeds) != 2 || sent.Embeds[0].Title != "first" || sent.Embeds[1].Title != "second" {
+		t.Fatal("Unexpected body:", body)
+	}
+
+	_, err = client.EditMessageComplex(1, 1, EditMessageData{Embeds: &[]discord.Embed{}})
+	if err != nil {
+		t.Fatal("Failed to edit message:", err)
+	}
+
+	if body != `{"embeds":[]}` {
+		t.Fatal("Unexpected body:", body)
+	}
+}
+
 func errMustContain(t *testing.T, err error, contains string) {
 	// mark function as helper so line traces are accurate.
 	t.Helper()
@@ -169,3 +230,30 @@ func mustMarshal(t *testing.T, v interface{}) string {
 	}
 	return string(j)
 }
+
+func TestSplitMessage(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+		limit   int
+		parts   []string
+	}{
+		{"fits", "hello world", 11, []string{"hello world"}},
+		{"newline", "hello\nbig world", 12, []string{"hello", "big world"}},
+		{"space", "hello big world", 12, []string{"hello big", "world"}},
+		{"word", "helloworld", 4, []string{"hell", "owor", "ld"}},
+		{"runes", "ééé ééé", 4, []string{"ééé", "ééé"}},
+		{"no limit", "hello world", 0, []string{"hello world"}},
+		{"negative limit", "hello world", -1, []string{"hello world"}},
+		{"one", "ab c", 1, []string{"a", "b", "c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parts := SplitMessage(test.content, test.limit)
+			if strings.Join(parts, "|") != strings.Join(test.parts, "|") {
+				t.Fatalf("Unexpected parts %q", parts)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"io"
+	"mime/multipart"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// Stickers returns the custom stickers of the given guild.
+func (c *Client) Stickers(guildID discord.GuildID) ([]discord.Sticker, error) {
+	var stks []discord.Sticker
+	return stks, c.RequestJSON(&stks, "GET", EndpointGuilds+guildID.String()+"/stickers")
+}
+
+// Sticker returns a custom sticker of the given guild.
+func (c *Client) Sticker(
+	guildID discord.GuildID, stickerID discord.StickerID) (*discord.Sticker, error) {
+
+	var stk *discord.Sticker
+	return stk, c.RequestJSON(&stk, "GET",
+		EndpointGuilds+guildID.String()+"/stickers/"+stickerID.String())
+}
+
+// https://discord.com/developers/docs/resources/sticker#create-guild-sticker-form-params
+type CreateStickerData struct {
+	// Name is the name of the sticker (2-30 characters).
+	Name string
+	// Description is the description of the sticker, which is either empty or
+	// 2-100 characters long.
+	Description string
+	// Tags are the autocomplete and suggestion tags of the sticker, separated
+	// by commas (max 200 characters).
+	Tags string
+	// File is the PNG, APNG, GIF or Lottie JSON sticker file. Its name must
+	// have the right extension.
+	File SendMessageFile
+}
+
+// WriteMultipart writes the form fields of the sticker.
+func (data *CreateStickerData) WriteMultipart(body *multipart.Writer) error {
+	defer body.Close()
+
+	var fields = [...][2]string{
+		{"name", data.Name},
+		{"description", data.Description},
+		{"tags", data.Tags},
+	}
+
+	for _, field := range fields {
+		if err := body.WriteField(field[0], field[1]); err != nil {
+			return errors.Wrap(err, "failed to write field "+field[0])
+		}
+	}
+
+	w, err := body.CreateFormFile("file", data.File.Name)
+	if err != nil {
+		return errors.Wrap(err, "failed to create bodypart for file")
+	}
+
+	if _, err := io.Copy(w, data.File.Reader); err != nil {
+		return errors.Wrap(err, "failed to write file")
+	}
+
+	return nil
+}
+
+// CreateSticker uploads a new sticker to the guild. This endpoint requires
+// MANAGE_EMOJIS. Stickers have a maximum file size of 512kb, and Lottie
+// stickers can only be uploaded by verified and partnered guilds.
+//
+// Fires a Guild Stickers Update Gateway event.
+func (c *Client) CreateSticker(
+	guildID discord.GuildID, data CreateStickerData) (*discord.Sticker, error) {
+
+	resp, err := c.MeanwhileMultipart(
+		data.WriteMultipart, "POST", EndpointGuilds+guildID.String()+"/stickers",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var body = resp.GetBody()
+	defer body.Close()
+
+	var stk *discord.Sticker
+	return stk, c.JSONDriver().DecodeStream(body, &stk)
+}
+
+// Delete the given sticker.
+//
+// Requires the MANAGE_EMOJIS permission.
+// Fires a Guild Stickers Update Gateway event.
+func (c *Client) DeleteSticker(guildID discord.GuildID, stickerID discord.StickerID) error {
+	return c.FastRequest("DELETE", EndpointGuilds+guildID.String()+"/stickers/"+stickerID.String())
+}
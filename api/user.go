@@ -12,7 +12,7 @@ var (
 )
 
 // User returns a user object for a given user ID.
-func (c *Client) User(userID discord.Snowflake) (*discord.User, error) {
+func (c *Client) User(userID discord.UserID) (*discord.User, error) {
 	var u *discord.User
 	return u, c.RequestJSON(&u, "GET", EndpointUsers+userID.String())
 }
@@ -44,7 +44,7 @@ func (c *Client) ModifyMe(data ModifySelfData) (*discord.User, error) {
 //
 // Fires a Guild Member Update Gateway event.
 func (c *Client) ChangeOwnNickname(
-	guildID discord.Snowflake, nick string) error {
+	guildID discord.GuildID, nick string) error {
 
 	var param struct {
 		Nick string `json:"nick"`
@@ -68,9 +68,9 @@ func (c *Client) PrivateChannels() ([]discord.Channel, error) {
 }
 
 // CreatePrivateChannel creates a new DM channel with a user.
-func (c *Client) CreatePrivateChannel(recipientID discord.Snowflake) (*discord.Channel, error) {
+func (c *Client) CreatePrivateChannel(recipientID discord.UserID) (*discord.Channel, error) {
 	var param struct {
-		RecipientID discord.Snowflake `json:"recipient_id"`
+		RecipientID discord.UserID `json:"recipient_id"`
 	}
 
 	param.RecipientID = recipientID
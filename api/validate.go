@@ -0,0 +1,224 @@
+package api
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json/option"
+)
+
+// Validator is implemented by request data that can be checked locally before
+// being sent to Discord. Requests are only validated if the Client's
+// ValidateRequests is true.
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError is returned when request data fails local validation. It
+// describes the field that is invalid, so mistakes can be caught without
+// decoding Discord's errors.
+type ValidationError struct {
+	// Field is the JSON name of the invalid field.
+	Field string
+	// Reason describes why the field is invalid.
+	Reason string
+}
+
+func (err *ValidationError) Error() string {
+	return "invalid " + err.Field + ": " + err.Reason
+}
+
+// validate validates the given data if ValidateRequests is true.
+func (c *Client) validate(v Validator) error {
+	if !c.ValidateRequests {
+		return nil
+	}
+	return v.Validate()
+}
+
+// validateLength checks that the length of s in characters is within min and
+// max, both inclusive.
+func validateLength(field, s string, min, max int) error {
+	switch n := utf8.RuneCountInString(s); {
+	case n < min:
+		return &ValidationError{field, "must be at least " + strconv.Itoa(min) + " characters"}
+	case n > max:
+		return &ValidationError{field, "must be at most " + strconv.Itoa(max) + " characters"}
+	}
+	return nil
+}
+
+func validateChannelType(t discord.ChannelType) error {
+	if t > discord.GuildStore {
+		return &ValidationError{"type", "unknown channel type " + strconv.Itoa(int(t))}
+	}
+	return nil
+}
+
+func validateVideoQualityMode(m discord.VideoQualityMode) error {
+	if m != discord.AutoVideoQuality && m != discord.FullVideoQuality {
+		return &ValidationError{
+			"video_quality_mode", "unknown video quality mode " + strconv.Itoa(int(m)),
+		}
+	}
+	return nil
+}
+
+// Validate checks the nickname length and that the timeout isn't longer than
+// MaxTimeout. An empty nickname resets it.
+func (data ModifyMemberData) Validate() error {
+	if data.Nick != nil {
+		if err := validateLength("nick", *data.Nick, 0, 32); err != nil {
+			return err
+		}
+	}
+	if data.CommunicationDisabledUntil != nil {
+		var until = data.CommunicationDisabledUntil.Time()
+		if until.After(time.Now().Add(MaxTimeout)) {
+			return &ValidationError{
+				"communication_disabled_until", "must be at most 28 days in the future",
+			}
+		}
+	}
+	return nil
+}
+
+// validatePruneDays checks the number of days of a prune. 0 is allowed, as it's
+// replaced with the default of 7 days.
+func validatePruneDays(days uint) error {
+	if days > 30 {
+		return &ValidationError{"days", "must be between 1 and 30, or 0 for the default"}
+	}
+	return nil
+}
+
+// Validate checks the number of days.
+func (data PruneCountData) Validate() error {
+	return validatePruneDays(data.Days)
+}
+
+// Validate checks the number of days.
+func (data PruneData) Validate() error {
+	return validatePruneDays(data.Days)
+}
+
+// Validate checks the reason length and the number of days or seconds to
+// delete messages for.
+func (data BanData) Validate() error {
+	if data.DeleteDays != nil && *data.DeleteDays > 7 {
+		return &ValidationError{"delete_message_days", "must be between 0 and 7"}
+	}
+	if data.DeleteSeconds != nil {
+		if data.DeleteDays != nil {
+			return &ValidationError{
+				"delete_message_seconds", "can't be set along with delete_message_days",
+			}
+		}
+		if *data.DeleteSeconds < 0 || *data.DeleteSeconds > maxBanDeleteSeconds {
+			return &ValidationError{"delete_message_seconds", "must be between 0 and 604800"}
+		}
+	}
+	if data.Reason != nil {
+		return validateLength("reason", *data.Reason, 0, 512)
+	}
+	return nil
+}
+
+// Validate checks the number of seconds to delete messages for.
+func (data BulkBanData) Validate() error {
+	if data.DeleteSeconds != nil &&
+		(*data.DeleteSeconds < 0 || *data.DeleteSeconds > maxBanDeleteSeconds) {
+
+		return &ValidationError{"delete_message_seconds", "must be between 0 and 604800"}
+	}
+	return nil
+}
+
+// Validate checks the name and topic lengths, the channel type and the video
+// quality mode.
+func (data CreateChannelData) Validate() error {
+	if err := validateLength("name", data.Name, 1, 100); err != nil {
+		return err
+	}
+	if err := validateLength("topic", data.Topic, 0, 1024); err != nil {
+		return err
+	}
+	if data.VoiceQualityMode != 0 {
+		if err := validateVideoQualityMode(data.VoiceQualityMode); err != nil {
+			return err
+		}
+	}
+	return validateChannelType(data.Type)
+}
+
+// Validate checks the name and topic lengths, the channel type and the video
+// quality mode.
+func (data ModifyChannelData) Validate() error {
+	if data.Name != "" {
+		if err := validateLength("name", data.Name, 1, 100); err != nil {
+			return err
+		}
+	}
+	if data.Topic != nil {
+		if err := validateLength("topic", data.Topic.Val, 0, 1024); err != nil {
+			return err
+		}
+	}
+	if data.VoiceQualityMode != nil {
+		if err := validateVideoQualityMode(*data.VoiceQualityMode); err != nil {
+			return err
+		}
+	}
+	if data.Type != nil {
+		return validateChannelType(*data.Type)
+	}
+	return nil
+}
+
+// Validate checks the name length.
+func (data CreateRoleData) Validate() error {
+	return validateLength("name", data.Name, 0, 100)
+}
+
+// Validate checks the name length.
+func (data ModifyRoleData) Validate() error {
+	if data.Name != nil {
+		return validateLength("name", data.Name.Val, 0, 100)
+	}
+	return nil
+}
+
+// Validate checks the name length and the AFK timeout.
+func (data CreateGuildData) Validate() error {
+	if err := validateLength("name", data.Name, 2, 100); err != nil {
+		return err
+	}
+	return validateAFKTimeout(data.AFKTimeout)
+}
+
+// Validate checks the name length and the AFK timeout.
+func (data ModifyGuildData) Validate() error {
+	if data.Name != "" {
+		if err := validateLength("name", data.Name, 2, 100); err != nil {
+			return err
+		}
+	}
+	return validateAFKTimeout(data.AFKTimeout)
+}
+
+// validateAFKTimeout checks that the timeout is one of the durations that can
+// be picked in the client.
+func validateAFKTimeout(timeout option.Seconds) error {
+	if timeout == nil {
+		return nil
+	}
+
+	switch *timeout {
+	case 60, 300, 900, 1800, 3600:
+		return nil
+	default:
+		return &ValidationError{"afk_timeout", "must be 60, 300, 900, 1800 or 3600 seconds"}
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/utils/json/option"
+)
+
+func TestValidate(t *testing.T) {
+	var quality = discord.FullVideoQuality
+	var timeout = discord.NewTimestamp(time.Now().Add(time.Hour))
+	var longTimeout = discord.NewTimestamp(time.Now().Add(MaxTimeout + time.Hour))
+
+	var tests = []struct {
+		name  string
+		data  Validator
+		field string // empty if valid
+	}{
+		{"valid nick", ModifyMemberData{Nick: option.NewString("arikawa")}, ""},
+		{"long nick", ModifyMemberData{Nick: option.NewString(strings.Repeat("a", 33))}, "nick"},
+		{"timeout", ModifyMemberData{CommunicationDisabledUntil: &timeout}, ""},
+		{"long timeout", ModifyMemberData{CommunicationDisabledUntil: &longTimeout}, "communication_disabled_until"},
+		{"remove timeout", ModifyMemberData{CommunicationDisabledUntil: &discord.Timestamp{}}, ""},
+		{"long reason", BanData{Reason: option.NewString(strings.Repeat("a", 513))}, "reason"},
+		{"delete days", BanData{DeleteDays: option.NewUint(8)}, "delete_message_days"},
+		{"delete seconds", BanData{DeleteSeconds: option.NewSeconds(3600)}, ""},
+		{"long delete seconds", BanData{DeleteSeconds: option.NewSeconds(604801)}, "delete_message_seconds"},
+		{"negative delete seconds", BanData{DeleteSeconds: option.NewSeconds(-1)}, "delete_message_seconds"},
+		{"bulk delete seconds", BulkBanData{DeleteSeconds: option.NewSeconds(-2)}, "delete_message_seconds"},
+		{"delete days and seconds", BanData{
+			DeleteDays:    option.NewUint(1),
+			DeleteSeconds: option.NewSeconds(3600),
+		}, "delete_message_seconds"},
+		{"prune days", PruneData{Days: 31}, "days"},
+		{"max prune days", PruneData{Days: 30}, ""},
+		{"min prune days", PruneCountData{Days: 1}, ""},
+		{"default prune days", PruneCountData{}, ""},
+		{"long prune count days", PruneCountData{Days: 31}, "days"},
+		{"no channel name", CreateChannelData{}, "name"},
+		{"long topic", CreateChannelData{Name: "a", Topic: strings.Repeat("a", 1025)}, "topic"},
+		{"channel type", CreateChannelData{Name: "a", Type: 100}, "type"},
+		{"multibyte name", CreateRoleData{Name: strings.Repeat("あ", 100)}, ""},
+		{"short guild name", CreateGuildData{Name: "a"}, "name"},
+		{"unchanged guild", ModifyGuildData{}, ""},
+		{"afk timeout", ModifyGuildData{AFKTimeout: option.NewSeconds(120)}, "afk_timeout"},
+		{"valid channel type", ModifyChannelData{Type: &discord.GuildNews}, ""},
+		{"video quality mode", CreateChannelData{Name: "a", VoiceQualityMode: 3}, "video_quality_mode"},
+		{"valid video quality mode", ModifyChannelData{VoiceQualityMode: &quality}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.data.Validate()
+			if test.field == "" {
+				if err != nil {
+					t.Fatal("Unexpected error:", err)
+				}
+				return
+			}
+
+			verr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatal("Unexpected error type:", err)
+			}
+			if verr.Field != test.field {
+				t.Fatal("Unexpected field:", verr.Field)
+			}
+		})
+	}
+}
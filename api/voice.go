@@ -0,0 +1,15 @@
+package api
+
+import (
+	"github.com/diamondburned/arikawa/discord"
+)
+
+var EndpointVoice = Endpoint + "voice/"
+
+// VoiceRegions returns the voice regions that can be used as the RTC region of
+// voice channels. Use GuildVoiceRegions to also get the VIP regions available
+// to a guild.
+func (c *Client) VoiceRegions() ([]discord.VoiceRegion, error) {
+	var vrs []discord.VoiceRegion
+	return vrs, c.RequestJSON(&vrs, "GET", EndpointVoice+"regions")
+}
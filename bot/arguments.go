@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/diamondburned/arikawa/discord"
 )
 
 type argumentValueFn func(string) (reflect.Value, error)
@@ -196,6 +198,21 @@ func newArgument(t reflect.Type, variadic bool) (*Argument, error) {
 
 	var fn argumentValueFn
 
+	// Snowflakes are int64s, but they also accept the mentions of their type,
+	// so that commands can take IDs without a custom Parser.
+	if prefixes, ok := snowflakeTypes[t]; ok {
+		fn = func(s string) (reflect.Value, error) {
+			sf, err := discord.ParseSnowflake(trimMention(s, prefixes))
+			return quickRet(sf, err, t)
+		}
+
+		return &Argument{
+			String: fromUsager(t),
+			rtype:  t,
+			fn:     fn,
+		}, nil
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		fn = func(s string) (reflect.Value, error) {
@@ -244,6 +261,52 @@ func newArgument(t reflect.Type, variadic bool) (*Argument, error) {
 	}, nil
 }
 
+// Mention prefixes of the typed IDs.
+var (
+	userMentions    = []string{"@!", "@"}
+	channelMentions = []string{"#"}
+	roleMentions    = []string{"@&"}
+)
+
+// snowflakeTypes contains discord.Snowflake and all typed IDs, mapped to the
+// prefixes of the mentions that they accept. A plain Snowflake accepts all
+// mentions.
+var snowflakeTypes = map[reflect.Type][]string{
+	reflect.TypeOf(discord.Snowflake(0)):       {"@!", "@&", "@", "#"},
+	reflect.TypeOf(discord.AppID(0)):           nil,
+	reflect.TypeOf(discord.AttachmentID(0)):    nil,
+	reflect.TypeOf(discord.AuditLogEntryID(0)): nil,
+	reflect.TypeOf(discord.ChannelID(0)):       channelMentions,
+	reflect.TypeOf(discord.EmojiID(0)):         nil,
+	reflect.TypeOf(discord.GuildID(0)):         nil,
+	reflect.TypeOf(discord.IntegrationID(0)):   nil,
+	reflect.TypeOf(discord.MessageID(0)):       nil,
+	reflect.TypeOf(discord.RoleID(0)):          roleMentions,
+	reflect.TypeOf(discord.StickerID(0)):       nil,
+	reflect.TypeOf(discord.StickerPackID(0)):   nil,
+	reflect.TypeOf(discord.UserID(0)):          userMentions,
+	reflect.TypeOf(discord.WebhookID(0)):       nil,
+}
+
+// trimMention trims the mention syntax around an ID, e.g. <@!id>, <#id> or
+// <@&id>, if the mention has one of the given prefixes. Strings that aren't
+// such mentions are returned as-is, so they fail to parse as IDs.
+func trimMention(s string, prefixes []string) string {
+	if len(s) < 3 || s[0] != '<' || s[len(s)-1] != '>' {
+		return s
+	}
+
+	var inner = s[1 : len(s)-1]
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(inner, prefix) {
+			return inner[len(prefix):]
+		}
+	}
+
+	return s
+}
+
 func quickRet(v interface{}, err error, t reflect.Type) (reflect.Value, error) {
 	if err != nil {
 		return nilV, err
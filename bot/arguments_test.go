@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
 )
 
 type mockParser string
@@ -26,6 +28,35 @@ func TestArguments(t *testing.T) {
 	testArgs(t, 69.420, "69.420")
 	testArgs(t, mockParse("testString"), "testString")
 	testArgs(t, *mockParse("testString"), "testString")
+	testArgs(t, discord.Snowflake(170132746042081280), "170132746042081280")
+	testArgs(t, discord.Snowflake(170132746042081280), "<@!170132746042081280>")
+	testArgs(t, discord.Snowflake(170132746042081280), "<#170132746042081280>")
+	testArgs(t, discord.Snowflake(170132746042081280), "<@&170132746042081280>")
+	testArgs(t, discord.UserID(170132746042081280), "<@!170132746042081280>")
+	testArgs(t, discord.ChannelID(170132746042081280), "<#170132746042081280>")
+	testArgs(t, discord.UserID(170132746042081280), "<@170132746042081280>")
+	testArgs(t, discord.RoleID(170132746042081280), "<@&170132746042081280>")
+
+	var wrongMentions = []struct {
+		typ     interface{}
+		mention string
+	}{
+		{discord.UserID(0), "<#170132746042081280>"},
+		{discord.UserID(0), "<@&170132746042081280>"},
+		{discord.ChannelID(0), "<@170132746042081280>"},
+		{discord.RoleID(0), "<@!170132746042081280>"},
+		{discord.GuildID(0), "<#170132746042081280>"},
+	}
+
+	for _, test := range wrongMentions {
+		f, err := newArgument(reflect.TypeOf(test.typ), false)
+		if err != nil {
+			t.Fatal("Failed to get argument value function:", err)
+		}
+		if _, err := f.fn(test.mention); err == nil {
+			t.Errorf("Unexpected success parsing %s as %T", test.mention, test.typ)
+		}
+	}
 
 	_, err := newArgument(reflect.TypeOf(struct{}{}), false)
 	if !strings.HasPrefix(err.Error(), "invalid type: ") {
@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/diamondburned/arikawa/api"
 	"github.com/diamondburned/arikawa/bot/extras/shellwords"
+	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/gateway"
 	"github.com/diamondburned/arikawa/state"
 	"github.com/pkg/errors"
@@ -124,6 +126,10 @@ type Context struct {
 	// MessageCreate events.
 	ReplyError bool
 
+	// HelpFormatter, if not nil, overrides the format of Help(). Refer to
+	// HelpGenerate() for the default format.
+	HelpFormatter HelpFormatter
+
 	// EditableCommands when true will also listen for MessageUpdateEvent and
 	// treat them as newly created messages. This is convenient if you want
 	// to quickly edit a message and re-execute the command.
@@ -136,10 +142,22 @@ type Context struct {
 	// Quick access map from event types to pointers. This map will never have
 	// MessageCreateEvent's type.
 	typeCache sync.Map // map[reflect.Type][]*CommandContext
+
+	// stopCtx is canceled when the handler returned by Start is removed or
+	// when the Session is closed.
+	stopCtx context.Context
+	stop    context.CancelFunc
 }
 
 // Start quickly starts a bot with the given command. It will prepend "Bot"
 // into the token automatically. Refer to example/ for usage.
+//
+// The Gateway identifies with gateway.DefaultIntents. Commands in messages that
+// don't mention the bot and aren't in DMs have empty content unless the
+// privileged IntentMessageContent is added in opts, which also has to be
+// enabled in the developer portal:
+//
+//    ctx.Gateway.Identifier.Intents |= gateway.IntentMessageContent
 func Start(token string, cmd interface{},
 	opts func(*Context) error) (wait func() error, err error) {
 
@@ -180,7 +198,7 @@ func Start(token string, cmd interface{},
 
 // Wait blocks until SIGINT.
 func Wait() {
-	sigs := make(chan os.Signal)
+	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt)
 	<-sigs
 }
@@ -212,7 +230,7 @@ func New(s *state.State, cmd interface{}) (*Context, error) {
 		HasPrefix:  NewPrefix("~"),
 		FormatError: func(err error) string {
 			// Escape all pings, including @everyone.
-			return strings.Replace(err.Error(), "@", "@\u200b", -1)
+			return discord.EscapeMentions(err.Error())
 		},
 		ErrorLogger: func(err error) {
 			log.Println("Bot error:", err)
@@ -307,11 +325,35 @@ func (ctx *Context) RegisterSubcommandCustom(cmd interface{}, name string) (*Sub
 	return s, nil
 }
 
+// Context returns a context that is canceled once the bot stops, which is when
+// the function returned by Start is called or the Session is closed. Commands
+// should make their API calls with it, so that shutting down doesn't leave
+// them hanging:
+//
+//    func (c *Commands) Slow(m *gateway.MessageCreateEvent) error {
+//        s := c.Ctx.WithContext(c.Ctx.Context())
+//        _, err := s.SendMessage(m.ChannelID, "Done.", nil)
+//        return err
+//    }
+func (ctx *Context) Context() context.Context {
+	if ctx.stopCtx == nil {
+		return context.Background()
+	}
+	return ctx.stopCtx
+}
+
 // Start adds itself into the discordgo Session handlers. This needs to be run.
 // The returned function is a delete function, which removes itself from the
-// Session handlers.
+// Session handlers and cancels Context().
 func (ctx *Context) Start() func() {
-	return ctx.State.AddHandler(func(v interface{}) {
+	var parent = context.Background()
+	if ctx.State.Session != nil {
+		parent = ctx.State.CloseContext()
+	}
+
+	ctx.stopCtx, ctx.stop = context.WithCancel(parent)
+
+	rm := ctx.State.AddHandler(func(v interface{}) {
 		err := ctx.callCmd(v)
 		if err == nil {
 			return
@@ -357,6 +399,13 @@ func (ctx *Context) Start() func() {
 			// TODO: there ought to be a better way lol
 		}
 	})
+
+	var stop = ctx.stop
+
+	return func() {
+		rm()
+		stop()
+	}
 }
 
 // Call should only be used if you know what you're doing.
@@ -364,9 +413,45 @@ func (ctx *Context) Call(event interface{}) error {
 	return ctx.callCmd(event)
 }
 
-// Help generates a full Help message. It serves mainly as a reference for
-// people to reimplement and change.
+// HelpFormatter formats the help message of the whole Context. It can be set
+// in Context to override the default format, for example to render the help
+// into an embed or a more compact list.
+type HelpFormatter interface {
+	FormatHelp(ctx *Context) api.SendMessageData
+}
+
+// HelpFormatterFunc is a function that implements HelpFormatter.
+type HelpFormatterFunc func(ctx *Context) api.SendMessageData
+
+// FormatHelp implements HelpFormatter.
+func (fn HelpFormatterFunc) FormatHelp(ctx *Context) api.SendMessageData {
+	return fn(ctx)
+}
+
+// HelpMessage returns the help message of the Context, formatted with the
+// HelpFormatter if there is one, or HelpGenerate otherwise. A help command can
+// be made by simply returning it, as the returned message is sent as-is:
+//
+//    func (c *Commands) Help(*gateway.MessageCreateEvent) (*api.SendMessageData, error) {
+//        return c.Ctx.HelpMessage(), nil
+//    }
+func (ctx *Context) HelpMessage() *api.SendMessageData {
+	if ctx.HelpFormatter != nil {
+		msg := ctx.HelpFormatter.FormatHelp(ctx)
+		return &msg
+	}
+	return &api.SendMessageData{Content: ctx.HelpGenerate()}
+}
+
+// Help returns the content of HelpMessage. Use HelpMessage instead if the
+// HelpFormatter might use embeds.
 func (ctx *Context) Help() string {
+	return ctx.HelpMessage().Content
+}
+
+// HelpGenerate generates a full Help message. It serves mainly as a reference
+// for people to reimplement and change.
+func (ctx *Context) HelpGenerate() string {
 	// Generate the header.
 	buf := strings.Builder{}
 	buf.WriteString("__Help__")
@@ -1,6 +1,7 @@
 package bot
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/diamondburned/arikawa/api"
 	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/gateway"
 	"github.com/diamondburned/arikawa/handler"
@@ -78,6 +80,30 @@ func TestNewContext(t *testing.T) {
 	}
 }
 
+func TestContextStop(t *testing.T) {
+	var state = &state.State{
+		Store:   state.NewDefaultStore(nil),
+		Handler: handler.New(),
+	}
+
+	c, err := New(state, &testc{})
+	if err != nil {
+		t.Fatal("Failed to create new context:", err)
+	}
+
+	stop := c.Start()
+
+	if err := c.Context().Err(); err != nil {
+		t.Fatal("Context canceled before stopping:", err)
+	}
+
+	stop()
+
+	if err := c.Context().Err(); err != context.Canceled {
+		t.Fatal("Context not canceled after stopping:", err)
+	}
+}
+
 func TestContext(t *testing.T) {
 	var given = &testc{}
 	var state = &state.State{
@@ -140,6 +166,25 @@ func TestContext(t *testing.T) {
 		}
 	})
 
+	t.Run("help formatter", func(t *testing.T) {
+		ctx.HelpFormatter = HelpFormatterFunc(func(ctx *Context) api.SendMessageData {
+			return api.SendMessageData{
+				Content: "custom",
+				Embeds:  []discord.Embed{{Title: ctx.Name}},
+			}
+		})
+		defer func() { ctx.HelpFormatter = nil }()
+
+		if h := ctx.Help(); h != "custom" {
+			t.Fatal("Unexpected help:", h)
+		}
+
+		msg := ctx.HelpMessage()
+		if len(msg.Embeds) != 1 || msg.Embeds[0].Title != "arikawa/bot test" {
+			t.Fatal("Unexpected help embeds:", msg.Embeds)
+		}
+	})
+
 	t.Run("middleware", func(t *testing.T) {
 		ctx.HasPrefix = NewPrefix("pls do ")
 
@@ -0,0 +1,26 @@
+package arguments
+
+import "github.com/diamondburned/arikawa/discord"
+
+// Color parses a hexadecimal color code, with or without the "#" or "0x"
+// prefix, e.g. "#7289DA". The 3 digit shorthand is accepted too. Refer to
+// discord.ParseColor.
+type Color discord.Color
+
+func (c *Color) Parse(arg string) error {
+	v, err := discord.ParseColor(arg)
+	if err != nil {
+		return err
+	}
+
+	*c = Color(v)
+	return nil
+}
+
+func (c *Color) Usage() string {
+	return "#color"
+}
+
+func (c Color) Color() discord.Color {
+	return discord.Color(c)
+}
@@ -0,0 +1,28 @@
+package arguments
+
+import "testing"
+
+func TestColor(t *testing.T) {
+	for _, str := range []string{"#7289DA", "7289da", "0x7289DA"} {
+		var c Color
+
+		if err := c.Parse(str); err != nil {
+			t.Fatal("Failed to parse", str, "error:", err)
+		}
+
+		if c != 0x7289DA {
+			t.Fatalf("Expected 0x7289DA, got %X", c)
+		}
+	}
+
+	var c Color
+	if err := c.Parse("#abc"); err != nil || c != 0xAABBCC {
+		t.Fatalf("Failed to parse short color: %X, error: %v", c, err)
+	}
+
+	for _, invalid := range []string{"", "#FFFF", "#GGGGGG"} {
+		if err := c.Parse(invalid); err == nil {
+			t.Fatal("Unexpected success parsing", invalid)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package arguments
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration parses a duration, either in the format accepted by
+// time.ParseDuration (e.g. "1h30m") or as a number of seconds. Days are also
+// accepted with the "d" suffix, e.g. "7d".
+type Duration time.Duration
+
+func (d *Duration) Parse(arg string) error {
+	if arg == "" {
+		return errors.New("invalid duration")
+	}
+
+	if s, err := strconv.ParseUint(arg, 10, 64); err == nil {
+		*d = Duration(time.Duration(s) * time.Second)
+		return nil
+	}
+
+	var days time.Duration
+
+	// time.ParseDuration doesn't know about days, so they're parsed first.
+	if i := strings.Index(arg, "d"); i > 0 {
+		n, err := strconv.ParseUint(arg[:i], 10, 64)
+		if err != nil {
+			return errors.New("invalid duration")
+		}
+
+		days = time.Duration(n) * 24 * time.Hour
+		arg = arg[i+1:]
+	}
+
+	var dura time.Duration
+
+	if arg != "" {
+		v, err := time.ParseDuration(arg)
+		if err != nil || v < 0 {
+			return errors.New("invalid duration")
+		}
+		dura = v
+	}
+
+	*d = Duration(days + dura)
+	return nil
+}
+
+func (d *Duration) Usage() string {
+	return "duration"
+}
+
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
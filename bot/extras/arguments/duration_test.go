@@ -0,0 +1,38 @@
+package arguments
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	var tests = []struct {
+		str    string
+		expect time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{"7d", 7 * 24 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+	}
+
+	for _, test := range tests {
+		var d Duration
+
+		if err := d.Parse(test.str); err != nil {
+			t.Fatal("Failed to parse", test.str, "error:", err)
+		}
+
+		if d.Duration() != test.expect {
+			t.Fatal("Expected", test.expect, "got", d.Duration())
+		}
+	}
+
+	var d Duration
+
+	for _, invalid := range []string{"", "d", "xd", "-1h", "1h1d"} {
+		if err := d.Parse(invalid); err == nil {
+			t.Fatal("Unexpected success parsing", invalid)
+		}
+	}
+}
@@ -15,7 +15,7 @@ var (
 )
 
 type Emoji struct {
-	ID   discord.Snowflake
+	ID   discord.EmojiID
 	Name string
 
 	Custom   bool
@@ -47,13 +47,7 @@ func (e Emoji) URL() string {
 		return ""
 	}
 
-	base := "https://cdn.discordapp.com/emojis/" + e.ID.String()
-
-	if e.Animated {
-		return base + ".gif"
-	} else {
-		return base + ".png"
-	}
+	return discord.EmojiAsset(e.ID, e.Animated).URL(discord.AutoImage, 0)
 }
 
 func (e *Emoji) Usage() string {
@@ -83,7 +77,7 @@ func (e *Emoji) Parse(arg string) error {
 	e.Custom = true
 	e.Animated = matches[1] == "a"
 	e.Name = matches[2]
-	e.ID = id
+	e.ID = discord.EmojiID(id)
 
 	return nil
 }
@@ -0,0 +1,112 @@
+package arguments
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// MemberGetter gets the members of a guild. It's implemented by *state.State,
+// which fetches missing members from the API, and by state.Store, which only
+// looks in the cache.
+type MemberGetter interface {
+	Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error)
+	Members(guildID discord.GuildID) ([]discord.Member, error)
+}
+
+// MemberArg is a guild member given as a mention, a user ID, or a name. The
+// name is matched case-insensitively against the username, the
+// "username#discriminator" tag and the nickname.
+//
+// Parsers don't have access to the state, so Parse only stores the argument,
+// and the command resolves it with Member:
+//
+//    func (b *Bot) Whois(m *gateway.MessageCreateEvent, arg arguments.MemberArg) (string, error) {
+//        member, err := arg.Member(b.Ctx, m.GuildID)
+//        if err != nil {
+//            return "", err
+//        }
+//        return member.User.Username, nil
+//    }
+type MemberArg struct {
+	// ID is the user ID of the member if the argument is a mention or an ID,
+	// or 0 if it's a name.
+	ID discord.UserID
+	// Name is the raw argument if it's a name.
+	Name string
+}
+
+func (m *MemberArg) Parse(arg string) error {
+	if arg == "" {
+		return errors.New("invalid member")
+	}
+
+	*m = MemberArg{}
+
+	var id = arg
+	if matches := UserRegex.FindStringSubmatch(arg); len(matches) == 2 && matches[0] == arg {
+		id = matches[1]
+	}
+
+	if s, err := discord.ParseSnowflake(id); err == nil && s.Valid() {
+		m.ID = discord.UserID(s)
+		return nil
+	}
+
+	m.Name = arg
+	return nil
+}
+
+func (m *MemberArg) Usage() string {
+	return "member"
+}
+
+// Member resolves the argument into a member of the given guild. A name that
+// matches more than one member returns an error.
+func (m MemberArg) Member(s MemberGetter, guildID discord.GuildID) (*discord.Member, error) {
+	if m.ID.Valid() {
+		member, err := s.Member(guildID, m.ID)
+		if err != nil {
+			return nil, errors.New("member not found")
+		}
+		return member, nil
+	}
+
+	members, err := s.Members(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *discord.Member
+
+	for i, member := range members {
+		if !memberHasName(member, m.Name) {
+			continue
+		}
+		if found != nil {
+			return nil, errors.New("more than one member is named " + m.Name)
+		}
+		found = &members[i]
+	}
+
+	if found == nil {
+		return nil, errors.New("member not found")
+	}
+
+	return found, nil
+}
+
+func memberHasName(m discord.Member, name string) bool {
+	if strings.EqualFold(m.User.Username, name) {
+		return true
+	}
+	if m.Nick != "" && strings.EqualFold(m.Nick, name) {
+		return true
+	}
+	// Users migrated to unique usernames have the discriminator "0".
+	if m.User.Discriminator != "" && m.User.Discriminator != "0" {
+		return strings.EqualFold(m.User.Username+"#"+m.User.Discriminator, name)
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package arguments
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/state"
+)
+
+func TestMemberArg(t *testing.T) {
+	const guildID discord.GuildID = 1
+
+	var store = state.NewDefaultStore(nil)
+
+	var members = []discord.Member{
+		{User: discord.User{ID: 10, Username: "arikawa", Discriminator: "0"}, Nick: "Ari"},
+		{User: discord.User{ID: 11, Username: "kawa", Discriminator: "1234"}},
+		{User: discord.User{ID: 12, Username: "twin", Discriminator: "0"}},
+		{User: discord.User{ID: 13, Username: "other", Discriminator: "0"}, Nick: "Twin"},
+	}
+	for i := range members {
+		if err := store.MemberSet(guildID, &members[i]); err != nil {
+			t.Fatal("Failed to set member:", err)
+		}
+	}
+
+	var tests = []struct {
+		arg    string
+		expect discord.UserID // 0 if not found
+	}{
+		{"<@10>", 10},
+		{"<@!11>", 11},
+		{"11", 11},
+		{"ARIKAWA", 10},
+		{"ari", 10},
+		{"kawa#1234", 11},
+		{"twin", 0},
+		{"nobody", 0},
+		{"99", 0},
+	}
+
+	for _, test := range tests {
+		var arg MemberArg
+		if err := arg.Parse(test.arg); err != nil {
+			t.Fatal("Failed to parse", test.arg, "error:", err)
+		}
+
+		m, err := arg.Member(store, guildID)
+		if test.expect == 0 {
+			if err == nil {
+				t.Fatal("Unexpected member for", test.arg, "got", m.User.ID)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal("Failed to resolve", test.arg, "error:", err)
+		}
+		if m.User.ID != test.expect {
+			t.Fatal("Expected", test.expect, "for", test.arg, "got", m.User.ID)
+		}
+	}
+
+	var arg MemberArg
+	if err := arg.Parse(""); err == nil {
+		t.Fatal("Unexpected success parsing an empty member")
+	}
+}
@@ -15,7 +15,7 @@ var (
 
 //
 
-type ChannelMention discord.Snowflake
+type ChannelMention discord.ChannelID
 
 func (m *ChannelMention) Parse(arg string) error {
 	return grabFirst(ChannelRegex, "channel mention", arg, (*discord.Snowflake)(m))
@@ -25,8 +25,8 @@ func (m *ChannelMention) Usage() string {
 	return "#channel"
 }
 
-func (m *ChannelMention) ID() discord.Snowflake {
-	return discord.Snowflake(*m)
+func (m *ChannelMention) ID() discord.ChannelID {
+	return discord.ChannelID(*m)
 }
 
 func (m *ChannelMention) Mention() string {
@@ -35,7 +35,7 @@ func (m *ChannelMention) Mention() string {
 
 //
 
-type UserMention discord.Snowflake
+type UserMention discord.UserID
 
 func (m *UserMention) Parse(arg string) error {
 	return grabFirst(UserRegex, "user mention", arg, (*discord.Snowflake)(m))
@@ -45,8 +45,8 @@ func (m *UserMention) Usage() string {
 	return "@user"
 }
 
-func (m *UserMention) ID() discord.Snowflake {
-	return discord.Snowflake(*m)
+func (m *UserMention) ID() discord.UserID {
+	return discord.UserID(*m)
 }
 
 func (m *UserMention) Mention() string {
@@ -55,7 +55,7 @@ func (m *UserMention) Mention() string {
 
 //
 
-type RoleMention discord.Snowflake
+type RoleMention discord.RoleID
 
 func (m *RoleMention) Parse(arg string) error {
 	return grabFirst(RoleRegex, "role mention", arg, (*discord.Snowflake)(m))
@@ -65,8 +65,8 @@ func (m *RoleMention) Usage() string {
 	return "@role"
 }
 
-func (m *RoleMention) ID() discord.Snowflake {
-	return discord.Snowflake(*m)
+func (m *RoleMention) ID() discord.RoleID {
+	return discord.RoleID(*m)
 }
 
 func (m *RoleMention) Mention() string {
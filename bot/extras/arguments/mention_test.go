@@ -15,18 +15,18 @@ func TestMention(t *testing.T) {
 
 	type mention interface {
 		Parse(arg string) error
-		ID() discord.Snowflake
 		Mention() string
 	}
 
 	var tests = []struct {
 		mention
+		ID  func() discord.Snowflake
 		str string
 		id  discord.Snowflake
 	}{
-		{&c, "<#123123>", 123123},
-		{&r, "<@&23321>", 23321},
-		{&u, "<@123123>", 123123},
+		{&c, func() discord.Snowflake { return discord.Snowflake(c.ID()) }, "<#123123>", 123123},
+		{&r, func() discord.Snowflake { return discord.Snowflake(r.ID()) }, "<@&23321>", 23321},
+		{&u, func() discord.Snowflake { return discord.Snowflake(u.ID()) }, "<@123123>", 123123},
 	}
 
 	for _, test := range tests {
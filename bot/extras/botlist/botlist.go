@@ -0,0 +1,195 @@
+// Package botlist periodically posts a bot's server count to bot lists, such
+// as top.gg. It does not depend on any particular bot list: lists are
+// implemented as Posters, and HTTPPoster covers most of them.
+//
+// Guilds are counted by a Counter. StateCounter counts the guilds of one State
+// per shard, and ShardedStoreCounter counts all shards of a ShardedStore.
+package botlist
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/state"
+	"github.com/diamondburned/arikawa/utils/httputil"
+	"github.com/pkg/errors"
+)
+
+// Stats contains the statistics posted to bot lists.
+type Stats struct {
+	Guilds int
+	Shards int
+}
+
+// Poster posts the statistics of a bot to a bot list.
+type Poster interface {
+	Post(ctx context.Context, botID discord.UserID, stats Stats) error
+}
+
+// PosterFunc is a function that implements Poster.
+type PosterFunc func(ctx context.Context, botID discord.UserID, stats Stats) error
+
+// Post implements Poster.
+func (fn PosterFunc) Post(ctx context.Context, botID discord.UserID, stats Stats) error {
+	return fn(ctx, botID, stats)
+}
+
+// HTTPPoster posts the statistics as a JSON body to a URL. This is the API that
+// most bot lists use.
+type HTTPPoster struct {
+	// URL is the endpoint to post to. Any "{id}" in the URL is replaced with
+	// the bot's ID, e.g. "https://top.gg/api/bots/{id}/stats".
+	URL string
+	// Token is sent in the Authorization header.
+	Token string
+	// Body returns the JSON body to post. It defaults to DefaultBody.
+	Body func(Stats) interface{}
+	// Client is the HTTP client to use. If it's nil, a client is created with
+	// httputil.NewClient() on the first Post and reused afterwards.
+	Client *httputil.Client
+
+	once sync.Once
+}
+
+var _ Poster = (*HTTPPoster)(nil)
+
+// DefaultBody returns the body used by top.gg and most other bot lists.
+func DefaultBody(stats Stats) interface{} {
+	return struct {
+		ServerCount int `json:"server_count"`
+		ShardCount  int `json:"shard_count,omitempty"`
+	}{
+		ServerCount: stats.Guilds,
+		ShardCount:  stats.Shards,
+	}
+}
+
+// Post implements Poster.
+func (p *HTTPPoster) Post(ctx context.Context, botID discord.UserID, stats Stats) error {
+	var body = p.Body
+	if body == nil {
+		body = DefaultBody
+	}
+
+	p.once.Do(func() {
+		if p.Client == nil {
+			p.Client = httputil.NewClient()
+		}
+	})
+
+	return p.Client.WithContext(ctx).FastRequest(
+		"POST", strings.Replace(p.URL, "{id}", botID.String(), -1),
+		httputil.WithHeaders(http.Header{"Authorization": {p.Token}}),
+		httputil.WithJSONBody(body(stats)),
+	)
+}
+
+// Counter returns the current statistics of the bot.
+type Counter func() (Stats, error)
+
+// StateCounter counts the guilds in the given states, which are usually one
+// per shard.
+func StateCounter(states ...*state.State) Counter {
+	return func() (Stats, error) {
+		var stats = Stats{Shards: len(states)}
+
+		for _, s := range states {
+			g, err := s.Store.Guilds()
+			if err != nil {
+				return stats, errors.Wrap(err, "failed to get guilds")
+			}
+			stats.Guilds += len(g)
+		}
+
+		return stats, nil
+	}
+}
+
+// ShardedStoreCounter counts the guilds of all shards in the given store. It's
+// meant for bots that run all of their shards in one process with a shared
+// ShardedStore, where it reports the aggregate guild count of the shard set.
+func ShardedStoreCounter(store *state.ShardedStore) Counter {
+	return func() (Stats, error) {
+		g, err := store.Guilds()
+		if err != nil {
+			return Stats{}, errors.Wrap(err, "failed to get guilds")
+		}
+
+		return Stats{Guilds: len(g), Shards: store.NumShards()}, nil
+	}
+}
+
+// Updater posts the bot's statistics to all Posters on an interval.
+type Updater struct {
+	BotID   discord.UserID
+	Count   Counter
+	Posters []Poster
+
+	// Interval is the time between each post. It defaults to 30 minutes.
+	Interval time.Duration
+
+	// ErrorLog is called when counting or posting fails. It defaults to
+	// log.Println.
+	ErrorLog func(error)
+}
+
+// NewUpdater creates a new Updater with the default interval.
+func NewUpdater(botID discord.UserID, count Counter, posters ...Poster) *Updater {
+	return &Updater{
+		BotID:    botID,
+		Count:    count,
+		Posters:  posters,
+		Interval: 30 * time.Minute,
+		ErrorLog: func(err error) {
+			log.Println("Bot list error:", err)
+		},
+	}
+}
+
+// Run posts the statistics right away, then on every interval until the
+// context is canceled. A failing Poster does not affect the others.
+func (u *Updater) Run(ctx context.Context) error {
+	var interval = u.Interval
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		u.Update(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Update posts the statistics to all Posters once.
+func (u *Updater) Update(ctx context.Context) {
+	stats, err := u.Count()
+	if err != nil {
+		u.logError(errors.Wrap(err, "failed to count stats"))
+		return
+	}
+
+	for _, p := range u.Posters {
+		if err := p.Post(ctx, u.BotID, stats); err != nil {
+			u.logError(errors.Wrap(err, "failed to post stats"))
+		}
+	}
+}
+
+func (u *Updater) logError(err error) {
+	if u.ErrorLog != nil {
+		u.ErrorLog(err)
+	}
+}
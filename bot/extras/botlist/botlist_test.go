@@ -0,0 +1,159 @@
+package botlist
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/state"
+	"github.com/diamondburned/arikawa/utils/httputil"
+)
+
+func TestHTTPPoster(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/bots/42/stats" {
+			t.Error("Unexpected request:", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "hunter2" {
+			t.Error("Unexpected authorization:", auth)
+		}
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error("Failed to read body:", err)
+		}
+		if body := strings.TrimSpace(string(b)); body != `{"server_count":10,"shard_count":2}` {
+			t.Error("Unexpected body:", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var p = &HTTPPoster{
+		URL:   srv.URL + "/bots/{id}/stats",
+		Token: "hunter2",
+	}
+
+	if err := p.Post(context.Background(), 42, Stats{Guilds: 10, Shards: 2}); err != nil {
+		t.Fatal("Failed to post:", err)
+	}
+
+	var client = p.Client
+	if client == nil {
+		t.Fatal("Client was not created")
+	}
+
+	if err := p.Post(context.Background(), 42, Stats{Guilds: 10, Shards: 2}); err != nil {
+		t.Fatal("Failed to post again:", err)
+	}
+	if p.Client != client {
+		t.Fatal("Client was created again")
+	}
+}
+
+func TestHTTPPosterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Unauthorized"}`))
+	}))
+	defer srv.Close()
+
+	var p = &HTTPPoster{
+		URL: srv.URL,
+		Body: func(stats Stats) interface{} {
+			return map[string]int{"guilds": stats.Guilds}
+		},
+	}
+
+	err := p.Post(context.Background(), 42, Stats{Guilds: 10})
+
+	var httpErr *httputil.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatal("Unexpected error:", err)
+	}
+	if httpErr.Status != http.StatusUnauthorized {
+		t.Fatal("Unexpected status:", httpErr.Status)
+	}
+}
+
+func TestShardedStoreCounter(t *testing.T) {
+	var store = state.NewShardedStore(2, func() state.Store { return state.NewDefaultStore(nil) })
+
+	for _, id := range []discord.GuildID{1 << 22, 2 << 22, 3 << 22} {
+		if err := store.GuildSet(&discord.Guild{ID: id}); err != nil {
+			t.Fatal("Failed to set guild:", err)
+		}
+	}
+
+	stats, err := ShardedStoreCounter(store)()
+	if err != nil {
+		t.Fatal("Failed to count:", err)
+	}
+	if stats != (Stats{Guilds: 3, Shards: 2}) {
+		t.Fatal("Unexpected stats:", stats)
+	}
+}
+
+func TestUpdater(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mutex sync.Mutex
+	var posted []Stats
+	var errs []error
+
+	var count int
+	var counter = func() (Stats, error) {
+		count++
+		return Stats{Guilds: count}, nil
+	}
+
+	var working = PosterFunc(func(_ context.Context, botID discord.UserID, stats Stats) error {
+		if botID != 42 {
+			t.Error("Unexpected bot ID:", botID)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		posted = append(posted, stats)
+		if len(posted) == 3 {
+			cancel()
+		}
+		return nil
+	})
+
+	var failing = PosterFunc(func(context.Context, discord.UserID, Stats) error {
+		return errors.New("down")
+	})
+
+	u := NewUpdater(42, counter, failing, working)
+	u.Interval = time.Millisecond
+	u.ErrorLog = func(err error) {
+		mutex.Lock()
+		errs = append(errs, err)
+		mutex.Unlock()
+	}
+
+	if err := u.Run(ctx); err != context.Canceled {
+		t.Fatal("Unexpected Run error:", err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(posted) != 3 || posted[0].Guilds != 1 || posted[2].Guilds != 3 {
+		t.Fatal("Unexpected posts:", posted)
+	}
+	if len(errs) != 3 {
+		t.Fatal("Unexpected number of errors:", len(errs))
+	}
+}
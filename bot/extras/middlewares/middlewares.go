@@ -1,6 +1,8 @@
 package middlewares
 
 import (
+	"fmt"
+
 	"github.com/diamondburned/arikawa/bot"
 	"github.com/diamondburned/arikawa/bot/extras/infer"
 	"github.com/diamondburned/arikawa/discord"
@@ -47,3 +49,59 @@ func GuildOnly(ctx *bot.Context) func(interface{}) error {
 		return nil
 	}
 }
+
+// MissingPermissionsError is returned by RequirePermissions if the user lacks
+// any of the required permissions. Unlike Break, it is replied to the user,
+// which can be customized with the Context's FormatError.
+type MissingPermissionsError struct {
+	Missing discord.Permissions
+}
+
+func (err *MissingPermissionsError) Error() string {
+	return fmt.Sprintf("missing permissions: %v", err.Missing)
+}
+
+// RequirePermissions only allows users with all the given permissions in the
+// channel. It returns a *MissingPermissionsError if the user lacks some of
+// them, or Break if the permissions can't be determined.
+func RequirePermissions(ctx *bot.Context, perms discord.Permissions) func(interface{}) error {
+	return func(ev interface{}) error {
+		var channelID = infer.ChannelID(ev)
+		if !channelID.Valid() {
+			return bot.Break
+		}
+
+		var userID = infer.UserID(ev)
+		if !userID.Valid() {
+			return bot.Break
+		}
+
+		p, err := ctx.Permissions(channelID, userID)
+		if err != nil {
+			return bot.Break
+		}
+
+		// Administrators implicitly have every permission.
+		if p.Has(discord.PermissionAdministrator) || p.Has(perms) {
+			return nil
+		}
+
+		return &MissingPermissionsError{Missing: perms &^ p}
+	}
+}
+
+// OwnerOnly only allows the given users, which are usually the owners of the
+// bot.
+func OwnerOnly(ownerIDs ...discord.UserID) func(interface{}) error {
+	return func(ev interface{}) error {
+		var userID = infer.UserID(ev)
+
+		for _, id := range ownerIDs {
+			if id == userID {
+				return nil
+			}
+		}
+
+		return bot.Break
+	}
+}
@@ -89,6 +89,59 @@ func TestGuildOnly(t *testing.T) {
 	})
 }
 
+func TestRequirePermissions(t *testing.T) {
+	var ctx = &bot.Context{
+		State: &state.State{
+			Store: &mockStore{},
+		},
+	}
+	var middleware = RequirePermissions(ctx, discord.PermissionBanMembers)
+
+	t.Run("allow admin", func(t *testing.T) {
+		var msg = &gateway.MessageCreateEvent{
+			Message: discord.Message{
+				ID:        1,
+				ChannelID: 1337,
+				Author:    discord.User{ID: 69420},
+			},
+		}
+		expectNil(t, middleware(msg))
+	})
+
+	t.Run("deny missing permissions", func(t *testing.T) {
+		var msg = &gateway.MessageCreateEvent{
+			Message: discord.Message{
+				ID:        2,
+				ChannelID: 1337,
+				Author:    discord.User{ID: 1337},
+			},
+		}
+
+		var perr *MissingPermissionsError
+		if err := middleware(msg); !errors.As(err, &perr) {
+			t.Fatal("Unexpected error:", err)
+		}
+		if perr.Missing != discord.PermissionBanMembers {
+			t.Fatal("Unexpected missing permissions:", perr.Missing)
+		}
+		if perr.Error() != "missing permissions: BAN_MEMBERS" {
+			t.Fatal("Unexpected error message:", perr.Error())
+		}
+	})
+}
+
+func TestOwnerOnly(t *testing.T) {
+	var middleware = OwnerOnly(69420)
+
+	expectNil(t, middleware(&gateway.MessageCreateEvent{
+		Message: discord.Message{Author: discord.User{ID: 69420}},
+	}))
+	expectBreak(t, middleware(&gateway.MessageCreateEvent{
+		Message: discord.Message{Author: discord.User{ID: 1337}},
+	}))
+	expectBreak(t, middleware(&gateway.TypingStartEvent{}))
+}
+
 func expectNil(t *testing.T, err error) {
 	t.Helper()
 	if err != nil {
@@ -162,7 +215,7 @@ type mockStore struct {
 	state.NoopStore
 }
 
-func (s *mockStore) Guild(id discord.Snowflake) (*discord.Guild, error) {
+func (s *mockStore) Guild(id discord.GuildID) (*discord.Guild, error) {
 	return &discord.Guild{
 		ID: id,
 		Roles: []discord.Role{{
@@ -172,15 +225,15 @@ func (s *mockStore) Guild(id discord.Snowflake) (*discord.Guild, error) {
 	}, nil
 }
 
-func (s *mockStore) Member(g, m discord.Snowflake) (*discord.Member, error) {
+func (s *mockStore) Member(g discord.GuildID, m discord.UserID) (*discord.Member, error) {
 	return &discord.Member{
 		User:    discord.User{ID: m},
-		RoleIDs: []discord.Snowflake{m},
+		RoleIDs: []discord.RoleID{discord.RoleID(m)},
 	}, nil
 }
 
 // Channel returns a channel with a guildID for #69420.
-func (s *mockStore) Channel(chID discord.Snowflake) (*discord.Channel, error) {
+func (s *mockStore) Channel(chID discord.ChannelID) (*discord.Channel, error) {
 	if chID == 69420 {
 		return &discord.Channel{
 			ID:      chID,
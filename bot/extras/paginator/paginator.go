@@ -0,0 +1,242 @@
+// Package paginator sends a list of embeds as one message that users can page
+// through with reactions:
+//
+//    p := paginator.New(ctx.State, pages...)
+//    p.UserID = ev.Author.ID
+//
+//    go p.Send(context.Background(), ev.ChannelID)
+//
+// The message is edited in place on every page change.
+package paginator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/api"
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/state"
+)
+
+// The default emojis used to navigate.
+const (
+	FirstEmoji    api.Emoji = "⏮"
+	PreviousEmoji api.Emoji = "◀"
+	NextEmoji     api.Emoji = "▶"
+	LastEmoji     api.Emoji = "⏭"
+	StopEmoji     api.Emoji = "⏹"
+)
+
+// Paginator is a message with pages. It's not safe to send the same Paginator
+// twice at the same time.
+type Paginator struct {
+	State *state.State
+	Pages []discord.Embed
+
+	// UserID is the only user that can change pages. If it's not valid, anyone
+	// can.
+	UserID discord.UserID
+	// Timeout is the time of inactivity after which the reactions are removed
+	// and the pages can no longer be changed. It defaults to 2 minutes.
+	Timeout time.Duration
+	// Emojis are the reactions used to navigate, in the order they're added.
+	// Unknown emojis are ignored.
+	Emojis []api.Emoji
+	// PageFooter, if true, sets the footer of pages that have none to the page
+	// number.
+	PageFooter bool
+
+	page int
+}
+
+// New creates a new Paginator with the default emojis.
+func New(s *state.State, pages ...discord.Embed) *Paginator {
+	return &Paginator{
+		State:      s,
+		Pages:      pages,
+		Timeout:    2 * time.Minute,
+		Emojis:     []api.Emoji{FirstEmoji, PreviousEmoji, NextEmoji, LastEmoji, StopEmoji},
+		PageFooter: true,
+	}
+}
+
+// Page returns the index of the current page.
+func (p *Paginator) Page() int {
+	return p.page
+}
+
+// Send sends the first page to the channel, then changes pages on reactions
+// until the timeout, the stop emoji, or the context is canceled. It blocks
+// until then, so it's usually called in a goroutine.
+//
+// The reactions of users are removed after they're handled if the bot has the
+// MANAGE_MESSAGES permission, so that the same reaction can be used again.
+// Otherwise, both adding and removing a reaction change the page.
+func (p *Paginator) Send(ctx context.Context, channelID discord.ChannelID) error {
+	if len(p.Pages) == 0 {
+		return errors.New("no pages to send")
+	}
+
+	p.page = 0
+
+	m, err := p.State.SendEmbed(channelID, p.embed())
+	if err != nil {
+		return errors.Wrap(err, "failed to send first page")
+	}
+
+	if len(p.Pages) == 1 {
+		return nil
+	}
+
+	me, err := p.State.Me()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current user")
+	}
+
+	// Reactions of others can't be removed in DMs.
+	var manage bool
+	if ch, err := p.State.Channel(channelID); err == nil && ch.GuildID.Valid() {
+		perm, err := p.State.Permissions(channelID, me.ID)
+		manage = err == nil && perm.Has(discord.PermissionManageMessages)
+	}
+
+	var reactions = make(chan reaction)
+	var done = make(chan struct{})
+	defer close(done)
+
+	var send = func(r reaction) {
+		if r.messageID != m.ID || r.userID == me.ID {
+			return
+		}
+		if p.UserID.Valid() && r.userID != p.UserID {
+			return
+		}
+		select {
+		case reactions <- r:
+		case <-done:
+		}
+	}
+
+	rm := p.State.AddHandler(func(ev *gateway.MessageReactionAddEvent) {
+		send(reaction{ev.MessageID, ev.UserID, ev.Emoji, true})
+	})
+	defer rm()
+
+	if !manage {
+		rm := p.State.AddHandler(func(ev *gateway.MessageReactionRemoveEvent) {
+			send(reaction{ev.MessageID, ev.UserID, ev.Emoji, false})
+		})
+		defer rm()
+	}
+
+	for _, emoji := range p.Emojis {
+		if err := p.State.React(channelID, m.ID, emoji); err != nil {
+			return errors.Wrap(err, "failed to add reaction")
+		}
+	}
+
+	var timeout = p.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case r := <-reactions:
+			var emoji = r.emoji.APIString()
+
+			if manage && r.added {
+				// Failing to remove the reaction only means it has to be
+				// clicked twice.
+				p.State.DeleteUserReaction(channelID, m.ID, r.userID, emoji)
+			}
+
+			if emoji == StopEmoji {
+				return p.finish(channelID, m.ID, manage)
+			}
+
+			if !p.navigate(emoji) {
+				continue
+			}
+
+			if _, err := p.State.EditEmbed(channelID, m.ID, p.embed()); err != nil {
+				return errors.Wrap(err, "failed to edit page")
+			}
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+
+		case <-timer.C:
+			return p.finish(channelID, m.ID, manage)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+type reaction struct {
+	messageID discord.MessageID
+	userID    discord.UserID
+	emoji     discord.Emoji
+	added     bool
+}
+
+// navigate changes the page, and returns false if the page is the same.
+func (p *Paginator) navigate(emoji api.Emoji) bool {
+	var page = p.page
+
+	switch emoji {
+	case FirstEmoji:
+		page = 0
+	case PreviousEmoji:
+		page--
+	case NextEmoji:
+		page++
+	case LastEmoji:
+		page = len(p.Pages) - 1
+	}
+
+	if page < 0 || page >= len(p.Pages) || page == p.page {
+		return false
+	}
+
+	p.page = page
+	return true
+}
+
+func (p *Paginator) embed() discord.Embed {
+	var e = p.Pages[p.page]
+	if p.PageFooter && e.Footer == nil {
+		e.Footer = &discord.EmbedFooter{
+			Text: fmt.Sprintf("Page %d/%d", p.page+1, len(p.Pages)),
+		}
+	}
+	return e
+}
+
+// finish removes all reactions if the bot can, or only its own otherwise.
+func (p *Paginator) finish(
+	channelID discord.ChannelID, messageID discord.MessageID, manage bool) error {
+
+	if manage {
+		return p.State.DeleteAllReactions(channelID, messageID)
+	}
+
+	for _, emoji := range p.Emojis {
+		if err := p.State.Unreact(channelID, messageID, emoji); err != nil {
+			return errors.Wrap(err, "failed to remove reaction")
+		}
+	}
+
+	return nil
+}
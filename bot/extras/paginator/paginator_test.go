@@ -0,0 +1,35 @@
+package paginator
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func TestNavigate(t *testing.T) {
+	p := New(nil, discord.Embed{Title: "1"}, discord.Embed{Title: "2"}, discord.Embed{Title: "3"})
+
+	var tests = []struct {
+		emoji   string
+		page    int
+		changed bool
+	}{
+		{PreviousEmoji, 0, false},
+		{NextEmoji, 1, true},
+		{LastEmoji, 2, true},
+		{NextEmoji, 2, false},
+		{"🤔", 2, false},
+		{FirstEmoji, 0, true},
+	}
+
+	for _, test := range tests {
+		if changed := p.navigate(test.emoji); changed != test.changed || p.Page() != test.page {
+			t.Fatalf("%s: expected page %d (%v), got %d (%v)",
+				test.emoji, test.page, test.changed, p.Page(), changed)
+		}
+	}
+
+	if e := p.embed(); e.Footer == nil || e.Footer.Text != "Page 1/3" {
+		t.Fatal("Unexpected footer:", e.Footer)
+	}
+}
@@ -199,14 +199,15 @@ func (sub *Subcommand) HelpGenerate() string {
 		buf.WriteString(sub.Command + " " + cmd.Command)
 
 		// Write the usages first.
-		for _, usage := range cmd.Usage() {
+		var usages = cmd.Usage()
+		for j, usage := range usages {
 			// Is the last argument trailing? If so, append ellipsis.
-			if cmd.Variadic {
+			if cmd.Variadic && j == len(usages)-1 {
 				usage += "..."
 			}
 
 			// Uses \u2000, which is wider than a space.
-			buf.WriteString(s + "__" + usage + "__")
+			buf.WriteString(s + underline(usage))
 		}
 
 		// Write the description if there's any.
@@ -24,13 +24,13 @@ type AuditLog struct {
 // https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object
 type AuditLogEntry struct {
 	// ID is the id of the entry.
-	ID Snowflake `json:"id"`
+	ID AuditLogEntryID `json:"id"`
 	// TargetID is the id of the affected entity (webhook, user, role, etc.).
 	TargetID string `json:"target_id,omitempty"`
 	// Changes are the changes made to the TargetID.
 	Changes []AuditLogChange `json:"changes,omitempty"`
 	// UserID is the id of the user who made the changes.
-	UserID Snowflake `json:"user_id"`
+	UserID UserID `json:"user_id"`
 
 	// ActionType is the type of action that occurred.
 	ActionType AuditLogEvent `json:"action_type"`
@@ -97,11 +97,11 @@ type AuditEntryInfo struct {
 	// ChannelID is the id of the channel in which the entities were targeted.
 	//
 	// Events: MEMBER_MOVE, MESSAGE_PIN, MESSAGE_UNPIN, MESSAGE_DELETE
-	ChannelID Snowflake `json:"channel_id,omitempty"`
+	ChannelID ChannelID `json:"channel_id,omitempty"`
 	// MessagesID is the id of the message that was targeted.
 	//
 	// Events: MESSAGE_PIN, MESSAGE_UNPIN
-	MessageID Snowflake `json:"message_id,omitempty"`
+	MessageID MessageID `json:"message_id,omitempty"`
 	// Count is the number of entities that were targeted.
 	//
 	// Events: MESSAGE_DELETE, MESSAGE_BULK_DELETE, MEMBER_DISCONNECT,
@@ -152,7 +152,7 @@ const (
 //    }
 //
 //    // We know these are snowflakes because the comment said so for AuditGuildOwnerID.
-//    var oldOwnerID, newOwnerID discord.Snowflake
+//    var oldOwnerID, newOwnerID discord.UserID
 //    if err := change.UnmarshalValues(&oldOwnerID, &newOwnerID); err != nil {
 //        return err
 //    }
@@ -0,0 +1,231 @@
+package discord
+
+import "strconv"
+
+// CDNURL is the base URL of all assets hosted by Discord.
+var CDNURL URL = "https://cdn.discordapp.com/"
+
+// The image sizes that the CDN accepts. Sizes are powers of 2 within them.
+const (
+	MinImageSize = 16
+	MaxImageSize = 4096
+)
+
+// CDNAsset is an image on Discord's CDN. The functions below return the
+// assets that Discord documents, and URL builds the link to one:
+//
+//    url := discord.GuildIconAsset(g.ID, g.Icon).URL(discord.AutoImage, 128)
+//
+// Most types also have URL methods that use these, such as Guild's IconURL.
+type CDNAsset struct {
+	// Path is the path of the asset on the CDN without the extension.
+	Path string
+	// Animated is true if the asset is available as a GIF.
+	Animated bool
+	// Types are the image types that the asset is available in. The first one
+	// is used if the requested type isn't available.
+	Types []ImageType
+}
+
+// The image types that most assets are available in.
+var staticImageTypes = []ImageType{PNGImage, JPEGImage, WebPImage, GIFImage}
+
+func hashAsset(path string, hash Hash) CDNAsset {
+	if hash == "" {
+		return CDNAsset{}
+	}
+
+	return CDNAsset{
+		Path:     path + hash,
+		Animated: HashAnimated(hash),
+		Types:    staticImageTypes,
+	}
+}
+
+// GuildIconAsset returns the icon of a guild.
+func GuildIconAsset(guildID GuildID, hash Hash) CDNAsset {
+	return hashAsset("icons/"+guildID.String()+"/", hash)
+}
+
+// GuildBannerAsset returns the banner of a guild.
+func GuildBannerAsset(guildID GuildID, hash Hash) CDNAsset {
+	return hashAsset("banners/"+guildID.String()+"/", hash)
+}
+
+// GuildSplashAsset returns the invite splash of a guild.
+func GuildSplashAsset(guildID GuildID, hash Hash) CDNAsset {
+	return hashAsset("splashes/"+guildID.String()+"/", hash)
+}
+
+// GuildDiscoverySplashAsset returns the discovery splash of a guild.
+func GuildDiscoverySplashAsset(guildID GuildID, hash Hash) CDNAsset {
+	return hashAsset("discovery-splashes/"+guildID.String()+"/", hash)
+}
+
+// UserAvatarAsset returns the avatar of a user. Users without an avatar have a
+// default one, which User's AvatarURL falls back to.
+func UserAvatarAsset(userID UserID, hash Hash) CDNAsset {
+	return hashAsset("avatars/"+userID.String()+"/", hash)
+}
+
+// DefaultAvatarAsset returns the default avatar with the given index, which is
+// only available as a PNG.
+func DefaultAvatarAsset(index int) CDNAsset {
+	return CDNAsset{
+		Path:  "embed/avatars/" + strconv.Itoa(index),
+		Types: []ImageType{PNGImage},
+	}
+}
+
+// UserBannerAsset returns the banner of a user.
+func UserBannerAsset(userID UserID, hash Hash) CDNAsset {
+	return hashAsset("banners/"+userID.String()+"/", hash)
+}
+
+// MemberAvatarAsset returns the guild-specific avatar of a member.
+func MemberAvatarAsset(guildID GuildID, userID UserID, hash Hash) CDNAsset {
+	return hashAsset("guilds/"+guildID.String()+"/users/"+userID.String()+"/avatars/", hash)
+}
+
+// ChannelIconAsset returns the icon of a group DM.
+func ChannelIconAsset(channelID ChannelID, hash Hash) CDNAsset {
+	return hashAsset("channel-icons/"+channelID.String()+"/", hash)
+}
+
+// RoleIconAsset returns the icon of a role.
+func RoleIconAsset(roleID RoleID, hash Hash) CDNAsset {
+	return hashAsset("role-icons/"+roleID.String()+"/", hash)
+}
+
+// AvatarDecorationAsset returns an avatar decoration, which is only available
+// as a PNG.
+func AvatarDecorationAsset(hash Hash) CDNAsset {
+	if hash == "" {
+		return CDNAsset{}
+	}
+
+	return CDNAsset{
+		Path:  "avatar-decoration-presets/" + hash,
+		Types: []ImageType{PNGImage},
+	}
+}
+
+// NameplateAsset returns the static image of a nameplate, which is only
+// available as a PNG. The asset is the path of the nameplate, such as
+// "nameplates/nameplates/twilight/".
+func NameplateAsset(asset string) CDNAsset {
+	if asset == "" {
+		return CDNAsset{}
+	}
+
+	return CDNAsset{
+		Path:  "assets/collectibles/" + asset + "static",
+		Types: []ImageType{PNGImage},
+	}
+}
+
+// EmojiAsset returns a custom emoji. Emoji IDs don't tell whether the emoji is
+// animated, so it has to be given.
+func EmojiAsset(emojiID EmojiID, animated bool) CDNAsset {
+	if !emojiID.Valid() {
+		return CDNAsset{}
+	}
+
+	return CDNAsset{
+		Path:     "emojis/" + emojiID.String(),
+		Animated: animated,
+		Types:    staticImageTypes,
+	}
+}
+
+// StickerFormat is the format of a sticker.
+type StickerFormat uint8
+
+const (
+	PNGSticker    StickerFormat = 1
+	APNGSticker   StickerFormat = 2
+	LottieSticker StickerFormat = 3
+	GIFSticker    StickerFormat = 4
+)
+
+// StickerAsset returns a sticker. Lottie stickers are only available as
+// LottieImage, GIF stickers only as GIFImage, and the others only as PNGImage.
+func StickerAsset(stickerID StickerID, format StickerFormat) CDNAsset {
+	var t = PNGImage
+	switch format {
+	case LottieSticker:
+		t = LottieImage
+	case GIFSticker:
+		t = GIFImage
+	}
+
+	return CDNAsset{
+		Path:     "stickers/" + stickerID.String(),
+		Animated: format == GIFSticker,
+		Types:    []ImageType{t},
+	}
+}
+
+// URL returns the link to the asset in the given type and size, or an empty
+// string if the asset is empty.
+//
+// AutoImage picks GIFImage for animated assets and PNGImage otherwise. If the
+// asset isn't available in the type, such as GIFImage for an asset that isn't
+// animated, the first type it's available in is used instead.
+//
+// A size of 0 leaves the size to Discord. Other sizes are clamped between
+// MinImageSize and MaxImageSize, then rounded down to a power of 2.
+func (a CDNAsset) URL(t ImageType, size int) URL {
+	if a.Path == "" {
+		return ""
+	}
+
+	if t == AutoImage {
+		t = PNGImage
+		if a.Animated {
+			t = GIFImage
+		}
+	}
+
+	if !a.has(t) && len(a.Types) > 0 {
+		t = a.Types[0]
+	}
+
+	var url = CDNURL + a.Path + string(t)
+	if size > 0 {
+		url = SizedURL(url, size)
+	}
+
+	return url
+}
+
+func (a CDNAsset) has(t ImageType) bool {
+	if t == GIFImage && !a.Animated {
+		return false
+	}
+
+	for _, typ := range a.Types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// clampImageSize clamps the size between MinImageSize and MaxImageSize, and
+// rounds it down to a power of 2.
+func clampImageSize(size int) int {
+	if size <= MinImageSize {
+		return MinImageSize
+	}
+	if size >= MaxImageSize {
+		return MaxImageSize
+	}
+
+	var pow = MinImageSize
+	for pow*2 <= size {
+		pow *= 2
+	}
+
+	return pow
+}
@@ -0,0 +1,68 @@
+package discord
+
+import "testing"
+
+func TestCDNAssetURL(t *testing.T) {
+	var tests = []struct {
+		name   string
+		url    URL
+		expect URL
+	}{
+		{
+			"auto animated",
+			GuildIconAsset(1, "a_hash").URL(AutoImage, 0),
+			"https://cdn.discordapp.com/icons/1/a_hash.gif",
+		},
+		{
+			"auto static",
+			GuildIconAsset(1, "hash").URL(AutoImage, 0),
+			"https://cdn.discordapp.com/icons/1/hash.png",
+		},
+		{
+			"GIF of static",
+			EmojiAsset(2, false).URL(GIFImage, 0),
+			"https://cdn.discordapp.com/emojis/2.png",
+		},
+		{
+			"WebP",
+			GuildDiscoverySplashAsset(1, "hash").URL(WebPImage, 0),
+			"https://cdn.discordapp.com/discovery-splashes/1/hash.webp",
+		},
+		{
+			"Lottie sticker",
+			StickerAsset(3, LottieSticker).URL(PNGImage, 0),
+			"https://cdn.discordapp.com/stickers/3.json",
+		},
+		{
+			"rounded size",
+			RoleIconAsset(4, "hash").URL(PNGImage, 100),
+			"https://cdn.discordapp.com/role-icons/4/hash.png?size=64",
+		},
+		{
+			"clamped size",
+			MemberAvatarAsset(1, 5, "hash").URL(JPEGImage, 10000),
+			"https://cdn.discordapp.com/guilds/1/users/5/avatars/hash.jpeg?size=4096",
+		},
+		{
+			"empty hash",
+			UserBannerAsset(5, "").URL(AutoImage, 64),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		if test.url != test.expect {
+			t.Fatalf("Unexpected %s URL: %q", test.name, test.url)
+		}
+	}
+}
+
+func TestClampImageSize(t *testing.T) {
+	var tests = [][2]int{{1, 16}, {16, 16}, {17, 16}, {32, 32}, {1000, 512}, {4096, 4096}, {5000, 4096}}
+
+	for _, test := range tests {
+		if size := clampImageSize(test[0]); size != test[1] {
+			t.Fatalf("Size %d was clamped to %d, expected %d", test[0], size, test[1])
+		}
+	}
+}
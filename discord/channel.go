@@ -1,12 +1,19 @@
 package discord
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 type Channel struct {
-	ID   Snowflake   `json:"id,string"`
+	ID   ChannelID   `json:"id"`
 	Type ChannelType `json:"type"`
 
 	// Fields below may not appear
 
-	GuildID Snowflake `json:"guild_id,string,omitempty"`
+	GuildID GuildID `json:"guild_id,omitempty"`
 
 	Position int    `json:"position,omitempty"`
 	Name     string `json:"name,omitempty"`  // 2-100 chars
@@ -16,21 +23,21 @@ type Channel struct {
 	Icon Hash `json:"icon,omitempty"`
 
 	// Direct Messaging fields
-	DMOwnerID    Snowflake `json:"owner_id,string,omitempty"`
-	DMRecipients []User    `json:"recipients,omitempty"`
+	DMOwnerID    UserID `json:"owner_id,omitempty"`
+	DMRecipients []User `json:"recipients,omitempty"`
 
 	// AppID of the group DM creator if it's bot-created
-	AppID Snowflake `json:"application_id,string,omitempty"`
+	AppID AppID `json:"application_id,omitempty"`
 
 	// ID of the category the channel is in, if any.
-	CategoryID Snowflake `json:"parent_id,string,omitempty"`
+	CategoryID ChannelID `json:"parent_id,omitempty"`
 
 	LastPinTime Timestamp `json:"last_pin_timestamp,omitempty"`
 
 	// Explicit permission overrides for members and roles.
 	Permissions []Overwrite `json:"permission_overwrites,omitempty"`
 	// ID of the last message, may not point to a valid one.
-	LastMessageID Snowflake `json:"last_message_id,string,omitempty"`
+	LastMessageID MessageID `json:"last_message_id,omitempty"`
 
 	// Slow mode duration. Bots and people with "manage_messages" or
 	// "manage_channel" permissions are unaffected.
@@ -39,21 +46,22 @@ type Channel struct {
 	// Voice, so GuildVoice only
 	VoiceBitrate   uint `json:"bitrate,omitempty"`
 	VoiceUserLimit uint `json:"user_limit,omitempty"`
+	// VoiceRTCRegion is the ID of the voice region of the channel. It's empty
+	// if the region is picked automatically.
+	VoiceRTCRegion string `json:"rtc_region,omitempty"`
+	// VoiceQualityMode is the camera video quality of the channel. It's 0 if
+	// the mode was never set, which is treated as AutoVideoQuality.
+	VoiceQualityMode VideoQualityMode `json:"video_quality_mode,omitempty"`
 }
 
 func (ch Channel) Mention() string {
-	return "<#" + ch.ID.String() + ">"
+	return ch.ID.Mention()
 }
 
 // IconURL returns the icon of the channel. This function will only return
 // something if ch.Icon is not empty.
 func (ch Channel) IconURL() string {
-	if ch.Icon == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/channel-icons/" +
-		ch.ID.String() + "/" + ch.Icon + ".png"
+	return ChannelIconAsset(ch.ID, ch.Icon).URL(PNGImage, 0)
 }
 
 type ChannelType uint8
@@ -68,16 +76,66 @@ var (
 	GuildStore    ChannelType = 6
 )
 
+// VideoQualityMode is the camera video quality of a voice channel.
+type VideoQualityMode uint8
+
+const (
+	// AutoVideoQuality lets Discord choose the quality for optimal
+	// performance.
+	AutoVideoQuality VideoQualityMode = 1
+	// FullVideoQuality is 720p.
+	FullVideoQuality VideoQualityMode = 2
+)
+
+// Overwrite is a permission overwrite of a role or a member in a channel. The
+// Allow and Deny permissions are sent by Discord as strings, which Permissions
+// decodes.
 type Overwrite struct {
-	ID    Snowflake     `json:"id,string,omitempty"`
+	ID    Snowflake     `json:"id,omitempty"`
 	Type  OverwriteType `json:"type"`
 	Allow Permissions   `json:"allow"`
 	Deny  Permissions   `json:"deny"`
 }
 
-type OverwriteType string
+// OverwriteType is whether an overwrite is of a role or a member. It is encoded
+// as a number, but the strings "role" and "member" that older API versions use
+// are also decoded.
+type OverwriteType uint8
 
 const (
-	OverwriteRole   OverwriteType = "role"
-	OverwriteMember OverwriteType = "member"
+	OverwriteRole OverwriteType = iota
+	OverwriteMember
 )
+
+// String returns "role" or "member", or "unknown" for other types.
+func (t OverwriteType) String() string {
+	switch t {
+	case OverwriteRole:
+		return "role"
+	case OverwriteMember:
+		return "member"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalJSON decodes the type from a number, a string of a number, or the
+// "role" or "member" string.
+func (t *OverwriteType) UnmarshalJSON(b []byte) error {
+	switch s := strings.Trim(string(b), `"`); s {
+	case "null":
+		return nil
+	case "role":
+		*t = OverwriteRole
+	case "member":
+		*t = OverwriteMember
+	default:
+		u, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return errors.Wrap(err, "invalid overwrite type")
+		}
+		*t = OverwriteType(u)
+	}
+
+	return nil
+}
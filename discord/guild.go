@@ -1,13 +1,18 @@
 package discord
 
+import (
+	"sort"
+	"time"
+)
+
 // https://discord.com/developers/docs/resources/guild#guild-object
 type Guild struct {
 	// ID is the guild id.
-	ID Snowflake `json:"id,string"`
+	ID GuildID `json:"id"`
 	// Name is the guild name (2-100 characters, excluding trailing and leading
 	// whitespace).
 	Name string `json:"name"`
-	// Icon is the icon hash.&nullableUint64{}
+	// Icon is the icon hash.
 	Icon Hash `json:"icon"`
 	// Splash is the splash hash.
 	Splash Hash `json:"splash,omitempty"`
@@ -19,17 +24,20 @@ type Guild struct {
 	// Owner is true if the user is the owner of the guild.
 	Owner bool `json:"owner,omitempty"`
 	// OwnerID is the id of owner.
-	OwnerID Snowflake `json:"owner_id,string"`
+	OwnerID UserID `json:"owner_id"`
 
 	// Permissions are the total permissions for the user in the guild
 	// (excludes overrides).
 	Permissions Permissions `json:"permissions,omitempty"`
 
 	// VoiceRegion is the voice region id for the guild.
+	//
+	// Deprecated: Voice regions are set per channel since API v9. Refer to
+	// Channel's VoiceRTCRegion.
 	VoiceRegion string `json:"region"`
 
 	// AFKChannelID is the id of the afk channel.
-	AFKChannelID Snowflake `json:"afk_channel_id,string,omitempty"`
+	AFKChannelID ChannelID `json:"afk_channel_id,omitempty"`
 	// AFKTimeout is the afk timeout in seconds.
 	AFKTimeout Seconds `json:"afk_timeout"`
 
@@ -41,7 +49,7 @@ type Guild struct {
 	// to, or null if set to no invite .
 	//
 	// Deprecated: replaced with WidgetChannelID
-	EmbedChannelID Snowflake `json:"embed_channel_id,string,omitempty"`
+	EmbedChannelID ChannelID `json:"embed_channel_id,omitempty"`
 
 	// Verification is the verification level required for the guild.
 	Verification Verification `json:"verification_level"`
@@ -54,8 +62,10 @@ type Guild struct {
 	Roles []Role `json:"roles"`
 	// Emojis are the custom guild emojis.
 	Emojis []Emoji `json:"emojis"`
+	// Stickers are the custom guild stickers.
+	Stickers []Sticker `json:"stickers,omitempty"`
 	// Features are the enabled guild features.
-	Features []GuildFeature `json:"guild_features"`
+	Features []GuildFeature `json:"features"`
 
 	// MFA is the required MFA level for the guild.
 	MFA MFALevel `json:"mfa"`
@@ -63,23 +73,23 @@ type Guild struct {
 	// AppID is the application id of the guild creator if it is bot-created.
 	//
 	// This field is nullable.
-	AppID Snowflake `json:"application_id,string,omitempty"`
+	AppID AppID `json:"application_id,omitempty"`
 
 	// Widget is true if the server widget is enabled.
 	Widget bool `json:"widget_enabled,omitempty"`
 	// WidgetChannelID is the channel id that the widget will generate an
 	// invite to, or null if set to no invite.
-	WidgetChannelID Snowflake `json:"widget_channel_id,string,omitempty"`
+	WidgetChannelID ChannelID `json:"widget_channel_id,omitempty"`
 
 	// SystemChannelID is the the id of the channel where guild notices such as
 	// welcome messages and boost events are posted.
-	SystemChannelID Snowflake `json:"system_channel_id,string,omitempty"`
+	SystemChannelID ChannelID `json:"system_channel_id,omitempty"`
 	// SystemChannelFlags are the system channel flags.
 	SystemChannelFlags SystemChannelFlags `json:"system_channel_flags"`
 
 	// RulesChannelID is the id of the channel where guilds with the "PUBLIC"
 	// feature can display rules and/or guidelines.
-	RulesChannelID Snowflake `json:"rules_channel_id"`
+	RulesChannelID ChannelID `json:"rules_channel_id"`
 
 	// MaxPresences is the maximum number of presences for the guild (the
 	// default value, currently 25000, is in effect when null is returned, so
@@ -110,7 +120,7 @@ type Guild struct {
 	// PublicUpdatesChannelID is the id of the channel where admins and
 	// moderators of guilds with the "PUBLIC" feature receive notices from
 	// Discord.
-	PublicUpdatesChannelID Snowflake `json:"public_updates_channel_id"`
+	PublicUpdatesChannelID ChannelID `json:"public_updates_channel_id"`
 
 	// MaxVideoChannelUsers is the maximum amount of users in a video channel.
 	MaxVideoChannelUsers uint64 `json:"max_video_channel_users,omitempty"`
@@ -122,6 +132,20 @@ type Guild struct {
 	ApproximatePresences uint64 `json:"approximate_presence_count,omitempty"`
 }
 
+// HasFeature returns true if the guild has the feature.
+func (g Guild) HasFeature(feature GuildFeature) bool {
+	return hasFeature(g.Features, feature)
+}
+
+func hasFeature(features []GuildFeature, feature GuildFeature) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g Guild) IconURL() string {
@@ -133,11 +157,12 @@ func (g Guild) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP, GIF
 func (g Guild) IconURLWithType(t ImageType) string {
-	if g.Icon == "" {
-		return ""
-	}
+	return GuildIconAsset(g.ID, g.Icon).URL(t, 0)
+}
 
-	return "https://cdn.discordapp.com/icons/" + g.ID.String() + "/" + t.format(g.Icon)
+// IconAnimated returns true if the guild icon is animated.
+func (g Guild) IconAnimated() bool {
+	return HashAnimated(g.Icon)
 }
 
 // BannerURL returns the URL to the banner, which is the image on top of the
@@ -151,23 +176,13 @@ func (g Guild) BannerURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g Guild) BannerURLWithType(t ImageType) string {
-	if g.Banner == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/banners/" +
-		g.ID.String() + "/" + t.format(g.Banner)
+	return GuildBannerAsset(g.ID, g.Banner).URL(t, 0)
 }
 
 // SplashURL returns the URL to the guild splash, which is the invite page's
 // background. This will always return a link to a PNG file.
 func (g Guild) SplashURL() string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + g.Splash + ".png"
+	return g.SplashURLWithType(PNGImage)
 }
 
 // SplashURLWithType returns the URL to the guild splash, which is the invite page's
@@ -175,18 +190,13 @@ func (g Guild) SplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g Guild) SplashURLWithType(t ImageType) string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.Splash)
+	return GuildSplashAsset(g.ID, g.Splash).URL(t, 0)
 }
 
 // https://discord.com/developers/docs/resources/guild#guild-preview-object
 type GuildPreview struct {
 	// ID is the guild id.
-	ID Snowflake `json:"id"`
+	ID GuildID `json:"id"`
 	// Name is the guild name (2-100 characters).
 	Name string `json:"name"`
 
@@ -200,7 +210,7 @@ type GuildPreview struct {
 	// Emojis are the custom guild emojis.
 	Emojis []Emoji `json:"emojis"`
 	// Features are the enabled guild features.
-	Features []GuildFeature `json:"guild_features"`
+	Features []GuildFeature `json:"features"`
 
 	// ApproximateMembers is the approximate number of members in this guild.
 	ApproximateMembers uint64 `json:"approximate_member_count"`
@@ -212,6 +222,11 @@ type GuildPreview struct {
 	Description string `json:"description,omitempty"`
 }
 
+// HasFeature returns true if the guild has the feature, such as DISCOVERABLE.
+func (g GuildPreview) HasFeature(feature GuildFeature) bool {
+	return hasFeature(g.Features, feature)
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g GuildPreview) IconURL() string {
@@ -223,22 +238,13 @@ func (g GuildPreview) IconURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP, GIF
 func (g GuildPreview) IconURLWithType(t ImageType) string {
-	if g.Icon == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/icons/" + g.ID.String() + "/" + t.format(g.Icon)
+	return GuildIconAsset(g.ID, g.Icon).URL(t, 0)
 }
 
 // SplashURL returns the URL to the guild splash, which is the invite page's
 // background. This will always return a link to a PNG file.
 func (g GuildPreview) SplashURL() string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + g.Splash + ".png"
+	return g.SplashURLWithType(PNGImage)
 }
 
 // SplashURLWithType returns the URL to the guild splash, which is the invite page's
@@ -246,42 +252,27 @@ func (g GuildPreview) SplashURL() string {
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g GuildPreview) SplashURLWithType(t ImageType) string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.Splash)
+	return GuildSplashAsset(g.ID, g.Splash).URL(t, 0)
 }
 
-// DiscoverySplashURL returns the URL to the guild splash, which is the invite page's
-// background. This will always return a link to a PNG file.
+// DiscoverySplashURL returns the URL to the guild discovery splash, which is
+// shown in the guild discovery. This will always return a link to a PNG file.
 func (g GuildPreview) DiscoverySplashURL() string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + g.Splash + ".png"
+	return g.DiscoverySplashURLWithType(PNGImage)
 }
 
-// DiscoverySplashURLWithType returns the URL to the guild splash, which is the invite page's
-// background, using the passed ImageType.
+// DiscoverySplashURLWithType returns the URL to the guild discovery splash,
+// which is shown in the guild discovery, using the passed ImageType.
 //
 // Supported ImageTypes: PNG, JPEG, WebP
 func (g GuildPreview) DiscoverySplashURLWithType(t ImageType) string {
-	if g.Splash == "" {
-		return ""
-	}
-
-	return "https://cdn.discordapp.com/splashes/" +
-		g.ID.String() + "/" + t.format(g.Splash)
+	return GuildDiscoverySplashAsset(g.ID, g.DiscoverySplash).URL(t, 0)
 }
 
 // https://discord.com/developers/docs/topics/permissions#role-object
 type Role struct {
 	// ID is the role id.
-	ID Snowflake `json:"id,string"`
+	ID RoleID `json:"id"`
 	// Name is the role name.
 	Name string `json:"name"`
 
@@ -295,6 +286,9 @@ type Role struct {
 	// Permissions is the permission bit set.
 	Permissions Permissions `json:"permissions"`
 
+	// Icon is the role icon hash, if any.
+	Icon Hash `json:"icon,omitempty"`
+
 	// Manages specifies whether this role is managed by an integration.
 	Managed bool `json:"managed"`
 	// Mentionable specifies whether this role is mentionable.
@@ -303,7 +297,30 @@ type Role struct {
 
 // Mention returns the mention of the Role.
 func (r Role) Mention() string {
-	return "<&" + r.ID.String() + ">"
+	return r.ID.Mention()
+}
+
+// IconURL returns the URL of the role icon as a PNG, or an empty string if the
+// role has no icon.
+func (r Role) IconURL() string {
+	return RoleIconAsset(r.ID, r.Icon).URL(PNGImage, 0)
+}
+
+// Above returns true if the role is higher than the other role in the role
+// hierarchy. Roles with the same position are ordered by ID, the older role
+// being higher, as Discord does.
+func (r Role) Above(other Role) bool {
+	if r.Position != other.Position {
+		return r.Position > other.Position
+	}
+	return r.ID < other.ID
+}
+
+// SortRoles sorts the roles in place from the highest to the lowest.
+func SortRoles(roles []Role) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Above(roles[j])
+	})
 }
 
 // https://discord.com/developers/docs/topics/gateway#presence-update
@@ -311,7 +328,7 @@ type Presence struct {
 	// User is the user presence is being updated for.
 	User User `json:"user"`
 	// RoleIDs are the roles this user is in.
-	RoleIDs []Snowflake `json:"roles"`
+	RoleIDs []RoleID `json:"roles"`
 
 	// These fields are only filled in gateway events, according to the
 	// documentation.
@@ -320,32 +337,46 @@ type Presence struct {
 	Game *Activity `json:"game"`
 
 	// GuildID is the id of the guild
-	GuildID Snowflake `json:"guild_id"`
+	GuildID GuildID `json:"guild_id"`
 
 	// Status is either "idle", "dnd", "online", or "offline".
 	Status Status `json:"status"`
 	// Activities are the user's current activities.
 	Activities []Activity `json:"activities"`
-	// ClientStaus is the user's platform-dependent status.
-	//
-	// https://discord.com/developers/docs/topics/gateway#client-status-object
-	ClientStatus struct {
-		// Desktop is the user's status set for an active desktop (Windows,
-		// Linux, Mac) application session.
-		Desktop Status `json:"desktop,omitempty"`
-		// Mobile is the user's status set for an active mobile (iOS, Android)
-		// application session.
-		Mobile Status `json:"mobile,omitempty"`
-		// Web is the user's status set for an active web (browser, bot
-		// account) application session.
-		Web Status `json:"web,omitempty"`
-	} `json:"client_status"`
+	// ClientStatus is the user's platform-dependent status.
+	ClientStatus ClientStatus `json:"client_status"`
 	// Premium since specifies when the user started boosting the guild.
 	PremiumSince Timestamp `json:"premium_since,omitempty"`
 	// Nick is this users guild nickname (if one is set).
 	Nick string `json:"nick,omitempty"`
 }
 
+// Activity returns the first of the user's activities with the given type.
+func (p Presence) Activity(t ActivityType) (*Activity, bool) {
+	for i, a := range p.Activities {
+		if a.Type == t {
+			return &p.Activities[i], true
+		}
+	}
+	return nil, false
+}
+
+// ClientStatus is the status of a user on each platform. Platforms that the
+// user isn't active on are empty.
+//
+// https://discord.com/developers/docs/topics/gateway#client-status-object
+type ClientStatus struct {
+	// Desktop is the user's status set for an active desktop (Windows,
+	// Linux, Mac) application session.
+	Desktop Status `json:"desktop,omitempty"`
+	// Mobile is the user's status set for an active mobile (iOS, Android)
+	// application session.
+	Mobile Status `json:"mobile,omitempty"`
+	// Web is the user's status set for an active web (browser, bot
+	// account) application session.
+	Web Status `json:"web,omitempty"`
+}
+
 // https://discord.com/developers/docs/resources/guild#guild-member-object
 //
 // The field user won't be included in the member object attached to
@@ -356,7 +387,7 @@ type Member struct {
 	// Nick is this users guild nickname.
 	Nick string `json:"nick,omitempty"`
 	// RoleIDs is an array of role object ids.
-	RoleIDs []Snowflake `json:"roles"`
+	RoleIDs []RoleID `json:"roles"`
 
 	// Joined specifies when the user joined the guild.
 	Joined Timestamp `json:"joined_at"`
@@ -367,13 +398,86 @@ type Member struct {
 	Deaf bool `json:"deaf"`
 	// Mute specifies whether the user is muted in voice channels.
 	Mute bool `json:"mute"`
+
+	// Avatar is the member's guild-specific avatar hash, if any.
+	Avatar Hash `json:"avatar,omitempty"`
+	// AvatarDecoration is the member's guild-specific avatar decoration, if
+	// any.
+	AvatarDecoration *AvatarDecoration `json:"avatar_decoration_data,omitempty"`
+
+	// CommunicationDisabledUntil is when the member's timeout ends. It's
+	// invalid if the member was never timed out, but it may also be in the
+	// past once the timeout is over.
+	CommunicationDisabledUntil Timestamp `json:"communication_disabled_until,omitempty"`
+}
+
+// TimedOut returns true if the member is currently timed out.
+func (m Member) TimedOut() bool {
+	return m.CommunicationDisabledUntil.Time().After(time.Now())
 }
 
-// Mention returns the mention of the role.
+// Mention returns the mention of the member.
 func (m Member) Mention() string {
 	return "<@!" + m.User.ID.String() + ">"
 }
 
+// HighestRole returns the highest of the member's roles, which are usually the
+// guild's roles. It returns false if the member has none of the given roles.
+// The @everyone role is not counted, as members don't have it in their
+// RoleIDs.
+func (m Member) HighestRole(roles []Role) (Role, bool) {
+	var highest Role
+	var found bool
+
+	for _, r := range roles {
+		if !m.hasRole(r.ID) {
+			continue
+		}
+		if !found || r.Above(highest) {
+			highest = r
+			found = true
+		}
+	}
+
+	return highest, found
+}
+
+func (m Member) hasRole(id RoleID) bool {
+	for _, roleID := range m.RoleIDs {
+		if roleID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AvatarURL returns the URL of the member's guild avatar, falling back to the
+// user's avatar if the member has none. It automatically detects a suitable
+// type.
+func (m Member) AvatarURL(guildID GuildID) string {
+	return m.AvatarURLWithType(guildID, AutoImage)
+}
+
+// AvatarURLWithType returns the URL of the member's guild avatar using the
+// passed type, falling back to the user's avatar if the member has none.
+//
+// Supported ImageTypes: PNG, JPEG, WebP, GIF
+func (m Member) AvatarURLWithType(guildID GuildID, t ImageType) string {
+	if m.Avatar == "" {
+		return m.User.AvatarURLWithType(t)
+	}
+
+	return MemberAvatarAsset(guildID, m.User.ID, m.Avatar).URL(t, 0)
+}
+
+// AvatarAnimated returns true if the member's effective avatar is animated.
+func (m Member) AvatarAnimated() bool {
+	if m.Avatar == "" {
+		return m.User.AvatarAnimated()
+	}
+	return HashAnimated(m.Avatar)
+}
+
 // https://discord.com/developers/docs/resources/guild#ban-object
 type Ban struct {
 	// Reason is the reason for the ban.
@@ -385,7 +489,7 @@ type Ban struct {
 // https://discord.com/developers/docs/resources/guild#integration-object
 type Integration struct {
 	// ID is the integration id.
-	ID Snowflake `json:"id"`
+	ID IntegrationID `json:"id"`
 	// Name is the integration name.
 	Name string `json:"name"`
 	// Type is the integration type (twitch, youtube, etc).
@@ -397,7 +501,7 @@ type Integration struct {
 	Syncing bool `json:"syncing"`
 
 	// RoleID is the id that this integration uses for "subscribers".
-	RoleID Snowflake `json:"role_id"`
+	RoleID RoleID `json:"role_id"`
 
 	// EnableEmoticons specifies whether emoticons should be synced for this
 	// integration (twitch only currently).
@@ -430,30 +534,51 @@ type GuildWidget struct {
 	// Enabled specifies whether the widget is enabled.
 	Enabled bool `json:"enabled"`
 	// ChannelID is the widget channel id.
-	ChannelID Snowflake `json:"channel_id,omitempty"`
+	ChannelID ChannelID `json:"channel_id,omitempty"`
 }
 
 // DefaultMemberColor is the color used for members without colored roles.
 var DefaultMemberColor Color = 0x0
 
 // MemberColor computes the effective color of the Member, taking into account
-// the role colors.
+// the role colors. It's the same as guild.MemberColor(member).
 func MemberColor(guild Guild, member Member) Color {
+	return guild.MemberColor(member)
+}
+
+// MemberColor returns the color of the member's name, which is the color of
+// the member's highest role that has one, or DefaultMemberColor if none do.
+func (g Guild) MemberColor(member Member) Color {
 	var c = DefaultMemberColor
-	var pos int
-
-	for _, r := range guild.Roles {
-		for _, mr := range member.RoleIDs {
-			if mr != r.ID {
-				continue
-			}
-
-			if r.Color > 0 && r.Position > pos {
-				c = r.Color
-				pos = r.Position
-			}
+	var top *Role
+
+	for i, r := range g.Roles {
+		if r.Color > 0 && member.hasRole(r.ID) && (top == nil || r.Above(*top)) {
+			c = r.Color
+			top = &g.Roles[i]
 		}
 	}
 
 	return c
 }
+
+// CanModerate returns true if the moderator is above the target in the role
+// hierarchy, which Discord requires to kick, ban, or edit the nickname or
+// roles of the target. The owner is above everyone, and members with no roles
+// are above no one. Permissions are not checked.
+func (g Guild) CanModerate(moderator, target Member) bool {
+	switch {
+	case target.User.ID == g.OwnerID:
+		return false
+	case moderator.User.ID == g.OwnerID:
+		return true
+	}
+
+	mod, ok := moderator.HighestRole(g.Roles)
+	if !ok {
+		return false
+	}
+
+	top, ok := target.HighestRole(g.Roles)
+	return !ok || mod.Above(top)
+}
@@ -20,6 +20,49 @@ const (
 	NitroLevel3
 )
 
+// EmojiLimit returns the maximum number of emojis a guild with this premium
+// tier can have. The limit applies to static and animated emojis separately.
+func (n NitroBoost) EmojiLimit() int {
+	switch n {
+	case NitroLevel1:
+		return 100
+	case NitroLevel2:
+		return 150
+	case NitroLevel3:
+		return 250
+	default:
+		return 50
+	}
+}
+
+// StickerLimit returns the maximum number of custom stickers a guild with this
+// premium tier can have.
+func (n NitroBoost) StickerLimit() int {
+	switch n {
+	case NitroLevel1:
+		return 15
+	case NitroLevel2:
+		return 30
+	case NitroLevel3:
+		return 60
+	default:
+		return 5
+	}
+}
+
+// MaxArchiveDuration returns the longest thread archive duration that a guild
+// with this premium tier can use.
+func (n NitroBoost) MaxArchiveDuration() ArchiveDuration {
+	switch n {
+	case NoNitroLevel:
+		return OneDayArchive
+	case NitroLevel1:
+		return ThreeDaysArchive
+	default:
+		return SevenDaysArchive
+	}
+}
+
 // MFALevel is the required MFA level for a guild.
 type MFALevel uint8
 
@@ -0,0 +1,154 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRoleHierarchy(t *testing.T) {
+	var guild = Guild{
+		ID:      1,
+		OwnerID: 2,
+		Roles: []Role{
+			{ID: 1, Position: 0},
+			{ID: 10, Position: 1, Color: 0xFF0000},
+			{ID: 11, Position: 2},
+			// Same position as 11, but newer, so it's lower.
+			{ID: 12, Position: 2, Color: 0x00FF00},
+		},
+	}
+
+	var (
+		owner = Member{User: User{ID: 2}}
+		mod   = Member{User: User{ID: 3}, RoleIDs: []RoleID{10, 11}}
+		user  = Member{User: User{ID: 4}, RoleIDs: []RoleID{12, 10}}
+		none  = Member{User: User{ID: 5}}
+	)
+
+	if r, ok := mod.HighestRole(guild.Roles); !ok || r.ID != 11 {
+		t.Fatal("Unexpected highest role of mod:", r.ID)
+	}
+	if _, ok := none.HighestRole(guild.Roles); ok {
+		t.Fatal("Member without roles has a highest role")
+	}
+
+	if c := guild.MemberColor(user); c != 0x00FF00 {
+		t.Fatalf("Unexpected color %06X", c)
+	}
+	if c := guild.MemberColor(mod); c != 0xFF0000 {
+		t.Fatalf("Unexpected color %06X", c)
+	}
+
+	var tests = []struct {
+		name        string
+		a, b        Member
+		canModerate bool
+	}{
+		{"mod on user", mod, user, true},
+		{"user on mod", user, mod, false},
+		{"user on none", user, none, true},
+		{"none on none", none, none, false},
+		{"owner on mod", owner, mod, true},
+		{"mod on owner", mod, owner, false},
+	}
+
+	for _, test := range tests {
+		if guild.CanModerate(test.a, test.b) != test.canModerate {
+			t.Errorf("%s: expected %v", test.name, test.canModerate)
+		}
+	}
+}
+
+func TestGuildPreviewUnmarshal(t *testing.T) {
+	var preview GuildPreview
+
+	err := json.Unmarshal([]byte(`{
+		"id": "197038439483310086",
+		"name": "Discord Testers",
+		"features": ["DISCOVERABLE", "VANITY_URL"],
+		"approximate_member_count": 60814,
+		"approximate_presence_count": 20034
+	}`), &preview)
+	if err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if !preview.HasFeature(Discoverable) || preview.HasFeature(Banner) {
+		t.Fatal("Unexpected features:", preview.Features)
+	}
+	if preview.ApproximateMembers != 60814 {
+		t.Fatal("Unexpected member count:", preview.ApproximateMembers)
+	}
+}
+
+func TestPresenceUnmarshal(t *testing.T) {
+	var p Presence
+
+	err := json.Unmarshal([]byte(`{
+		"user": {"id": "1337"},
+		"guild_id": "42",
+		"status": "dnd",
+		"activities": [{
+			"name": "Custom Status",
+			"type": 4,
+			"state": "busy",
+			"emoji": {"name": "🔥"},
+			"created_at": 1618953630123
+		}, {
+			"name": "Spotify",
+			"type": 2,
+			"details": "Song",
+			"timestamps": {"start": 1618953600000, "end": 1618953800000},
+			"party": {"id": "spotify:1337"},
+			"assets": {"large_image": "spotify:ab67616d", "large_text": "Album"},
+			"flags": 48,
+			"buttons": ["Listen"]
+		}],
+		"client_status": {"desktop": "dnd", "mobile": "idle"}
+	}`), &p)
+	if err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if p.ClientStatus != (ClientStatus{Desktop: DoNotDisturbStatus, Mobile: IdleStatus}) {
+		t.Fatal("Unexpected client status:", p.ClientStatus)
+	}
+
+	custom, ok := p.Activity(CustomActivity)
+	if !ok || custom.State != "busy" || custom.Emoji.Name != "🔥" {
+		t.Fatal("Unexpected custom activity:", custom)
+	}
+	if custom.CreatedAt.Time().Unix() != 1618953630 {
+		t.Fatal("Unexpected creation time:", custom.CreatedAt)
+	}
+
+	music, ok := p.Activity(ListeningActivity)
+	if !ok || music.Timestamps.End.Time().Sub(music.Timestamps.Start.Time()).Seconds() != 200 {
+		t.Fatal("Unexpected listening activity:", music)
+	}
+	if music.Flags != SyncActivity|PlayActivity || len(music.Buttons) != 1 {
+		t.Fatal("Unexpected flags or buttons:", music.Flags, music.Buttons)
+	}
+
+	if _, ok := p.Activity(GameActivity); ok {
+		t.Fatal("Unexpected game activity")
+	}
+}
+
+func TestMemberTimedOut(t *testing.T) {
+	var m Member
+	if m.TimedOut() {
+		t.Fatal("Member without a timeout is timed out")
+	}
+
+	m.CommunicationDisabledUntil = NewTimestamp(time.Now().Add(-time.Minute))
+	if m.TimedOut() {
+		t.Fatal("Member with an expired timeout is timed out")
+	}
+
+	m.CommunicationDisabledUntil = NewTimestamp(time.Now().Add(time.Minute))
+	if !m.TimedOut() {
+		t.Fatal("Member with a timeout is not timed out")
+	}
+}
@@ -16,8 +16,12 @@ type Invite struct {
 
 	// Target is the target user for this invite.
 	Target *User `json:"target_user,omitempty"`
-	// Target type is the type of user target for this invite.
-	TargetType InviteUserType `json:"target_user_type,omitempty"`
+	// TargetType is the type of target for this voice channel invite.
+	TargetType InviteUserType `json:"target_type,omitempty"`
+	// TargetApplication is the partial embedded application to open for this
+	// voice channel invite. It is only present if TargetType is
+	// InviteEmbeddedApplication.
+	TargetApplication *MessageApplication `json:"target_application,omitempty"`
 
 	// ApproximatePresences is the approximate count of online members (only
 	// present when Target is set).
@@ -32,12 +36,23 @@ type Invite struct {
 	InviteMetadata
 }
 
-// https://discord.com/developers/docs/resources/invite#invite-object-target-user-types
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
 type InviteUserType uint8
 
 const (
 	InviteNormalUser InviteUserType = iota
 	InviteUserStream
+	InviteEmbeddedApplication
+)
+
+// Known application IDs of embedded activities that can be launched in a voice
+// channel by creating an invite with the InviteEmbeddedApplication target type.
+const (
+	YouTubeTogetherActivity AppID = 755600276941176913
+	PokerNightActivity      AppID = 755827207812677713
+	BetrayalActivity        AppID = 773336526917861400
+	FishingtonActivity      AppID = 814288819477020702
+	ChessInTheParkActivity  AppID = 832012774040141894
 )
 
 // Extra information about an invite, will extend the invite object.
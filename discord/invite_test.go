@@ -0,0 +1,17 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInviteTargetType(t *testing.T) {
+	var inv Invite
+	if err := json.Unmarshal([]byte(`{"code":"abc","target_type":2}`), &inv); err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if inv.TargetType != InviteEmbeddedApplication {
+		t.Fatal("Unexpected target type:", inv.TargetType)
+	}
+}
@@ -0,0 +1,107 @@
+package discord
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Mention returns the mention of the user with the ID.
+func (s UserID) Mention() string {
+	return "<@" + s.String() + ">"
+}
+
+// Mention returns the mention of the channel with the ID.
+func (s ChannelID) Mention() string {
+	return "<#" + s.String() + ">"
+}
+
+// Mention returns the mention of the role with the ID.
+func (s RoleID) Mention() string {
+	return "<@&" + s.String() + ">"
+}
+
+// TimestampStyle is the style a timestamp is shown in by Discord clients. The
+// examples below are in the en-US locale, but clients format timestamps in the
+// locale of the user, and in their time zone.
+type TimestampStyle string
+
+const (
+	// DefaultTimestampStyle leaves out the style, which is the same as
+	// ShortDateTimeStyle.
+	DefaultTimestampStyle TimestampStyle = ""
+	// ShortTimeStyle looks like "16:20".
+	ShortTimeStyle TimestampStyle = "t"
+	// LongTimeStyle looks like "16:20:30".
+	LongTimeStyle TimestampStyle = "T"
+	// ShortDateStyle looks like "20/04/2021".
+	ShortDateStyle TimestampStyle = "d"
+	// LongDateStyle looks like "20 April 2021".
+	LongDateStyle TimestampStyle = "D"
+	// ShortDateTimeStyle looks like "20 April 2021 16:20".
+	ShortDateTimeStyle TimestampStyle = "f"
+	// LongDateTimeStyle looks like "Tuesday, 20 April 2021 16:20".
+	LongDateTimeStyle TimestampStyle = "F"
+	// RelativeTimeStyle looks like "2 months ago", and is updated live.
+	RelativeTimeStyle TimestampStyle = "R"
+)
+
+// Markdown returns the timestamp in the markdown that Discord clients show in
+// the given style, such as "<t:1618953630:R>".
+func (t UnixTimestamp) Markdown(style TimestampStyle) string {
+	var s = "<t:" + strconv.FormatInt(int64(t), 10)
+	if style != DefaultTimestampStyle {
+		s += ":" + string(style)
+	}
+	return s + ">"
+}
+
+// Markdown returns the timestamp in the markdown that Discord clients show in
+// the given style. The precision is in seconds. For more documentation, refer
+// to UnixTimestamp's Markdown.
+func (t Timestamp) Markdown(style TimestampStyle) string {
+	return TimeToUnixTimestamp(t.Time()).Markdown(style)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`~`, `\~`,
+	"`", "\\`",
+	`|`, `\|`,
+	`>`, `\>`,
+	`#`, `\#`,
+)
+
+// markdownVerbatim matches the parts of a message that EscapeMarkdown leaves
+// alone: mentions, custom emojis, timestamps and links.
+var markdownVerbatim = regexp.MustCompile(
+	`<(?:@[!&]?|#)\d+>|<a?:\w+:\d+>|<t:-?\d+(?::\w)?>|<?https?://[^\s<>]+>?`)
+
+// EscapeMarkdown escapes the characters that Discord uses for markdown, so
+// that user-provided content is shown as-is. Mentions, emojis and links are
+// left as they are; use EscapeMentions or AllowedMentions to stop the content
+// from pinging.
+func EscapeMarkdown(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	var last int
+	for _, span := range markdownVerbatim.FindAllStringIndex(s, -1) {
+		b.WriteString(markdownEscaper.Replace(s[last:span[0]]))
+		b.WriteString(s[span[0]:span[1]])
+		last = span[1]
+	}
+	b.WriteString(markdownEscaper.Replace(s[last:]))
+
+	return b.String()
+}
+
+// EscapeMentions breaks every mention in the content, including @everyone and
+// @here, by putting a zero-width space after each "@". The content looks the
+// same, but nobody is pinged. Prefer sending the message with AllowedMentions
+// when possible, as this also changes mentions that were meant to be shown.
+func EscapeMentions(s string) string {
+	return strings.Replace(s, "@", "@\u200b", -1)
+}
@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMention(t *testing.T) {
+	var tests = []struct {
+		mention string
+		expect  string
+	}{
+		{UserID(1337).Mention(), "<@1337>"},
+		{ChannelID(1337).Mention(), "<#1337>"},
+		{RoleID(1337).Mention(), "<@&1337>"},
+		{Role{ID: 1337}.Mention(), "<@&1337>"},
+	}
+
+	for _, test := range tests {
+		if test.mention != test.expect {
+			t.Fatalf("Expected %q, got %q", test.expect, test.mention)
+		}
+	}
+}
+
+func TestTimestampMarkdown(t *testing.T) {
+	var ts = NewTimestamp(time.Unix(1618953630, 500))
+
+	if s := ts.Markdown(RelativeTimeStyle); s != "<t:1618953630:R>" {
+		t.Fatal("Unexpected relative timestamp:", s)
+	}
+	if s := UnixTimestamp(1618953630).Markdown(DefaultTimestampStyle); s != "<t:1618953630>" {
+		t.Fatal("Unexpected default timestamp:", s)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	var md = EscapeMarkdown("**bold** _it_ `code` ||spoiler|| > quote \\")
+	if md != `\*\*bold\*\* \_it\_ \`+"`"+`code\`+"`"+` \|\|spoiler\|\| \> quote \\` {
+		t.Fatal("Unexpected escaped markdown:", md)
+	}
+
+	var verbatim = []string{
+		"<@1337>",
+		"<@!1337>",
+		"<@&1337>",
+		"<#1337>",
+		"<:arikawa_emoji:1337>",
+		"<a:arikawa_emoji:1337>",
+		"<t:1618953630:R>",
+		"https://example.com/some_page#top",
+		"<https://example.com/some_page>",
+	}
+
+	for _, s := range verbatim {
+		if md := EscapeMarkdown("_hi_ " + s + " _hi_"); md != `\_hi\_ `+s+` \_hi\_` {
+			t.Errorf("Unexpected escaped markdown of %q: %q", s, md)
+		}
+	}
+
+	if s := EscapeMentions("@everyone <@1337>"); s != "@\u200beveryone <@\u200b1337>" {
+		t.Fatalf("Unexpected escaped mentions: %q", s)
+	}
+}
@@ -1,12 +1,10 @@
 package discord
 
-import "github.com/diamondburned/arikawa/utils/json/enum"
-
 type Message struct {
-	ID        Snowflake   `json:"id,string"`
+	ID        MessageID   `json:"id"`
 	Type      MessageType `json:"type"`
-	ChannelID Snowflake   `json:"channel_id,string"`
-	GuildID   Snowflake   `json:"guild_id,string,omitempty"`
+	ChannelID ChannelID   `json:"channel_id"`
+	GuildID   GuildID     `json:"guild_id,omitempty"`
 
 	// The author object follows the structure of the user object, but is only
 	// a valid user in the case where the message is generated by a user or bot
@@ -16,6 +14,9 @@ type Message struct {
 	// message object.
 	Author User `json:"author"`
 
+	// Content, Embeds and Attachments are empty in messages that are neither
+	// in DMs nor mention the bot, unless the bot has the message content
+	// intent.
 	Content string `json:"content"`
 
 	Timestamp       Timestamp `json:"timestamp,omitempty"`
@@ -29,8 +30,8 @@ type Message struct {
 	// text-based guild channels.
 	Mentions []GuildUser `json:"mentions"`
 
-	MentionRoleIDs  []Snowflake `json:"mention_roles"`
-	MentionEveryone bool        `json:"mention_everyone"`
+	MentionRoleIDs  []RoleID `json:"mention_roles"`
+	MentionEveryone bool     `json:"mention_everyone"`
 
 	// Not all channel mentions in a message will appear in mention_channels.
 	MentionChannels []ChannelMention `json:"mention_channels,omitempty"`
@@ -43,7 +44,7 @@ type Message struct {
 	// Used for validating a message was sent
 	Nonce string `json:"nonce,omitempty"`
 
-	WebhookID   Snowflake           `json:"webhook_id,string,omitempty"`
+	WebhookID   WebhookID           `json:"webhook_id,omitempty"`
 	Activity    *MessageActivity    `json:"activity,omitempty"`
 	Application *MessageApplication `json:"application,omitempty"`
 	Reference   *MessageReference   `json:"message_reference,omitempty"`
@@ -83,20 +84,43 @@ const (
 	GuildDiscoveryRequalifiedMessage
 )
 
-type MessageFlags enum.Enum
+// MessageFlags are the bit flags of a message. Only SuppressEmbeds and
+// SuppressNotifications can be set when sending a message, and only
+// SuppressEmbeds when editing one.
+type MessageFlags uint32
 
 var (
-	NullMessage          MessageFlags = enum.Null
-	CrosspostedMessage   MessageFlags = 1
-	MessageIsCrosspost   MessageFlags = 2
-	SuppressEmbeds       MessageFlags = 4
-	SourceMessageDeleted MessageFlags = 8
-	UrgentMessage        MessageFlags = 16
+	// CrosspostedMessage is set on messages that were published to the
+	// channels following them.
+	CrosspostedMessage MessageFlags = 1 << 0
+	// MessageIsCrosspost is set on messages that came from a followed channel.
+	MessageIsCrosspost MessageFlags = 1 << 1
+	// SuppressEmbeds hides the embeds of the links in the message.
+	SuppressEmbeds MessageFlags = 1 << 2
+	// SourceMessageDeleted is set on crossposts whose source was deleted.
+	SourceMessageDeleted MessageFlags = 1 << 3
+	// UrgentMessage is set on messages from the Urgent Message System.
+	UrgentMessage MessageFlags = 1 << 4
+	// MessageHasThread is set on messages that started a thread.
+	MessageHasThread MessageFlags = 1 << 5
+	// EphemeralMessage is set on messages that only the user who used an
+	// interaction can see.
+	EphemeralMessage MessageFlags = 1 << 6
+	// LoadingMessage is set on deferred interaction responses.
+	LoadingMessage MessageFlags = 1 << 7
+	// SuppressNotifications sends the message without pushing or desktop
+	// notifications.
+	SuppressNotifications MessageFlags = 1 << 12
 )
 
+// Has returns true if the flags have all the given flags.
+func (f MessageFlags) Has(flags MessageFlags) bool {
+	return f&flags == flags
+}
+
 type ChannelMention struct {
-	ChannelID   Snowflake   `json:"id,string"`
-	GuildID     Snowflake   `json:"guild_id,string"`
+	ChannelID   ChannelID   `json:"id"`
+	GuildID     GuildID     `json:"guild_id"`
 	ChannelType ChannelType `json:"type"`
 	ChannelName string      `json:"name"`
 }
@@ -127,29 +151,29 @@ const (
 //
 
 type MessageApplication struct {
-	ID          Snowflake `json:"id,string"`
-	CoverID     string    `json:"cover_image,omitempty"`
-	Description string    `json:"description"`
-	Icon        string    `json:"icon"`
-	Name        string    `json:"name"`
+	ID          AppID  `json:"id"`
+	CoverID     string `json:"cover_image,omitempty"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Name        string `json:"name"`
 }
 
 //
 
 type MessageReference struct {
-	ChannelID Snowflake `json:"channel_id,string"`
+	ChannelID ChannelID `json:"channel_id"`
 
 	// Field might not be provided
-	MessageID Snowflake `json:"message_id,string,omitempty"`
-	GuildID   Snowflake `json:"guild_id,string,omitempty"`
+	MessageID MessageID `json:"message_id,omitempty"`
+	GuildID   GuildID   `json:"guild_id,omitempty"`
 }
 
 //
 
 type Attachment struct {
-	ID       Snowflake `json:"id,string"`
-	Filename string    `json:"filename"`
-	Size     uint64    `json:"size"`
+	ID       AttachmentID `json:"id"`
+	Filename string       `json:"filename"`
+	Size     uint64       `json:"size"`
 
 	URL   URL `json:"url"`
 	Proxy URL `json:"proxy_url"`
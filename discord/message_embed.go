@@ -1,6 +1,12 @@
 package discord
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type Color uint32
 
@@ -27,6 +33,37 @@ func (c Color) RGB() (uint8, uint8, uint8) {
 	return r, g, b
 }
 
+// String formats the color as a lowercase hexadecimal color code, such as
+// "#303030".
+func (c Color) String() string {
+	return fmt.Sprintf("#%06x", c.Uint32()&0xFFFFFF)
+}
+
+// ParseColor parses a hexadecimal color code with 6 digits, or 3 digits as a
+// shorthand, optionally prefixed with "#" or "0x". For example, "#FF8800",
+// "0xff8800" and "f80" are all the same color.
+func ParseColor(s string) (Color, error) {
+	var hex = strings.TrimPrefix(s, "#")
+	if hex == s {
+		hex = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	}
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if len(hex) != 6 {
+		return 0, fmt.Errorf("invalid color %q: expected 3 or 6 hex digits", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid color %q: not hexadecimal", s)
+	}
+
+	return Color(v), nil
+}
+
 type Embed struct {
 	Title       string    `json:"title,omitempty"`
 	Type        EmbedType `json:"type,omitempty"`
@@ -46,6 +83,17 @@ type Embed struct {
 	Fields    []EmbedField    `json:"fields,omitempty"`
 }
 
+// NewEmbed creates a new rich embed with the default color. The embed can be
+// built with its setters, which all return the embed:
+//
+//    e := discord.NewEmbed().
+//        SetTitle("Server rules").
+//        SetDescription("Be nice.").
+//        AddField("Spam", "Don't.", false)
+//
+//    if err := e.Validate(); err != nil {
+//        return err
+//    }
 func NewEmbed() *Embed {
 	return &Embed{
 		Type:  NormalEmbed,
@@ -53,6 +101,83 @@ func NewEmbed() *Embed {
 	}
 }
 
+// SetTitle sets the title of the embed.
+func (e *Embed) SetTitle(title string) *Embed {
+	e.Title = title
+	return e
+}
+
+// SetDescription sets the description of the embed.
+func (e *Embed) SetDescription(description string) *Embed {
+	e.Description = description
+	return e
+}
+
+// SetURL sets the URL that the title links to.
+func (e *Embed) SetURL(url URL) *Embed {
+	e.URL = url
+	return e
+}
+
+// SetColor sets the color of the embed's left border.
+func (e *Embed) SetColor(color Color) *Embed {
+	e.Color = color
+	return e
+}
+
+// SetTimestamp sets the timestamp shown in the embed's footer.
+func (e *Embed) SetTimestamp(t time.Time) *Embed {
+	e.Timestamp = NewTimestamp(t)
+	return e
+}
+
+// SetFooter sets the footer of the embed. The icon is optional.
+func (e *Embed) SetFooter(text string, icon URL) *Embed {
+	e.Footer = &EmbedFooter{Text: text, Icon: icon}
+	return e
+}
+
+// SetAuthor sets the author of the embed. The URL and icon are optional.
+func (e *Embed) SetAuthor(name string, url, icon URL) *Embed {
+	e.Author = &EmbedAuthor{Name: name, URL: url, Icon: icon}
+	return e
+}
+
+// SetImage sets the large image of the embed.
+func (e *Embed) SetImage(url URL) *Embed {
+	e.Image = &EmbedImage{URL: url}
+	return e
+}
+
+// SetThumbnail sets the small image in the top right of the embed.
+func (e *Embed) SetThumbnail(url URL) *Embed {
+	e.Thumbnail = &EmbedThumbnail{URL: url}
+	return e
+}
+
+// AddField appends a field to the embed. Inline fields are shown next to each
+// other.
+func (e *Embed) AddField(name, value string, inline bool) *Embed {
+	e.Fields = append(e.Fields, EmbedField{Name: name, Value: value, Inline: inline})
+	return e
+}
+
+// The limits of an embed, in characters. They're checked by Validate.
+const (
+	EmbedTitleLimit       = 256
+	EmbedDescriptionLimit = 4096
+	EmbedFieldsLimit      = 25
+	EmbedFieldNameLimit   = 256
+	EmbedFieldValueLimit  = 1024
+	EmbedFooterTextLimit  = 2048
+	EmbedAuthorNameLimit  = 256
+	// EmbedTotalLimit is the limit of the title, description, field names and
+	// values, footer text and author name combined.
+	EmbedTotalLimit = 6000
+)
+
+// ErrOverbound is returned by Validate when a part of an embed is over its
+// limit.
 type ErrOverbound struct {
 	Count int
 	Max   int
@@ -70,6 +195,9 @@ func (e ErrOverbound) Error() string {
 	return fmt.Sprintf(e.Thing+" overbound: %d > %d", e.Count, e.Max)
 }
 
+// Validate checks the embed against Discord's limits, returning an
+// *ErrOverbound for the first part that is too long. It also fills in the
+// default type and color if they're empty.
 func (e *Embed) Validate() error {
 	if e.Type == "" {
 		e.Type = NormalEmbed
@@ -79,54 +207,58 @@ func (e *Embed) Validate() error {
 		e.Color = DefaultEmbedColor
 	}
 
-	if len(e.Title) > 256 {
-		return &ErrOverbound{len(e.Title), 256, "title"}
+	if n := utf8.RuneCountInString(e.Title); n > EmbedTitleLimit {
+		return &ErrOverbound{n, EmbedTitleLimit, "title"}
 	}
 
-	if len(e.Description) > 2048 {
-		return &ErrOverbound{len(e.Description), 2048, "description"}
+	if n := utf8.RuneCountInString(e.Description); n > EmbedDescriptionLimit {
+		return &ErrOverbound{n, EmbedDescriptionLimit, "description"}
 	}
 
-	if len(e.Fields) > 25 {
-		return &ErrOverbound{len(e.Fields), 25, "fields"}
+	if len(e.Fields) > EmbedFieldsLimit {
+		return &ErrOverbound{len(e.Fields), EmbedFieldsLimit, "fields"}
 	}
 
 	var sum = 0 +
-		len(e.Title) +
-		len(e.Description)
+		utf8.RuneCountInString(e.Title) +
+		utf8.RuneCountInString(e.Description)
 
 	if e.Footer != nil {
-		if len(e.Footer.Text) > 2048 {
-			return &ErrOverbound{len(e.Footer.Text), 2048, "footer text"}
+		n := utf8.RuneCountInString(e.Footer.Text)
+		if n > EmbedFooterTextLimit {
+			return &ErrOverbound{n, EmbedFooterTextLimit, "footer text"}
 		}
 
-		sum += len(e.Footer.Text)
+		sum += n
 	}
 
 	if e.Author != nil {
-		if len(e.Author.Name) > 256 {
-			return &ErrOverbound{len(e.Author.Name), 256, "author name"}
+		n := utf8.RuneCountInString(e.Author.Name)
+		if n > EmbedAuthorNameLimit {
+			return &ErrOverbound{n, EmbedAuthorNameLimit, "author name"}
 		}
 
-		sum += len(e.Author.Name)
+		sum += n
 	}
 
 	for i, field := range e.Fields {
-		if len(field.Name) > 256 {
-			return &ErrOverbound{len(field.Name), 256,
+		name := utf8.RuneCountInString(field.Name)
+		if name > EmbedFieldNameLimit {
+			return &ErrOverbound{name, EmbedFieldNameLimit,
 				fmt.Sprintf("field %d name", i)}
 		}
 
-		if len(field.Value) > 1024 {
-			return &ErrOverbound{len(field.Value), 1024,
+		value := utf8.RuneCountInString(field.Value)
+		if value > EmbedFieldValueLimit {
+			return &ErrOverbound{value, EmbedFieldValueLimit,
 				fmt.Sprintf("field %d value", i)}
 		}
 
-		sum += len(field.Name) + len(field.Value)
+		sum += name + value
 	}
 
-	if sum > 6000 {
-		return &ErrOverbound{sum, 6000, "sum of all characters"}
+	if sum > EmbedTotalLimit {
+		return &ErrOverbound{sum, EmbedTotalLimit, "sum of all characters"}
 	}
 
 	return nil
@@ -0,0 +1,85 @@
+package discord
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	var tests = []struct {
+		in    string
+		color Color
+		valid bool
+	}{
+		{"#FF8800", 0xFF8800, true},
+		{"0xff8800", 0xFF8800, true},
+		{"ff8800", 0xFF8800, true},
+		{"#f80", 0xFF8800, true},
+		{"#ff880", 0, false},
+		{"#gg8800", 0, false},
+		{"#+f8800", 0, false},
+		{"", 0, false},
+	}
+
+	for _, test := range tests {
+		c, err := ParseColor(test.in)
+		if (err == nil) != test.valid || c != test.color {
+			t.Fatalf("ParseColor(%q) = %v, %v", test.in, c, err)
+		}
+	}
+
+	if s := Color(0x0A0B0C).String(); s != "#0a0b0c" {
+		t.Fatal("Unexpected string:", s)
+	}
+
+	b, err := json.Marshal(Color(0xFF8800))
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if string(b) != "16746496" {
+		t.Fatal("Color is not marshaled as an integer:", string(b))
+	}
+}
+
+func TestEmbedValidate(t *testing.T) {
+	e := NewEmbed().
+		SetTitle("Title").
+		SetDescription(strings.Repeat("a", EmbedDescriptionLimit)).
+		AddField("Name", "Value", true)
+
+	if err := e.Validate(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// Limits are in characters, not bytes.
+	e.SetTitle(strings.Repeat("é", EmbedTitleLimit))
+	if err := e.Validate(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	e.SetDescription(e.Description + "a")
+
+	err := e.Validate()
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	overbound, ok := err.(*ErrOverbound)
+	if !ok {
+		t.Fatalf("Unexpected error type %T", err)
+	}
+	if overbound.Thing != "description" || overbound.Max != EmbedDescriptionLimit {
+		t.Fatal("Unexpected error:", overbound)
+	}
+
+	e.SetDescription(strings.Repeat("a", EmbedDescriptionLimit))
+	for i := 0; i < 2; i++ {
+		e.AddField(strings.Repeat("a", EmbedFieldNameLimit), strings.Repeat("a", EmbedFieldValueLimit), false)
+	}
+
+	err = e.Validate()
+	if overbound, ok := err.(*ErrOverbound); !ok || overbound.Max != EmbedTotalLimit {
+		t.Fatal("Expected the total to be overbound, got", err)
+	}
+}
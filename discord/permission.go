@@ -1,5 +1,10 @@
 package discord
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Permissions uint64
 
 var (
@@ -43,9 +48,8 @@ var (
 	PermissionMentionEveryone Permissions = 1 << 17
 	// Allows the usage of custom emojis from other servers
 	PermissionUseExternalEmojis Permissions = 1 << 18
-
-	// ?
-
+	// Allows for viewing guild insights
+	PermissionViewGuildInsights Permissions = 1 << 19
 	// Allows for joining of a voice channel
 	PermissionConnect Permissions = 1 << 20
 	// Allows for speaking in a voice channel
@@ -66,8 +70,50 @@ var (
 	PermissionManageRoles Permissions = 1 << 28
 	// Allows management and editing of webhooks
 	PermissionManageWebhooks Permissions = 1 << 29
-	// Allows management and editing of emojis
+	// Allows management and editing of emojis, stickers and soundboard sounds
 	PermissionManageEmojis Permissions = 1 << 30
+	// Allows members to use application commands, including slash commands
+	// and context menu commands
+	PermissionUseApplicationCommands Permissions = 1 << 31
+	// Allows for requesting to speak in stage channels
+	PermissionRequestToSpeak Permissions = 1 << 32
+	// Allows for editing and deleting scheduled events
+	PermissionManageEvents Permissions = 1 << 33
+	// Allows for deleting and archiving threads, and viewing all private
+	// threads
+	PermissionManageThreads Permissions = 1 << 34
+	// Allows for creating public and announcement threads
+	PermissionCreatePublicThreads Permissions = 1 << 35
+	// Allows for creating private threads
+	PermissionCreatePrivateThreads Permissions = 1 << 36
+	// Allows the usage of custom stickers from other servers
+	PermissionUseExternalStickers Permissions = 1 << 37
+	// Allows for sending messages in threads
+	PermissionSendMessagesInThreads Permissions = 1 << 38
+	// Allows for using activities in a voice channel
+	PermissionUseEmbeddedActivities Permissions = 1 << 39
+	// Allows for timing out users to prevent them from sending or reacting to
+	// messages in chat and threads, and from speaking in voice and stage
+	// channels
+	PermissionModerateMembers Permissions = 1 << 40
+	// Allows for viewing role subscription insights
+	PermissionViewCreatorMonetizationAnalytics Permissions = 1 << 41
+	// Allows for using the soundboard in a voice channel
+	PermissionUseSoundboard Permissions = 1 << 42
+	// Allows for creating emojis, stickers and soundboard sounds, and editing
+	// and deleting the ones created by the current user
+	PermissionCreateGuildExpressions Permissions = 1 << 43
+	// Allows for creating scheduled events, and editing and deleting the ones
+	// created by the current user
+	PermissionCreateEvents Permissions = 1 << 44
+	// Allows the usage of custom soundboard sounds from other servers
+	PermissionUseExternalSounds Permissions = 1 << 45
+	// Allows sending voice messages
+	PermissionSendVoiceMessages Permissions = 1 << 46
+	// Allows sending polls
+	PermissionSendPolls Permissions = 1 << 49
+	// Allows user-installed apps to send public responses
+	PermissionUseExternalApps Permissions = 1 << 50
 
 	PermissionAllText = 0 |
 		PermissionViewChannel |
@@ -78,7 +124,16 @@ var (
 		PermissionAttachFiles |
 		PermissionReadMessageHistory |
 		PermissionMentionEveryone |
-		PermissionUseExternalEmojis
+		PermissionUseExternalEmojis |
+		PermissionUseExternalStickers |
+		PermissionUseApplicationCommands |
+		PermissionManageThreads |
+		PermissionCreatePublicThreads |
+		PermissionCreatePrivateThreads |
+		PermissionSendMessagesInThreads |
+		PermissionSendVoiceMessages |
+		PermissionSendPolls |
+		PermissionUseExternalApps
 
 	PermissionAllVoice = 0 |
 		PermissionConnect |
@@ -87,7 +142,12 @@ var (
 		PermissionDeafenMembers |
 		PermissionMoveMembers |
 		PermissionUseVAD |
-		PermissionPrioritySpeaker
+		PermissionPrioritySpeaker |
+		PermissionStream |
+		PermissionRequestToSpeak |
+		PermissionUseEmbeddedActivities |
+		PermissionUseSoundboard |
+		PermissionUseExternalSounds
 
 	PermissionAllChannel = 0 |
 		PermissionAllText |
@@ -107,17 +167,143 @@ var (
 		PermissionManageWebhooks |
 		PermissionManageEmojis |
 		PermissionManageNicknames |
-		PermissionChangeNickname
+		PermissionChangeNickname |
+		PermissionModerateMembers |
+		PermissionViewGuildInsights |
+		PermissionManageEvents |
+		PermissionCreateEvents |
+		PermissionCreateGuildExpressions |
+		PermissionViewCreatorMonetizationAnalytics
 )
 
+// Has returns true if the permissions include all of the given ones.
 func (p Permissions) Has(perm Permissions) bool {
 	return HasFlag(uint64(p), uint64(perm))
 }
 
+// Add returns the permissions with the given ones added.
 func (p Permissions) Add(perm Permissions) Permissions {
 	return p | perm
 }
 
+// Remove returns the permissions without the given ones.
+func (p Permissions) Remove(perm Permissions) Permissions {
+	return p &^ perm
+}
+
+// String returns the API names of the permissions joined with "|", such as
+// "VIEW_CHANNEL|SEND_MESSAGES", or "0" if there are none. Unknown bits are
+// formatted as numbers.
+func (p Permissions) String() string {
+	if p == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, n := range permissionNames {
+		if p.Has(n.perm) {
+			names = append(names, n.name)
+			p &^= n.perm
+		}
+	}
+
+	for bit := uint(0); p != 0; bit++ {
+		if p&(1<<bit) != 0 {
+			names = append(names, "1<<"+strconv.FormatUint(uint64(bit), 10))
+			p &^= 1 << bit
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// MarshalJSON encodes the permissions as a string of the number, which is how
+// the API sends them since they no longer fit in a float.
+func (p Permissions) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatUint(uint64(p), 10) + `"`), nil
+}
+
+// UnmarshalJSON decodes the permissions from either a string or a number.
+func (p *Permissions) UnmarshalJSON(b []byte) error {
+	var str = strings.Trim(string(b), `"`)
+	if str == "null" || str == "" {
+		return nil
+	}
+
+	u, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*p = Permissions(u)
+	return nil
+}
+
+// permissionNames are the names of the permissions in the API, in the order
+// of their bits.
+var permissionNames = []struct {
+	perm Permissions
+	name string
+}{
+	{PermissionCreateInstantInvite, "CREATE_INSTANT_INVITE"},
+	{PermissionKickMembers, "KICK_MEMBERS"},
+	{PermissionBanMembers, "BAN_MEMBERS"},
+	{PermissionAdministrator, "ADMINISTRATOR"},
+	{PermissionManageChannels, "MANAGE_CHANNELS"},
+	{PermissionManageGuild, "MANAGE_GUILD"},
+	{PermissionAddReactions, "ADD_REACTIONS"},
+	{PermissionViewAuditLog, "VIEW_AUDIT_LOG"},
+	{PermissionPrioritySpeaker, "PRIORITY_SPEAKER"},
+	{PermissionStream, "STREAM"},
+	{PermissionViewChannel, "VIEW_CHANNEL"},
+	{PermissionSendMessages, "SEND_MESSAGES"},
+	{PermissionSendTTSMessages, "SEND_TTS_MESSAGES"},
+	{PermissionManageMessages, "MANAGE_MESSAGES"},
+	{PermissionEmbedLinks, "EMBED_LINKS"},
+	{PermissionAttachFiles, "ATTACH_FILES"},
+	{PermissionReadMessageHistory, "READ_MESSAGE_HISTORY"},
+	{PermissionMentionEveryone, "MENTION_EVERYONE"},
+	{PermissionUseExternalEmojis, "USE_EXTERNAL_EMOJIS"},
+	{PermissionViewGuildInsights, "VIEW_GUILD_INSIGHTS"},
+	{PermissionConnect, "CONNECT"},
+	{PermissionSpeak, "SPEAK"},
+	{PermissionMuteMembers, "MUTE_MEMBERS"},
+	{PermissionDeafenMembers, "DEAFEN_MEMBERS"},
+	{PermissionMoveMembers, "MOVE_MEMBERS"},
+	{PermissionUseVAD, "USE_VAD"},
+	{PermissionChangeNickname, "CHANGE_NICKNAME"},
+	{PermissionManageNicknames, "MANAGE_NICKNAMES"},
+	{PermissionManageRoles, "MANAGE_ROLES"},
+	{PermissionManageWebhooks, "MANAGE_WEBHOOKS"},
+	{PermissionManageEmojis, "MANAGE_GUILD_EXPRESSIONS"},
+	{PermissionUseApplicationCommands, "USE_APPLICATION_COMMANDS"},
+	{PermissionRequestToSpeak, "REQUEST_TO_SPEAK"},
+	{PermissionManageEvents, "MANAGE_EVENTS"},
+	{PermissionManageThreads, "MANAGE_THREADS"},
+	{PermissionCreatePublicThreads, "CREATE_PUBLIC_THREADS"},
+	{PermissionCreatePrivateThreads, "CREATE_PRIVATE_THREADS"},
+	{PermissionUseExternalStickers, "USE_EXTERNAL_STICKERS"},
+	{PermissionSendMessagesInThreads, "SEND_MESSAGES_IN_THREADS"},
+	{PermissionUseEmbeddedActivities, "USE_EMBEDDED_ACTIVITIES"},
+	{PermissionModerateMembers, "MODERATE_MEMBERS"},
+	{PermissionViewCreatorMonetizationAnalytics, "VIEW_CREATOR_MONETIZATION_ANALYTICS"},
+	{PermissionUseSoundboard, "USE_SOUNDBOARD"},
+	{PermissionCreateGuildExpressions, "CREATE_GUILD_EXPRESSIONS"},
+	{PermissionCreateEvents, "CREATE_EVENTS"},
+	{PermissionUseExternalSounds, "USE_EXTERNAL_SOUNDS"},
+	{PermissionSendVoiceMessages, "SEND_VOICE_MESSAGES"},
+	{PermissionSendPolls, "SEND_POLLS"},
+	{PermissionUseExternalApps, "USE_EXTERNAL_APPS"},
+}
+
+// CalcOverwrites calculates the effective permissions of the member in the
+// channel. The guild must contain its roles. The guild owner and members with
+// the ADMINISTRATOR permission have all permissions, regardless of the
+// channel's overwrites.
+//
+// Overwrites are applied in the order Discord applies them: the @everyone
+// overwrite first, then the overwrites of all of the member's roles at once,
+// and the member's own overwrite last.
 func CalcOverwrites(guild Guild, channel Channel, member Member) Permissions {
 	if guild.OwnerID == member.User.ID {
 		return PermissionAll
@@ -126,7 +312,7 @@ func CalcOverwrites(guild Guild, channel Channel, member Member) Permissions {
 	var perm Permissions
 
 	for _, role := range guild.Roles {
-		if role.ID == guild.ID {
+		if role.ID == RoleID(guild.ID) {
 			perm |= role.Permissions
 			break
 		}
@@ -146,7 +332,7 @@ func CalcOverwrites(guild Guild, channel Channel, member Member) Permissions {
 	}
 
 	for _, overwrite := range channel.Permissions {
-		if overwrite.ID == guild.ID {
+		if overwrite.Type == OverwriteRole && overwrite.ID == Snowflake(guild.ID) {
 			perm &= ^overwrite.Deny
 			perm |= overwrite.Allow
 			break
@@ -156,8 +342,11 @@ func CalcOverwrites(guild Guild, channel Channel, member Member) Permissions {
 	var deny, allow Permissions
 
 	for _, overwrite := range channel.Permissions {
+		if overwrite.Type != OverwriteRole {
+			continue
+		}
 		for _, id := range member.RoleIDs {
-			if id == overwrite.ID && overwrite.Type == "role" {
+			if Snowflake(id) == overwrite.ID {
 				deny |= overwrite.Deny
 				allow |= overwrite.Allow
 				break
@@ -169,16 +358,12 @@ func CalcOverwrites(guild Guild, channel Channel, member Member) Permissions {
 	perm |= allow
 
 	for _, overwrite := range channel.Permissions {
-		if overwrite.ID == member.User.ID {
+		if overwrite.Type == OverwriteMember && overwrite.ID == Snowflake(member.User.ID) {
 			perm &= ^overwrite.Deny
 			perm |= overwrite.Allow
 			break
 		}
 	}
 
-	if perm.Has(PermissionAdministrator) {
-		return PermissionAll
-	}
-
 	return perm
 }
@@ -0,0 +1,146 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+func TestCalcOverwrites(t *testing.T) {
+	var guild = Guild{
+		ID:      1,
+		OwnerID: 2,
+		Roles: []Role{
+			{ID: 1, Permissions: PermissionViewChannel | PermissionSendMessages},
+			{ID: 3, Permissions: PermissionManageMessages},
+			{ID: 4, Permissions: PermissionAdministrator},
+		},
+	}
+
+	var channel = Channel{
+		GuildID: 1,
+		Permissions: []Overwrite{
+			{ID: 1, Type: OverwriteRole, Deny: PermissionSendMessages},
+			{ID: 3, Type: OverwriteRole, Allow: PermissionSendMessages},
+			// A member overwrite with the ID of a role should not apply to the
+			// role.
+			{ID: 3, Type: OverwriteMember, Deny: PermissionViewChannel},
+			{ID: 5, Type: OverwriteMember, Deny: PermissionManageMessages},
+		},
+	}
+
+	var tests = []struct {
+		name   string
+		member Member
+		perm   Permissions
+	}{{
+		name:   "everyone",
+		member: Member{User: User{ID: 6}},
+		perm:   PermissionViewChannel,
+	}, {
+		name:   "role",
+		member: Member{User: User{ID: 6}, RoleIDs: []RoleID{3}},
+		perm:   PermissionViewChannel | PermissionSendMessages | PermissionManageMessages,
+	}, {
+		name:   "member",
+		member: Member{User: User{ID: 5}, RoleIDs: []RoleID{3}},
+		perm:   PermissionViewChannel | PermissionSendMessages,
+	}, {
+		name:   "owner",
+		member: Member{User: User{ID: 2}},
+		perm:   PermissionAll,
+	}, {
+		name:   "administrator",
+		member: Member{User: User{ID: 5}, RoleIDs: []RoleID{4}},
+		perm:   PermissionAll,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if perm := CalcOverwrites(guild, channel, test.member); perm != test.perm {
+				t.Fatalf("Expected %d, got %d", test.perm, perm)
+			}
+		})
+	}
+}
+
+func TestPermissionsString(t *testing.T) {
+	var tests = []struct {
+		perm   Permissions
+		expect string
+	}{
+		{0, "0"},
+		{PermissionSendMessages | PermissionViewChannel, "VIEW_CHANNEL|SEND_MESSAGES"},
+		{PermissionUseExternalApps | 1<<63, "USE_EXTERNAL_APPS|1<<63"},
+	}
+
+	for _, test := range tests {
+		if s := test.perm.String(); s != test.expect {
+			t.Fatalf("Expected %q, got %q", test.expect, s)
+		}
+	}
+}
+
+func TestPermissionAll(t *testing.T) {
+	for _, n := range permissionNames {
+		if !PermissionAll.Has(n.perm) {
+			t.Error("PermissionAll is missing", n.name)
+		}
+	}
+}
+
+func TestPermissionsJSON(t *testing.T) {
+	var perm = PermissionAll.Remove(PermissionAdministrator)
+
+	b, err := json.Marshal(perm)
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if string(b) != `"1829587348619255"` {
+		t.Fatal("Unexpected JSON:", string(b))
+	}
+
+	for _, input := range []string{string(b), "1829587348619255"} {
+		var p Permissions
+		if err := json.Unmarshal([]byte(input), &p); err != nil {
+			t.Fatal("Failed to unmarshal:", err)
+		}
+		if p != perm || p.Has(PermissionAdministrator) {
+			t.Fatalf("Unexpected permissions from %s: %v", input, p)
+		}
+	}
+}
+
+func TestOverwriteUnmarshal(t *testing.T) {
+	var tests = []struct {
+		json   string
+		expect Overwrite
+	}{{
+		json:   `{"id":"1","type":"role","allow":"2048","deny":"1024"}`,
+		expect: Overwrite{ID: 1, Type: OverwriteRole, Allow: PermissionSendMessages, Deny: PermissionViewChannel},
+	}, {
+		json:   `{"id":"2","type":1,"allow":"0","deny":"1099511627776"}`,
+		expect: Overwrite{ID: 2, Type: OverwriteMember, Deny: PermissionModerateMembers},
+	}, {
+		json:   `{"id":"3","type":"member","allow":8,"deny":0}`,
+		expect: Overwrite{ID: 3, Type: OverwriteMember, Allow: PermissionAdministrator},
+	}}
+
+	for _, test := range tests {
+		var o Overwrite
+		if err := json.Unmarshal([]byte(test.json), &o); err != nil {
+			t.Fatal("Failed to unmarshal:", err)
+		}
+		if o != test.expect {
+			t.Fatalf("Unexpected overwrite from %s: %+v", test.json, o)
+		}
+	}
+
+	b, err := json.Marshal(tests[1].expect)
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if string(b) != `{"id":"2","type":1,"allow":"0","deny":"1099511627776"}` {
+		t.Fatal("Unexpected JSON:", string(b))
+	}
+}
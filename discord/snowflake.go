@@ -1,6 +1,9 @@
 package discord
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -22,10 +25,41 @@ type Snowflake int64
 // optional and nullable snowflake fields.
 const NullSnowflake Snowflake = -1
 
+// MaxSnowflake is the largest valid snowflake. It can be used as an upper
+// bound that includes every ID, such as the Before of a query for the latest
+// messages.
+const MaxSnowflake Snowflake = math.MaxInt64
+
+// NewSnowflake creates the smallest snowflake with the given time, with the
+// worker, PID and increment set to 0. It's mostly useful as a bound in
+// queries, as no real snowflake has to match it. Times before the Discord
+// epoch give an invalid snowflake.
 func NewSnowflake(t time.Time) Snowflake {
 	return Snowflake((DurationSinceDiscordEpoch(t) / time.Millisecond) << 22)
 }
 
+// SnowflakeRange returns the exclusive bounds of all snowflakes created from
+// the start time up to, but not including, the end time. They map directly to
+// the after and before parameters of the API:
+//
+//    after, before := discord.SnowflakeRange(start, end)
+//    msgs, err := client.MessagesAfter(channelID, discord.MessageID(after), 0)
+//    // Throw away messages with IDs >= before.
+//
+// A zero end time has no upper bound, so before is MaxSnowflake.
+func SnowflakeRange(start, end time.Time) (after, before Snowflake) {
+	after = NewSnowflake(start) - 1
+	if after < 0 {
+		after = 0
+	}
+
+	if end.IsZero() {
+		return after, MaxSnowflake
+	}
+
+	return after, NewSnowflake(end)
+}
+
 func ParseSnowflake(sf string) (Snowflake, error) {
 	if sf == "null" {
 		return NullSnowflake, nil
@@ -39,8 +73,38 @@ func ParseSnowflake(sf string) (Snowflake, error) {
 	return Snowflake(i), nil
 }
 
+// LossySnowflakes, if true, allows snowflakes encoded as JSON numbers in
+// floating-point notation (e.g. 1.759288472991171e+17) to be decoded even if
+// they may have lost precision. This is false by default, meaning such
+// snowflakes will return ErrSnowflakePrecision.
+var LossySnowflakes = false
+
+// ErrSnowflakePrecision is returned when a snowflake is decoded from a JSON
+// number that cannot be represented exactly. This usually happens when a proxy
+// decodes the snowflake into a float64 and encodes it back.
+var ErrSnowflakePrecision = errors.New("snowflake JSON number lost its precision")
+
+// maxSafeInteger is the largest integer that a float64 can represent exactly.
+const maxSafeInteger = 1<<53 - 1
+
 func (s *Snowflake) UnmarshalJSON(v []byte) error {
-	p, err := ParseSnowflake(strings.Trim(string(v), `"`))
+	str := string(v)
+
+	// Snowflakes are usually strings, in which case they can be parsed
+	// exactly.
+	if strings.HasPrefix(str, `"`) {
+		str = strings.Trim(str, `"`)
+	} else if strings.ContainsAny(str, ".eE") {
+		p, err := parseFloatSnowflake(str)
+		if err != nil {
+			return err
+		}
+
+		*s = p
+		return nil
+	}
+
+	p, err := ParseSnowflake(str)
 	if err != nil {
 		return err
 	}
@@ -49,14 +113,51 @@ func (s *Snowflake) UnmarshalJSON(v []byte) error {
 	return nil
 }
 
+// parseFloatSnowflake parses a snowflake from a JSON number in floating-point
+// notation without going through a float64.
+func parseFloatSnowflake(str string) (Snowflake, error) {
+	r, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return 0, errors.New("invalid snowflake number " + str)
+	}
+
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, ErrSnowflakePrecision
+	}
+
+	i := r.Num().Int64()
+
+	// Integers beyond this limit have likely been rounded by a float64.
+	if !LossySnowflakes && (i > maxSafeInteger || i < -maxSafeInteger) {
+		return 0, ErrSnowflakePrecision
+	}
+
+	return Snowflake(i), nil
+}
+
+// NumberSnowflakes, if true, makes snowflakes encode as JSON numbers instead of
+// strings. Discord accepts both in most requests, but strings are always
+// exact, while numbers are rounded by decoders that use float64, such as
+// JavaScript's. It applies to all snowflake types and all clients.
+var NumberSnowflakes = false
+
+// MarshalJSON encodes the snowflake as a string, or a number if
+// NumberSnowflakes is true. Invalid snowflakes are encoded as null, so fields
+// that may be zero should have the omitempty tag. Snowflake fields don't need
+// the string tag, which is ignored as Snowflake is a json.Marshaler.
 func (s Snowflake) MarshalJSON() ([]byte, error) {
 	// This includes 0 and null, because MarshalJSON does not dictate when a
 	// value gets omitted.
 	if !s.Valid() {
 		return []byte("null"), nil
-	} else {
-		return []byte(`"` + strconv.FormatInt(int64(s), 10) + `"`), nil
 	}
+
+	var str = strconv.FormatInt(int64(s), 10)
+	if NumberSnowflakes {
+		return []byte(str), nil
+	}
+
+	return []byte(`"` + str + `"`), nil
 }
 
 // String returns the ID, or nothing if the snowflake isn't valid.
@@ -73,19 +174,25 @@ func (s Snowflake) Valid() bool {
 	return int64(s) > 0
 }
 
+// Time returns the time at which the snowflake was created, with millisecond
+// precision.
 func (s Snowflake) Time() time.Time {
 	unixnano := ((time.Duration(s) >> 22) * time.Millisecond) + DiscordEpoch
 	return time.Unix(0, int64(unixnano))
 }
 
+// Worker returns the ID of the internal worker that created the snowflake.
 func (s Snowflake) Worker() uint8 {
 	return uint8(s & 0x3E0000 >> 17)
 }
 
+// PID returns the ID of the internal process that created the snowflake.
 func (s Snowflake) PID() uint8 {
 	return uint8(s & 0x1F000 >> 12)
 }
 
+// Increment returns the number of snowflakes created by the same process in
+// the same millisecond before this one.
 func (s Snowflake) Increment() uint16 {
 	return uint16(s & 0xFFF)
 }
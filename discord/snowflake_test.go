@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -41,4 +42,82 @@ func TestSnowflake(t *testing.T) {
 			t.Fatal("Unexpected new snowflake from expected time:", s)
 		}
 	})
+
+	t.Run("range", func(t *testing.T) {
+		s := Snowflake(value)
+
+		after, before := SnowflakeRange(expect, expect.Add(time.Millisecond))
+		if !(after < s && s < before) {
+			t.Fatal("Snowflake is not in its own millisecond:", after, s, before)
+		}
+
+		after, before = SnowflakeRange(expect.Add(time.Millisecond), time.Time{})
+		if after < s {
+			t.Fatal("Snowflake is after a later start:", after, s)
+		}
+		if before != MaxSnowflake {
+			t.Fatal("Unexpected unbounded end:", before)
+		}
+
+		_, before = SnowflakeRange(time.Time{}, expect)
+		if s < before {
+			t.Fatal("Snowflake is before its own time:", s, before)
+		}
+	})
+}
+
+func TestSnowflakeUnmarshal(t *testing.T) {
+	var tests = []struct {
+		json   string
+		expect Snowflake
+		err    error
+	}{
+		{`"175928847299117063"`, 175928847299117063, nil},
+		{`175928847299117063`, 175928847299117063, nil},
+		{`null`, NullSnowflake, nil},
+		{`1.5e3`, 1500, nil},
+		{`1.759288472991171e+17`, 0, ErrSnowflakePrecision},
+		{`1.5`, 0, ErrSnowflakePrecision},
+	}
+
+	for _, test := range tests {
+		var s Snowflake
+
+		if err := json.Unmarshal([]byte(test.json), &s); err != test.err {
+			t.Fatalf("Unexpected error for %s (expected/got): %v/%v", test.json, test.err, err)
+		}
+
+		if test.err == nil && s != test.expect {
+			t.Fatalf("Unexpected snowflake for %s (expected/got): %d/%d", test.json, test.expect, s)
+		}
+	}
+}
+
+func TestSnowflakeMarshal(t *testing.T) {
+	var v = struct {
+		ChannelID ChannelID `json:"channel_id"`
+		ParentID  ChannelID `json:"parent_id,omitempty"`
+		GuildID   GuildID   `json:"guild_id"`
+	}{
+		ChannelID: 175928847299117063,
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if j := string(b); j != `{"channel_id":"175928847299117063","guild_id":null}` {
+		t.Fatal("Unexpected JSON:", j)
+	}
+
+	NumberSnowflakes = true
+	defer func() { NumberSnowflakes = false }()
+
+	b, err = json.Marshal(v)
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+	if j := string(b); j != `{"channel_id":175928847299117063,"guild_id":null}` {
+		t.Fatal("Unexpected JSON:", j)
+	}
 }
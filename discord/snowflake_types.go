@@ -0,0 +1,284 @@
+package discord
+
+import "time"
+
+// The types below are snowflakes of a specific kind of resource. They all
+// behave like Snowflake, but are distinct types, so the compiler catches IDs
+// passed in the wrong order, such as a message ID given as a channel ID. They
+// can be converted to and from Snowflake when needed:
+//
+//    var id = discord.ChannelID(snowflake)
+//    var sf = discord.Snowflake(id)
+
+// AppID is the snowflake of an application.
+type AppID Snowflake
+
+// NullAppID gets encoded into a null. This is used for optional and nullable
+// AppID fields.
+const NullAppID = AppID(NullSnowflake)
+
+func (s AppID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *AppID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s AppID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s AppID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s AppID) Time() time.Time   { return Snowflake(s).Time() }
+func (s AppID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s AppID) PID() uint8        { return Snowflake(s).PID() }
+func (s AppID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// AttachmentID is the snowflake of a message attachment.
+type AttachmentID Snowflake
+
+// NullAttachmentID gets encoded into a null. This is used for optional and nullable
+// AttachmentID fields.
+const NullAttachmentID = AttachmentID(NullSnowflake)
+
+func (s AttachmentID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *AttachmentID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s AttachmentID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s AttachmentID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s AttachmentID) Time() time.Time   { return Snowflake(s).Time() }
+func (s AttachmentID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s AttachmentID) PID() uint8        { return Snowflake(s).PID() }
+func (s AttachmentID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// AuditLogEntryID is the snowflake of an audit log entry.
+type AuditLogEntryID Snowflake
+
+// NullAuditLogEntryID gets encoded into a null. This is used for optional and nullable
+// AuditLogEntryID fields.
+const NullAuditLogEntryID = AuditLogEntryID(NullSnowflake)
+
+func (s AuditLogEntryID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *AuditLogEntryID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s AuditLogEntryID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s AuditLogEntryID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s AuditLogEntryID) Time() time.Time   { return Snowflake(s).Time() }
+func (s AuditLogEntryID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s AuditLogEntryID) PID() uint8        { return Snowflake(s).PID() }
+func (s AuditLogEntryID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// ChannelID is the snowflake of a channel.
+type ChannelID Snowflake
+
+// NullChannelID gets encoded into a null. This is used for optional and nullable
+// ChannelID fields.
+const NullChannelID = ChannelID(NullSnowflake)
+
+func (s ChannelID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *ChannelID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s ChannelID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s ChannelID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s ChannelID) Time() time.Time   { return Snowflake(s).Time() }
+func (s ChannelID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s ChannelID) PID() uint8        { return Snowflake(s).PID() }
+func (s ChannelID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// EmojiID is the snowflake of a custom emoji.
+type EmojiID Snowflake
+
+// NullEmojiID gets encoded into a null. This is used for optional and nullable
+// EmojiID fields.
+const NullEmojiID = EmojiID(NullSnowflake)
+
+func (s EmojiID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *EmojiID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s EmojiID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s EmojiID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s EmojiID) Time() time.Time   { return Snowflake(s).Time() }
+func (s EmojiID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s EmojiID) PID() uint8        { return Snowflake(s).PID() }
+func (s EmojiID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// GuildID is the snowflake of a guild.
+type GuildID Snowflake
+
+// NullGuildID gets encoded into a null. This is used for optional and nullable
+// GuildID fields.
+const NullGuildID = GuildID(NullSnowflake)
+
+func (s GuildID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *GuildID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s GuildID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s GuildID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s GuildID) Time() time.Time   { return Snowflake(s).Time() }
+func (s GuildID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s GuildID) PID() uint8        { return Snowflake(s).PID() }
+func (s GuildID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// IntegrationID is the snowflake of a guild integration.
+type IntegrationID Snowflake
+
+// NullIntegrationID gets encoded into a null. This is used for optional and nullable
+// IntegrationID fields.
+const NullIntegrationID = IntegrationID(NullSnowflake)
+
+func (s IntegrationID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *IntegrationID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s IntegrationID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s IntegrationID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s IntegrationID) Time() time.Time   { return Snowflake(s).Time() }
+func (s IntegrationID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s IntegrationID) PID() uint8        { return Snowflake(s).PID() }
+func (s IntegrationID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// MessageID is the snowflake of a message.
+type MessageID Snowflake
+
+// NullMessageID gets encoded into a null. This is used for optional and nullable
+// MessageID fields.
+const NullMessageID = MessageID(NullSnowflake)
+
+func (s MessageID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *MessageID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s MessageID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s MessageID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s MessageID) Time() time.Time   { return Snowflake(s).Time() }
+func (s MessageID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s MessageID) PID() uint8        { return Snowflake(s).PID() }
+func (s MessageID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// RoleID is the snowflake of a role.
+type RoleID Snowflake
+
+// NullRoleID gets encoded into a null. This is used for optional and nullable
+// RoleID fields.
+const NullRoleID = RoleID(NullSnowflake)
+
+func (s RoleID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *RoleID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s RoleID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s RoleID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s RoleID) Time() time.Time   { return Snowflake(s).Time() }
+func (s RoleID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s RoleID) PID() uint8        { return Snowflake(s).PID() }
+func (s RoleID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// StickerID is the snowflake of a sticker.
+type StickerID Snowflake
+
+// NullStickerID gets encoded into a null. This is used for optional and nullable
+// StickerID fields.
+const NullStickerID = StickerID(NullSnowflake)
+
+func (s StickerID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *StickerID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s StickerID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s StickerID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s StickerID) Time() time.Time   { return Snowflake(s).Time() }
+func (s StickerID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s StickerID) PID() uint8        { return Snowflake(s).PID() }
+func (s StickerID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// StickerPackID is the snowflake of a pack of standard stickers.
+type StickerPackID Snowflake
+
+// NullStickerPackID gets encoded into a null. This is used for optional and nullable
+// StickerPackID fields.
+const NullStickerPackID = StickerPackID(NullSnowflake)
+
+func (s StickerPackID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *StickerPackID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s StickerPackID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s StickerPackID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s StickerPackID) Time() time.Time   { return Snowflake(s).Time() }
+func (s StickerPackID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s StickerPackID) PID() uint8        { return Snowflake(s).PID() }
+func (s StickerPackID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// UserID is the snowflake of a user.
+type UserID Snowflake
+
+// NullUserID gets encoded into a null. This is used for optional and nullable
+// UserID fields.
+const NullUserID = UserID(NullSnowflake)
+
+func (s UserID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *UserID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s UserID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s UserID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s UserID) Time() time.Time   { return Snowflake(s).Time() }
+func (s UserID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s UserID) PID() uint8        { return Snowflake(s).PID() }
+func (s UserID) Increment() uint16 { return Snowflake(s).Increment() }
+
+// WebhookID is the snowflake of a webhook.
+type WebhookID Snowflake
+
+// NullWebhookID gets encoded into a null. This is used for optional and nullable
+// WebhookID fields.
+const NullWebhookID = WebhookID(NullSnowflake)
+
+func (s WebhookID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *WebhookID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s WebhookID) String() string { return Snowflake(s).String() }
+
+// Valid returns whether or not the snowflake is valid.
+func (s WebhookID) Valid() bool { return Snowflake(s).Valid() }
+
+func (s WebhookID) Time() time.Time   { return Snowflake(s).Time() }
+func (s WebhookID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s WebhookID) PID() uint8        { return Snowflake(s).PID() }
+func (s WebhookID) Increment() uint16 { return Snowflake(s).Increment() }
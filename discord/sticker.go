@@ -0,0 +1,46 @@
+package discord
+
+// Sticker is a sticker that can be sent in messages, either a standard one
+// from a pack or one uploaded to a guild.
+type Sticker struct {
+	// ID is the id of the sticker.
+	ID StickerID `json:"id"`
+	// PackID is the id of the pack of standard stickers.
+	PackID StickerPackID `json:"pack_id,omitempty"`
+	// Name is the name of the sticker.
+	Name string `json:"name"`
+	// Description is the description of the sticker.
+	Description string `json:"description"`
+	// Tags are the autocomplete and suggestion tags of the sticker, separated
+	// by commas.
+	Tags string `json:"tags"`
+	// Type is the type of the sticker.
+	Type StickerType `json:"type"`
+	// Format is the format type of the sticker.
+	Format StickerFormat `json:"format_type"`
+
+	// These fields are only set for guild stickers.
+
+	// Available is false if the sticker can't be used because the guild lost
+	// its boosts.
+	Available bool `json:"available,omitempty"`
+	// GuildID is the id of the guild that owns the sticker.
+	GuildID GuildID `json:"guild_id,omitempty"`
+	// User is the user that uploaded the sticker.
+	User *User `json:"user,omitempty"`
+}
+
+// StickerType is the type of a sticker.
+type StickerType uint8
+
+const (
+	// StandardSticker is an official sticker in a pack.
+	StandardSticker StickerType = 1
+	// GuildSticker is a sticker uploaded to a guild.
+	GuildSticker StickerType = 2
+)
+
+// URL returns the URL of the sticker in its only format.
+func (s Sticker) URL() URL {
+	return StickerAsset(s.ID, s.Format).URL(AutoImage, 0)
+}
@@ -2,6 +2,7 @@ package discord
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -26,10 +27,12 @@ func NowTimestamp() Timestamp {
 	return NewTimestamp(time.Now())
 }
 
-// UnmarshalJSON parses a nullable RFC3339 string into time.
+// UnmarshalJSON parses a nullable RFC3339 string into time. Both null and an
+// empty string are parsed as the zero value.
 func (t *Timestamp) UnmarshalJSON(v []byte) error {
 	str := strings.Trim(string(v), `"`)
-	if str == "null" {
+	if str == "null" || str == "" {
+		*t = Timestamp{}
 		return nil
 	}
 
@@ -66,13 +69,33 @@ func (t Timestamp) Time() time.Time {
 
 //
 
+// UnixTimestamp is a Unix time in seconds. Like Timestamp, its zero value is
+// treated as invalid.
 type UnixTimestamp int64
 
+// TimeToUnixTimestamp converts the time to a UnixTimestamp. The zero time is
+// converted to 0.
+func TimeToUnixTimestamp(t time.Time) UnixTimestamp {
+	if t.IsZero() {
+		return 0
+	}
+	return UnixTimestamp(t.Unix())
+}
+
+// Valid returns false if the timestamp is 0.
+func (t UnixTimestamp) Valid() bool {
+	return t != 0
+}
+
 func (t UnixTimestamp) String() string {
 	return t.Time().String()
 }
 
+// Time returns the time of the timestamp, or the zero time if it's not valid.
 func (t UnixTimestamp) Time() time.Time {
+	if !t.Valid() {
+		return time.Time{}
+	}
 	return time.Unix(int64(t), 0)
 }
 
@@ -122,6 +145,60 @@ func (s Seconds) Duration() time.Duration {
 
 //
 
+// ArchiveDuration is the duration of inactivity in minutes after which a thread
+// is archived. Only the durations below are accepted by Discord.
+type ArchiveDuration int
+
+const (
+	OneHourArchive   ArchiveDuration = 60
+	OneDayArchive    ArchiveDuration = 1440
+	ThreeDaysArchive ArchiveDuration = 4320
+	SevenDaysArchive ArchiveDuration = 10080
+)
+
+// DurationToArchiveDuration converts the duration to an ArchiveDuration. It
+// returns an error if the duration is not one of the accepted durations.
+func DurationToArchiveDuration(dura time.Duration) (ArchiveDuration, error) {
+	a := ArchiveDuration(dura / time.Minute)
+	if a.Duration() != dura || !a.valid() {
+		return 0, fmt.Errorf("invalid archive duration %v", dura)
+	}
+	return a, nil
+}
+
+func (a ArchiveDuration) valid() bool {
+	switch a {
+	case OneHourArchive, OneDayArchive, ThreeDaysArchive, SevenDaysArchive:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate returns an error if the duration is not accepted by Discord or is
+// not available to guilds of the given premium tier.
+func (a ArchiveDuration) Validate(tier NitroBoost) error {
+	if !a.valid() {
+		return fmt.Errorf("invalid archive duration %d minutes", a)
+	}
+
+	if max := tier.MaxArchiveDuration(); a > max {
+		return fmt.Errorf("archive duration %v is over the limit %v of the guild", a, max)
+	}
+
+	return nil
+}
+
+func (a ArchiveDuration) String() string {
+	return a.Duration().String()
+}
+
+func (a ArchiveDuration) Duration() time.Duration {
+	return time.Duration(a) * time.Minute
+}
+
+//
+
 // Milliseconds is in float64 because some Discord events return time with a
 // trailing decimal.
 type Milliseconds float64
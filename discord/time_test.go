@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	var tests = []struct {
+		json  string
+		time  time.Time
+		valid bool
+	}{
+		{`null`, time.Time{}, false},
+		{`""`, time.Time{}, false},
+		{`"2020-05-01T12:30:15.123000+00:00"`, time.Date(2020, 5, 1, 12, 30, 15, 123e6, time.UTC), true},
+		{`"2020-05-01T14:30:15+02:00"`, time.Date(2020, 5, 1, 12, 30, 15, 0, time.UTC), true},
+	}
+
+	for _, test := range tests {
+		// Start with a valid timestamp to check that null resets it.
+		var ts = NowTimestamp()
+		if err := json.Unmarshal([]byte(test.json), &ts); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", test.json, err)
+		}
+
+		if ts.Valid() != test.valid || !ts.Time().Equal(test.time) {
+			t.Fatalf("Unmarshaled %s into %v", test.json, ts.Time())
+		}
+	}
+
+	b, err := json.Marshal(struct {
+		Valid   Timestamp `json:"valid"`
+		Invalid Timestamp `json:"invalid"`
+	}{
+		Valid: NewTimestamp(time.Date(2020, 5, 1, 12, 30, 15, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatal("Failed to marshal:", err)
+	}
+
+	if s := string(b); s != `{"valid":"2020-05-01T12:30:15Z","invalid":null}` {
+		t.Fatal("Unexpected JSON:", s)
+	}
+}
+
+func TestUnixTimestamp(t *testing.T) {
+	var ts UnixTimestamp
+	if err := json.Unmarshal([]byte(`1588336215`), &ts); err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if want := time.Date(2020, 5, 1, 12, 30, 15, 0, time.UTC); !ts.Time().Equal(want) {
+		t.Fatal("Unexpected time:", ts.Time())
+	}
+	if TimeToUnixTimestamp(ts.Time()) != ts {
+		t.Fatal("Time did not convert back to", ts)
+	}
+
+	if UnixTimestamp(0).Valid() || !UnixTimestamp(0).Time().IsZero() {
+		t.Fatal("The zero timestamp is not treated as invalid")
+	}
+	if TimeToUnixTimestamp(time.Time{}) != 0 {
+		t.Fatal("The zero time did not convert to 0")
+	}
+}
+
+func TestArchiveDuration(t *testing.T) {
+	a, err := DurationToArchiveDuration(24 * time.Hour)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if a != OneDayArchive {
+		t.Fatalf("Expected %d, got %d", OneDayArchive, a)
+	}
+
+	if _, err := DurationToArchiveDuration(2 * time.Hour); err == nil {
+		t.Fatal("Expected an error for 2 hours")
+	}
+	if _, err := DurationToArchiveDuration(time.Hour + time.Second); err == nil {
+		t.Fatal("Expected an error for 1 hour and 1 second")
+	}
+
+	if err := SevenDaysArchive.Validate(NitroLevel1); err == nil {
+		t.Fatal("Expected an error for 7 days on level 1")
+	}
+	if err := SevenDaysArchive.Validate(NitroLevel2); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
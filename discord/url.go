@@ -1,6 +1,9 @@
 package discord
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 type ImageType string
 
@@ -16,18 +19,26 @@ const (
 	WebPImage ImageType = ".webp"
 	// GIFImage is the GIF image type.
 	GIFImage ImageType = ".gif"
+	// LottieImage is the Lottie animation type, which only Lottie stickers are
+	// available in.
+	LottieImage ImageType = ".json"
 )
 
-func (t ImageType) format(name string) string {
-	if t == AutoImage {
-		if strings.HasPrefix(name, "a_") {
-			return name + ".gif"
-		}
+// HashAnimated returns true if the given asset hash belongs to an animated
+// image, which is the case if it has the "a_" prefix.
+func HashAnimated(hash Hash) bool {
+	return strings.HasPrefix(hash, "a_")
+}
 
-		return name + ".png"
+// SizedURL appends the size query to a CDN URL. The size is clamped between
+// MinImageSize and MaxImageSize, and rounded down to a power of 2, as the CDN
+// accepts no other sizes. An empty URL is returned as-is.
+func SizedURL(url URL, size int) URL {
+	if url == "" {
+		return ""
 	}
 
-	return name + string(t)
+	return url + "?size=" + strconv.Itoa(clampImageSize(size))
 }
 
 type URL = string
@@ -5,10 +5,10 @@ import (
 )
 
 type User struct {
-	ID            Snowflake `json:"id,string"`
-	Username      string    `json:"username"`
-	Discriminator string    `json:"discriminator"`
-	Avatar        Hash      `json:"avatar"`
+	ID            UserID `json:"id"`
+	Username      string `json:"username"`
+	Discriminator string `json:"discriminator"`
+	Avatar        Hash   `json:"avatar"`
 
 	// These fields may be omitted
 
@@ -24,14 +24,32 @@ type User struct {
 	Flags       UserFlags `json:"flags,omitempty"`
 	PublicFlags UserFlags `json:"public_flags,omitempty"`
 	Nitro       UserNitro `json:"premium_type,omitempty"`
+
+	// Banner is the user's banner hash, if any.
+	Banner Hash `json:"banner,omitempty"`
+	// AccentColor is the user's banner color, which is shown if the user has
+	// no banner. It's 0 if the user never set one.
+	AccentColor Color `json:"accent_color,omitempty"`
+
+	// AvatarDecoration is the user's avatar decoration, if any.
+	AvatarDecoration *AvatarDecoration `json:"avatar_decoration_data,omitempty"`
+	// Collectibles contains the collectibles the user has equipped, if any.
+	Collectibles *Collectibles `json:"collectibles,omitempty"`
+	// ProfileEffect is the user's profile effect, if any. This field is
+	// undocumented.
+	ProfileEffect *ProfileEffect `json:"profile_effect,omitempty"`
 }
 
 func (u User) Mention() string {
-	return "<@" + u.ID.String() + ">"
+	return u.ID.Mention()
 }
 
 // AvatarURL returns the URL of the Avatar Image. It automatically detects a
-// suitable type.
+// suitable type, which is GIF for animated avatars. To get a specific size,
+// wrap it with SizedURL:
+//
+//    url := discord.SizedURL(u.AvatarURL(), 64)
+//
 func (u User) AvatarURL() string {
 	return u.AvatarURLWithType(AutoImage)
 }
@@ -47,15 +65,102 @@ func (u User) AvatarURLWithType(t ImageType) string {
 			return ""
 		}
 
-		disc, err := strconv.Atoi(u.Discriminator)
-		if err != nil { // this should never happen
-			return ""
-		}
+		return DefaultAvatarAsset(u.defaultAvatar()).URL(PNGImage, 0)
+	}
+
+	return UserAvatarAsset(u.ID, u.Avatar).URL(t, 0)
+}
+
+// defaultAvatar returns the index of the user's default avatar. Users without
+// a discriminator have 6 default avatars instead of 5.
+func (u User) defaultAvatar() int {
+	if u.Discriminator == "" || u.Discriminator == "0" {
+		return int((uint64(u.ID) >> 22) % 6)
+	}
+
+	disc, err := strconv.Atoi(u.Discriminator)
+	if err != nil { // this should never happen
+		return 0
+	}
+
+	return disc % 5
+}
+
+// BannerURL returns the URL of the user's banner, or an empty string if the
+// user has no banner. It automatically detects a suitable type. The banner is
+// only sent when the user is fetched directly, such as with api.User.
+func (u User) BannerURL() string {
+	return u.BannerURLWithType(AutoImage)
+}
+
+// BannerURLWithType returns the URL of the user's banner using the passed
+// type, or an empty string if the user has no banner.
+//
+// Supported Image Types: PNG, JPEG, WebP, GIF
+func (u User) BannerURLWithType(t ImageType) string {
+	return UserBannerAsset(u.ID, u.Banner).URL(t, 0)
+}
+
+// AvatarAnimated returns true if the user's avatar is animated.
+func (u User) AvatarAnimated() bool {
+	return HashAnimated(u.Avatar)
+}
+
+// AvatarDecoration is the decoration rendered around a user's avatar.
+//
+// https://discord.com/developers/docs/resources/user#avatar-decoration-data-object
+type AvatarDecoration struct {
+	// Asset is the avatar decoration hash.
+	Asset Hash `json:"asset"`
+	// SKUID is the ID of the avatar decoration's SKU.
+	SKUID Snowflake `json:"sku_id,omitempty"`
+}
+
+// URL returns the URL of the avatar decoration as a PNG image.
+func (d AvatarDecoration) URL() string {
+	return AvatarDecorationAsset(d.Asset).URL(PNGImage, 0)
+}
+
+// Collectibles contains the collectibles that a user has equipped.
+//
+// https://discord.com/developers/docs/resources/user#collectibles
+type Collectibles struct {
+	// Nameplate is the user's nameplate, if any.
+	Nameplate *Nameplate `json:"nameplate,omitempty"`
+}
+
+// Nameplate is a collectible rendered behind a user's name in the member list.
+//
+// https://discord.com/developers/docs/resources/user#nameplate
+type Nameplate struct {
+	// SKUID is the ID of the nameplate's SKU.
+	SKUID Snowflake `json:"sku_id"`
+	// Asset is the path to the nameplate asset, such as
+	// "nameplates/nameplates/twilight/".
+	Asset string `json:"asset"`
+	// Label is the label of this nameplate.
+	Label string `json:"label"`
+	// Palette is the background color of the nameplate, such as "crimson".
+	Palette string `json:"palette"`
+}
 
-		return "https://cdn.discordapp.com/embed/avatars/" + string(disc%5) + ".png"
+// URL returns the URL of the nameplate's static image. If animated is true,
+// the animated WebM variant will be returned instead.
+func (n Nameplate) URL(animated bool) string {
+	if animated && n.Asset != "" {
+		// WebM isn't an image type, so it can't be a CDNAsset.
+		return CDNURL + "assets/collectibles/" + n.Asset + "asset.webm"
 	}
+	return NameplateAsset(n.Asset).URL(PNGImage, 0)
+}
 
-	return "https://cdn.discordapp.com/avatars/" + u.ID.String() + "/" + t.format(u.Avatar)
+// ProfileEffect is the animated effect shown on a user's profile. This object
+// is undocumented.
+type ProfileEffect struct {
+	// ID is the ID of the profile effect.
+	ID Snowflake `json:"id"`
+	// ExpiresAt is when the profile effect expires, if ever.
+	ExpiresAt UnixMsTimestamp `json:"expires_at,omitempty"`
 }
 
 type UserFlags uint32
@@ -81,14 +186,25 @@ const (
 	_
 	VerifiedBot
 	VerifiedBotDeveloper
+	CertifiedModerator
+	BotHTTPInteractions
+	_
+	_
+	ActiveDeveloper
 )
 
+// Has returns true if the flags include all of the given flags.
+func (f UserFlags) Has(flags UserFlags) bool {
+	return HasFlag(uint64(f), uint64(flags))
+}
+
 type UserNitro uint8
 
 const (
 	NoUserNitro UserNitro = iota
 	NitroClassic
 	NitroFull
+	NitroBasic
 )
 
 type Connection struct {
@@ -125,28 +241,44 @@ const (
 	OfflineStatus      Status = "offline"
 )
 
+// Activity is the activity of a user, or the one that a bot sets for itself.
+// Bots can only set the name, type and URL.
+//
+// https://discord.com/developers/docs/topics/gateway#activity-object
 type Activity struct {
+	// Name is the name of the activity.
 	Name string       `json:"name"`
 	Type ActivityType `json:"type"`
-	URL  URL          `json:"url,omitempty"`
+	// URL is the stream URL, which is only used by StreamingActivity.
+	URL URL `json:"url,omitempty"`
 
 	// User only
 
-	CreatedAt  UnixTimestamp      `json:"created_at,omitempty"`
+	// CreatedAt is when the activity was added to the user's session.
+	CreatedAt UnixMsTimestamp `json:"created_at,omitempty"`
+	// Timestamps are the start and end of the activity, such as a song.
 	Timestamps *ActivityTimestamp `json:"timestamps,omitempty"`
 
-	ApplicationID Snowflake `json:"application_id,omitempty"`
-	Details       string    `json:"details,omitempty"`
-	State         string    `json:"state,omitempty"` // party status
-	Emoji         *Emoji    `json:"emoji,omitempty"`
+	ApplicationID AppID `json:"application_id,omitempty"`
+	// Details is what the user is currently doing.
+	Details string `json:"details,omitempty"`
+	// State is the party status, or the text of a CustomActivity.
+	State string `json:"state,omitempty"`
+	// Emoji is the emoji of a CustomActivity.
+	Emoji *Emoji `json:"emoji,omitempty"`
 
 	Party   *ActivityParty   `json:"party,omitempty"`
 	Assets  *ActivityAssets  `json:"assets,omitempty"`
 	Secrets *ActivitySecrets `json:"secrets,omitempty"`
 
+	// Instance is whether or not the activity is an instanced game session.
 	Instance bool          `json:"instance,omitempty"`
 	Flags    ActivityFlags `json:"flags,omitempty"`
 
+	// Buttons are the labels of the custom buttons shown with the activity.
+	// Their URLs are not sent to bots.
+	Buttons []string `json:"buttons,omitempty"`
+
 	// Undocumented fields
 	SyncID    string `json:"sync_id,omitempty"`
 	SessionID string `json:"session_id,omitempty"`
@@ -161,9 +293,12 @@ const (
 	StreamingActivity
 	// Listening to $name
 	ListeningActivity
-	_
+	// Watching $name
+	WatchingActivity
 	// $emoji $state
 	CustomActivity
+	// Competing in $name
+	CompetingActivity
 )
 
 type ActivityFlags uint32
@@ -175,18 +310,27 @@ const (
 	JoinRequestActivity
 	SyncActivity
 	PlayActivity
+	PartyPrivacyFriendsActivity
+	PartyPrivacyVoiceChannelActivity
+	EmbeddedActivity
 )
 
+// ActivityTimestamp is the start and end of an activity. Either may be
+// missing.
 type ActivityTimestamp struct {
 	Start UnixMsTimestamp `json:"start,omitempty"`
 	End   UnixMsTimestamp `json:"end,omitempty"`
 }
 
+// ActivityParty is the party of the user in an activity.
 type ActivityParty struct {
 	ID   string `json:"id,omitempty"`
 	Size [2]int `json:"size,omitempty"` // [ current, max ]
 }
 
+// ActivityAssets are the images of an activity and their hover texts. The
+// images are IDs of the application's assets, or "mp:" prefixed media proxy
+// paths.
 type ActivityAssets struct {
 	LargeImage string `json:"large_image,omitempty"` // id
 	LargeText  string `json:"large_text,omitempty"`
@@ -194,6 +338,7 @@ type ActivityAssets struct {
 	SmallText  string `json:"small_text,omitempty"`
 }
 
+// ActivitySecrets are the secrets used to join or spectate a user's game.
 type ActivitySecrets struct {
 	Join     string `json:"join,omitempty"`
 	Spectate string `json:"spectate,omitempty"`
@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserURLs(t *testing.T) {
+	var tests = []struct {
+		name   string
+		url    string
+		expect string
+	}{
+		{
+			"animated avatar",
+			User{ID: 1337, Avatar: "a_hash"}.AvatarURL(),
+			"https://cdn.discordapp.com/avatars/1337/a_hash.gif",
+		},
+		{
+			"sized avatar",
+			SizedURL(User{ID: 1337, Avatar: "hash"}.AvatarURL(), 64),
+			"https://cdn.discordapp.com/avatars/1337/hash.png?size=64",
+		},
+		{
+			"default avatar",
+			User{ID: 1337, Discriminator: "0007"}.AvatarURL(),
+			"https://cdn.discordapp.com/embed/avatars/2.png",
+		},
+		{
+			"default avatar without discriminator",
+			User{ID: 5 << 22, Discriminator: "0"}.AvatarURL(),
+			"https://cdn.discordapp.com/embed/avatars/5.png",
+		},
+		{
+			"banner",
+			User{ID: 1337, Banner: "a_hash"}.BannerURLWithType(WebPImage),
+			"https://cdn.discordapp.com/banners/1337/a_hash.webp",
+		},
+		{
+			"no banner",
+			User{ID: 1337}.BannerURL(),
+			"",
+		},
+		{
+			"avatar decoration",
+			AvatarDecoration{Asset: "a_hash"}.URL(),
+			"https://cdn.discordapp.com/avatar-decoration-presets/a_hash.png",
+		},
+		{
+			"static nameplate",
+			Nameplate{Asset: "nameplates/twilight/"}.URL(false),
+			"https://cdn.discordapp.com/assets/collectibles/nameplates/twilight/static.png",
+		},
+		{
+			"animated nameplate",
+			Nameplate{Asset: "nameplates/twilight/"}.URL(true),
+			"https://cdn.discordapp.com/assets/collectibles/nameplates/twilight/asset.webm",
+		},
+		{
+			"no nameplate",
+			Nameplate{}.URL(true),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		if test.url != test.expect {
+			t.Fatalf("Unexpected %s URL: %q", test.name, test.url)
+		}
+	}
+}
+
+func TestUserFlags(t *testing.T) {
+	var flags = VerifiedBot | ActiveDeveloper
+
+	if ActiveDeveloper != 1<<22 || CertifiedModerator != 1<<18 {
+		t.Fatal("Unexpected flag values")
+	}
+	if !flags.Has(ActiveDeveloper) || flags.Has(ActiveDeveloper|DiscordPartner) {
+		t.Fatal("Unexpected Has result")
+	}
+}
+
+func TestUserCollectiblesUnmarshal(t *testing.T) {
+	var u User
+
+	err := json.Unmarshal([]byte(`{
+		"id": "1337",
+		"username": "user",
+		"avatar_decoration_data": {
+			"asset": "a_fed43ab12698df65902ba06727e20c0e",
+			"sku_id": "1144058844004233369"
+		},
+		"collectibles": {
+			"nameplate": {
+				"sku_id": "2247558840304243311",
+				"asset": "nameplates/nameplates/twilight/",
+				"label": "",
+				"palette": "cobalt"
+			}
+		},
+		"profile_effect": {
+			"id": "1139323098643333151",
+			"expires_at": 1735689600000
+		}
+	}`), &u)
+	if err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if d := u.AvatarDecoration; d == nil ||
+		d.Asset != "a_fed43ab12698df65902ba06727e20c0e" || d.SKUID != 1144058844004233369 {
+
+		t.Fatal("Unexpected avatar decoration:", d)
+	}
+
+	if u.Collectibles == nil || u.Collectibles.Nameplate == nil {
+		t.Fatal("Missing nameplate")
+	}
+	if n := *u.Collectibles.Nameplate; n.SKUID != 2247558840304243311 ||
+		n.Asset != "nameplates/nameplates/twilight/" || n.Palette != "cobalt" {
+
+		t.Fatal("Unexpected nameplate:", n)
+	}
+
+	if e := u.ProfileEffect; e == nil || e.ID != 1139323098643333151 {
+		t.Fatal("Unexpected profile effect:", e)
+	}
+	if at := u.ProfileEffect.ExpiresAt.Time().UTC(); at.Year() != 2025 || at.YearDay() != 1 {
+		t.Fatal("Unexpected profile effect expiry:", at)
+	}
+}
+
+func TestUserCollectiblesMissing(t *testing.T) {
+	var u User
+
+	if err := json.Unmarshal([]byte(`{"id":"1337","avatar_decoration_data":null}`), &u); err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+
+	if u.AvatarDecoration != nil || u.Collectibles != nil || u.ProfileEffect != nil {
+		t.Fatal("Unexpected collectibles:", u.AvatarDecoration, u.Collectibles, u.ProfileEffect)
+	}
+}
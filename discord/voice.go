@@ -2,10 +2,10 @@ package discord
 
 type VoiceState struct {
 	// GuildID isn't available from the Guild struct.
-	GuildID Snowflake `json:"guild_id,string"`
+	GuildID GuildID `json:"guild_id"`
 
-	ChannelID Snowflake `json:"channel_id,string"`
-	UserID    Snowflake `json:"user_id,string"`
+	ChannelID ChannelID `json:"channel_id"`
+	UserID    UserID    `json:"user_id"`
 	Member    *Member   `json:"member,omitempty"`
 	SessionID string    `json:"session_id"`
 
@@ -18,11 +18,44 @@ type VoiceState struct {
 	Suppress   bool `json:"suppress"`
 }
 
+// https://discord.com/developers/docs/resources/voice#voice-region-object
 type VoiceRegion struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	VIP        bool   `json:"vip"`
-	Optimal    bool   `json:"optimal"`
-	Deprecated bool   `json:"deprecated"`
-	Custom     bool   `json:"custom"` // used for events
+	// ID is the unique ID of the region, which is used as the RTC region of
+	// voice channels.
+	ID string `json:"id"`
+	// Name is the name of the region.
+	Name string `json:"name"`
+	// VIP is true if the region is only available to VIP guilds.
+	VIP bool `json:"vip"`
+	// Optimal is true if the region is the closest to the current user's
+	// client.
+	Optimal bool `json:"optimal"`
+	// Deprecated is true if the region should not be used anymore.
+	Deprecated bool `json:"deprecated"`
+	// Custom is true if the region is a custom one, used for events.
+	Custom bool `json:"custom"`
+}
+
+// OptimalVoiceRegion returns the optimal region out of the given ones, or the
+// first region that isn't deprecated if none is optimal. It returns false if
+// all regions are deprecated.
+func OptimalVoiceRegion(regions []VoiceRegion) (VoiceRegion, bool) {
+	var fallback *VoiceRegion
+
+	for i, r := range regions {
+		if r.Deprecated {
+			continue
+		}
+		if r.Optimal {
+			return r, true
+		}
+		if fallback == nil {
+			fallback = &regions[i]
+		}
+	}
+
+	if fallback == nil {
+		return VoiceRegion{}, false
+	}
+	return *fallback, true
 }
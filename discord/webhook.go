@@ -1,12 +1,12 @@
 package discord
 
 type Webhook struct {
-	ID   Snowflake   `json:"id"`
+	ID   WebhookID   `json:"id"`
 	Type WebhookType `json:"type"`
 	User User        `json:"user"` // creator
 
-	GuildID   Snowflake `json:"guild_id,omitempty"`
-	ChannelID Snowflake `json:"channel_id"`
+	GuildID   GuildID   `json:"guild_id,omitempty"`
+	ChannelID ChannelID `json:"channel_id"`
 
 	Name   string `json:"name"`
 	Avatar Hash   `json:"avatar"`
@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/diamondburned/arikawa/discord"
 	"github.com/pkg/errors"
@@ -51,12 +53,13 @@ func (g *Gateway) Resume() error {
 type HeartbeatData int
 
 func (g *Gateway) Heartbeat() error {
+	atomic.StoreInt64(&g.sentBeat, time.Now().UnixNano())
 	return g.Send(HeartbeatOP, g.Sequence.Get())
 }
 
 type RequestGuildMembersData struct {
-	GuildID []discord.Snowflake `json:"guild_id"`
-	UserIDs []discord.Snowflake `json:"user_ids,omitempty"`
+	GuildID []discord.GuildID `json:"guild_id"`
+	UserIDs []discord.UserID  `json:"user_ids,omitempty"`
 
 	Query     string `json:"query,omitempty"`
 	Limit     uint   `json:"limit"`
@@ -68,8 +71,8 @@ func (g *Gateway) RequestGuildMembers(data RequestGuildMembersData) error {
 }
 
 type UpdateVoiceStateData struct {
-	GuildID   discord.Snowflake `json:"guild_id"`
-	ChannelID discord.Snowflake `json:"channel_id"` // nullable
+	GuildID   discord.GuildID   `json:"guild_id"`
+	ChannelID discord.ChannelID `json:"channel_id"` // nullable
 	SelfMute  bool              `json:"self_mute"`
 	SelfDeaf  bool              `json:"self_deaf"`
 }
@@ -81,8 +84,7 @@ func (g *Gateway) UpdateVoiceState(data UpdateVoiceStateData) error {
 type UpdateStatusData struct {
 	Since discord.UnixMsTimestamp `json:"since"` // 0 if not idle
 
-	// Both fields are nullable.
-	Game       *discord.Activity   `json:"game,omitempty"`
+	// Activities is nullable. Bots may only have one activity.
 	Activities *[]discord.Activity `json:"activities,omitempty"`
 
 	Status discord.Status `json:"status"`
@@ -93,14 +95,58 @@ func (g *Gateway) UpdateStatus(data UpdateStatusData) error {
 	return g.Send(StatusUpdateOP, data)
 }
 
+// Game creates a "Playing $name" activity to be used in UpdateStatusData.
+func Game(name string) discord.Activity {
+	return discord.Activity{
+		Name: name,
+		Type: discord.GameActivity,
+	}
+}
+
+// Listening creates a "Listening to $name" activity to be used in
+// UpdateStatusData.
+func Listening(name string) discord.Activity {
+	return discord.Activity{
+		Name: name,
+		Type: discord.ListeningActivity,
+	}
+}
+
+// Watching creates a "Watching $name" activity to be used in UpdateStatusData.
+func Watching(name string) discord.Activity {
+	return discord.Activity{
+		Name: name,
+		Type: discord.WatchingActivity,
+	}
+}
+
+// Competing creates a "Competing in $name" activity to be used in
+// UpdateStatusData.
+func Competing(name string) discord.Activity {
+	return discord.Activity{
+		Name: name,
+		Type: discord.CompetingActivity,
+	}
+}
+
+// Streaming creates a "Streaming $name" activity to be used in
+// UpdateStatusData. The URL must be a Twitch or YouTube URL.
+func Streaming(name string, url discord.URL) discord.Activity {
+	return discord.Activity{
+		Name: name,
+		Type: discord.StreamingActivity,
+		URL:  url,
+	}
+}
+
 // Undocumented
 type GuildSubscribeData struct {
-	Typing     bool              `json:"typing"`
-	Activities bool              `json:"activities"`
-	GuildID    discord.Snowflake `json:"guild_id"`
+	Typing     bool            `json:"typing"`
+	Activities bool            `json:"activities"`
+	GuildID    discord.GuildID `json:"guild_id"`
 
 	// Channels is not documented. It's used to fetch the right members sidebar.
-	Channels map[discord.Snowflake][][2]int `json:"channels"`
+	Channels map[discord.ChannelID][][2]int `json:"channels"`
 }
 
 func (g *Gateway) GuildSubscribe(data GuildSubscribeData) error {
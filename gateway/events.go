@@ -24,17 +24,17 @@ type (
 	ChannelUpdateEvent     discord.Channel
 	ChannelDeleteEvent     discord.Channel
 	ChannelPinsUpdateEvent struct {
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
-		ChannelID discord.Snowflake `json:"channel_id,omitempty"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+		ChannelID discord.ChannelID `json:"channel_id,omitempty"`
 		LastPin   discord.Timestamp `json:"timestamp,omitempty"`
 	}
 
 	ChannelUnreadUpdateEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
+		GuildID discord.GuildID `json:"guild_id"`
 
 		ChannelUnreadUpdates []struct {
-			ID            discord.Snowflake `json:"id"`
-			LastMessageID discord.Snowflake `json:"last_message_id"`
+			ID            discord.ChannelID `json:"id"`
+			LastMessageID discord.MessageID `json:"last_message_id"`
 		}
 	}
 )
@@ -44,7 +44,7 @@ type (
 	GuildCreateEvent struct {
 		discord.Guild
 
-		Joined      discord.Timestamp `json:"timestamp,omitempty"`
+		Joined      discord.Timestamp `json:"joined_at,omitempty"`
 		Large       bool              `json:"large,omitempty"`
 		Unavailable bool              `json:"unavailable,omitempty"`
 		MemberCount uint64            `json:"member_count,omitempty"`
@@ -56,48 +56,53 @@ type (
 	}
 	GuildUpdateEvent discord.Guild
 	GuildDeleteEvent struct {
-		ID discord.Snowflake `json:"id"`
+		ID discord.GuildID `json:"id"`
 		// Unavailable if false == removed
 		Unavailable bool `json:"unavailable"`
 	}
 
 	GuildBanAddEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		User    discord.User      `json:"user"`
+		GuildID discord.GuildID `json:"guild_id"`
+		User    discord.User    `json:"user"`
 	}
 	GuildBanRemoveEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		User    discord.User      `json:"user"`
+		GuildID discord.GuildID `json:"guild_id"`
+		User    discord.User    `json:"user"`
 	}
 
 	GuildEmojisUpdateEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		Emojis  []discord.Emoji   `json:"emoji"`
+		GuildID discord.GuildID `json:"guild_id"`
+		Emojis  []discord.Emoji `json:"emojis"`
+	}
+	GuildStickersUpdateEvent struct {
+		GuildID  discord.GuildID   `json:"guild_id"`
+		Stickers []discord.Sticker `json:"stickers"`
 	}
 
 	GuildIntegrationsUpdateEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
+		GuildID discord.GuildID `json:"guild_id"`
 	}
 
 	GuildMemberAddEvent struct {
 		discord.Member
-		GuildID discord.Snowflake `json:"guild_id"`
+		GuildID discord.GuildID `json:"guild_id"`
 	}
 	GuildMemberRemoveEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		User    discord.User      `json:"user"`
+		GuildID discord.GuildID `json:"guild_id"`
+		User    discord.User    `json:"user"`
 	}
 	GuildMemberUpdateEvent struct {
-		GuildID discord.Snowflake   `json:"guild_id"`
-		RoleIDs []discord.Snowflake `json:"roles"`
-		User    discord.User        `json:"user"`
-		Nick    string              `json:"nick"`
+		GuildID discord.GuildID  `json:"guild_id"`
+		RoleIDs []discord.RoleID `json:"roles"`
+		User    discord.User     `json:"user"`
+		Nick    string           `json:"nick"`
+		Avatar  discord.Hash     `json:"avatar"`
 	}
 
 	// GuildMembersChunkEvent is sent when Guild Request Members is called.
 	GuildMembersChunkEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		Members []discord.Member  `json:"members"`
+		GuildID discord.GuildID  `json:"guild_id"`
+		Members []discord.Member `json:"members"`
 
 		// Whatever's not found goes here
 		NotFound []string `json:"not_found,omitempty"`
@@ -106,20 +111,25 @@ type (
 		Presences []discord.Presence `json:"presences,omitempty"`
 	}
 
-	// GuildMemberListUpdate is an undocumented event. It's received when the
-	// client sends over GuildSubscriptions with the Channels field used.
+	// GuildMemberListUpdateEvent is an undocumented event. It's received when
+	// the client sends over GuildSubscriptions with the Channels field used.
 	// The State package does not handle this event.
-	GuildMemberListUpdate struct {
-		ID          string            `json:"id"`
-		GuildID     discord.Snowflake `json:"guild_id"`
-		MemberCount uint64            `json:"member_count"`
-		OnlineCount uint64            `json:"online_count"`
+	GuildMemberListUpdateEvent struct {
+		ID          string          `json:"id"`
+		GuildID     discord.GuildID `json:"guild_id"`
+		MemberCount uint64          `json:"member_count"`
+		OnlineCount uint64          `json:"online_count"`
 
 		// Groups is all the visible role sections.
 		Groups []GuildMemberListGroup `json:"groups"`
 
 		Ops []GuildMemberListOp `json:"ops"`
 	}
+	// GuildMemberListUpdate is the old name of GuildMemberListUpdateEvent.
+	//
+	// Deprecated: Use GuildMemberListUpdateEvent.
+	GuildMemberListUpdate = GuildMemberListUpdateEvent
+
 	GuildMemberListGroup struct {
 		ID    string `json:"id"` // either discord.Snowflake Role IDs or "online"
 		Count uint64 `json:"count"`
@@ -141,7 +151,7 @@ type (
 		Items []GuildMemberListOpItem `json:"items,omitempty"`
 	}
 	// GuildMemberListOpItem is an enum. Either of the fields are provided, but
-	// never both. Refer to (*GuildMemberListUpdate).Ops for more.
+	// never both. Refer to (*GuildMemberListUpdateEvent).Ops for more.
 	GuildMemberListOpItem struct {
 		Group  *GuildMemberListGroup `json:"group,omitempty"`
 		Member *struct {
@@ -152,16 +162,16 @@ type (
 	}
 
 	GuildRoleCreateEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		Role    discord.Role      `json:"role"`
+		GuildID discord.GuildID `json:"guild_id"`
+		Role    discord.Role    `json:"role"`
 	}
 	GuildRoleUpdateEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		Role    discord.Role      `json:"role"`
+		GuildID discord.GuildID `json:"guild_id"`
+		Role    discord.Role    `json:"role"`
 	}
 	GuildRoleDeleteEvent struct {
-		GuildID discord.Snowflake `json:"guild_id"`
-		RoleID  discord.Snowflake `json:"role_id"`
+		GuildID discord.GuildID `json:"guild_id"`
+		RoleID  discord.RoleID  `json:"role_id"`
 	}
 )
 
@@ -169,6 +179,7 @@ func (u GuildMemberUpdateEvent) Update(m *discord.Member) {
 	m.RoleIDs = u.RoleIDs
 	m.User = u.User
 	m.Nick = u.Nick
+	m.Avatar = u.Avatar
 }
 
 // https://discord.com/developers/docs/topics/gateway#invites
@@ -176,20 +187,22 @@ type (
 	InviteCreateEvent struct {
 		Code      string            `json:"code"`
 		CreatedAt discord.Timestamp `json:"created_at"`
-		ChannelID discord.Snowflake `json:"channel_id"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 
 		// Similar to discord.Invite
 		Inviter    *discord.User          `json:"inviter,omitempty"`
 		Target     *discord.User          `json:"target_user,omitempty"`
-		TargetType discord.InviteUserType `json:"target_user_type,omitempty"`
+		TargetType discord.InviteUserType `json:"target_type,omitempty"`
+
+		TargetApplication *discord.MessageApplication `json:"target_application,omitempty"`
 
 		discord.InviteMetadata
 	}
 	InviteDeleteEvent struct {
 		Code      string            `json:"code"`
-		ChannelID discord.Snowflake `json:"channel_id"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 	}
 )
 
@@ -204,48 +217,48 @@ type (
 		Member *discord.Member `json:"member,omitempty"`
 	}
 	MessageDeleteEvent struct {
-		ID        discord.Snowflake `json:"id"`
-		ChannelID discord.Snowflake `json:"channel_id"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		ID        discord.MessageID `json:"id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 	}
 	MessageDeleteBulkEvent struct {
-		IDs       []discord.Snowflake `json:"ids"`
-		ChannelID discord.Snowflake   `json:"channel_id"`
-		GuildID   discord.Snowflake   `json:"guild_id,omitempty"`
+		IDs       []discord.MessageID `json:"ids"`
+		ChannelID discord.ChannelID   `json:"channel_id"`
+		GuildID   discord.GuildID     `json:"guild_id,omitempty"`
 	}
 
 	MessageReactionAddEvent struct {
-		UserID    discord.Snowflake `json:"user_id"`
-		ChannelID discord.Snowflake `json:"channel_id"`
-		MessageID discord.Snowflake `json:"message_id"`
+		UserID    discord.UserID    `json:"user_id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		MessageID discord.MessageID `json:"message_id"`
 
 		Emoji discord.Emoji `json:"emoji,omitempty"`
 
-		GuildID discord.Snowflake `json:"guild_id,omitempty"`
-		Member  *discord.Member   `json:"member,omitempty"`
+		GuildID discord.GuildID `json:"guild_id,omitempty"`
+		Member  *discord.Member `json:"member,omitempty"`
 	}
 	MessageReactionRemoveEvent struct {
-		UserID    discord.Snowflake `json:"user_id"`
-		ChannelID discord.Snowflake `json:"channel_id"`
-		MessageID discord.Snowflake `json:"message_id"`
+		UserID    discord.UserID    `json:"user_id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		MessageID discord.MessageID `json:"message_id"`
 		Emoji     discord.Emoji     `json:"emoji"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 	}
 	MessageReactionRemoveAllEvent struct {
-		ChannelID discord.Snowflake `json:"channel_id"`
-		MessageID discord.Snowflake `json:"message_id"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		MessageID discord.MessageID `json:"message_id"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 	}
 	MessageReactionRemoveEmoji struct {
-		ChannelID discord.Snowflake `json:"channel_id"`
-		MessageID discord.Snowflake `json:"message_id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
+		MessageID discord.MessageID `json:"message_id"`
 		Emoji     discord.Emoji     `json:"emoji"`
-		GuildID   discord.Snowflake `json:"guild_id,omitempty"`
+		GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 	}
 
 	MessageAckEvent struct {
-		MessageID discord.Snowflake `json:"message_id"`
-		ChannelID discord.Snowflake `json:"channel_id"`
+		MessageID discord.MessageID `json:"message_id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
 	}
 )
 
@@ -276,12 +289,12 @@ type (
 	}
 
 	TypingStartEvent struct {
-		ChannelID discord.Snowflake     `json:"channel_id"`
-		UserID    discord.Snowflake     `json:"user_id"`
+		ChannelID discord.ChannelID     `json:"channel_id"`
+		UserID    discord.UserID        `json:"user_id"`
 		Timestamp discord.UnixTimestamp `json:"timestamp"`
 
-		GuildID discord.Snowflake `json:"guild_id,omitempty"`
-		Member  *discord.Member   `json:"member,omitempty"`
+		GuildID discord.GuildID `json:"guild_id,omitempty"`
+		Member  *discord.Member `json:"member,omitempty"`
 	}
 
 	UserUpdateEvent struct {
@@ -295,17 +308,17 @@ type (
 		discord.VoiceState
 	}
 	VoiceServerUpdateEvent struct {
-		Token    string            `json:"token"`
-		GuildID  discord.Snowflake `json:"guild_id"`
-		Endpoint string            `json:"endpoint"`
+		Token    string          `json:"token"`
+		GuildID  discord.GuildID `json:"guild_id"`
+		Endpoint string          `json:"endpoint"`
 	}
 )
 
 // https://discordapp.com/developers/docs/topics/gateway#webhooks
 type (
 	WebhooksUpdateEvent struct {
-		GuildID   discord.Snowflake `json:"guild_id"`
-		ChannelID discord.Snowflake `json:"channel_id"`
+		GuildID   discord.GuildID   `json:"guild_id"`
+		ChannelID discord.ChannelID `json:"channel_id"`
 	}
 )
 
@@ -318,8 +331,8 @@ type (
 		UserSettings
 	}
 	UserNoteUpdateEvent struct {
-		ID   discord.Snowflake `json:"id"`
-		Note string            `json:"note"`
+		ID   discord.UserID `json:"id"`
+		Note string         `json:"note"`
 	}
 )
 
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/diamondburned/arikawa/api"
@@ -26,7 +27,7 @@ var (
 	EndpointGateway    = api.Endpoint + "gateway"
 	EndpointGatewayBot = api.EndpointGateway + "/bot"
 
-	Version  = "6"
+	Version  = api.APIVersion
 	Encoding = "json"
 	// Compress = "zlib-stream"
 )
@@ -50,6 +51,9 @@ type SessionStartLimit struct {
 	Total      int                  `json:"total"`
 	Remaining  int                  `json:"remaining"`
 	ResetAfter discord.Milliseconds `json:"reset_after"`
+	// MaxConcurrency is the number of shards that can identify every 5
+	// seconds.
+	MaxConcurrency int `json:"max_concurrency"`
 }
 
 // URL asks Discord for a Websocket URL to the Gateway.
@@ -77,6 +81,14 @@ func BotURL(token string) (*GatewayBotData, error) {
 }
 
 type Gateway struct {
+	// These are accessed atomically, so they're kept first to be 64-bit
+	// aligned. Refer to Stats.
+	events     uint64
+	reconnects uint64
+	latency    int64 // nanoseconds
+	sentBeat   int64 // unix nanoseconds
+	lastAck    int64 // unix nanoseconds
+
 	WS        *wsutil.Websocket
 	WSTimeout time.Duration
 
@@ -85,6 +97,25 @@ type Gateway struct {
 	// Session.
 	Events chan Event
 
+	// SendMetadata, if true, will make the Gateway wrap all events sent into
+	// Events in an *EventWithMetadata. This is false by default.
+	SendMetadata bool
+
+	// EventFilter, if not nil, is called with the name of every dispatched
+	// event, such as "TYPING_START". Events that it returns false for are
+	// dropped before they're decoded, which saves the work for events that are
+	// never handled, such as presence updates on large bots:
+	//
+	//    g.EventFilter = gateway.IgnoreEvents("PRESENCE_UPDATE", "TYPING_START")
+	//
+	// READY and RESUMED are never filtered. Note that the State doesn't see
+	// dropped events either, so its store won't be updated by them.
+	EventFilter func(name string) bool
+
+	// JSON is the driver that decodes events and encodes commands. It
+	// defaults to json.Default if nil.
+	JSON json.Driver
+
 	SessionID string
 
 	Identifier *Identifier
@@ -93,6 +124,21 @@ type Gateway struct {
 
 	ErrorLog func(err error) // default to log.Println
 
+	// ReconnectPolicy controls the delays between reconnection attempts and
+	// how many are made. It defaults to DefaultReconnectPolicy.
+	ReconnectPolicy ReconnectPolicy
+
+	// OnConnect, if not nil, is called after a new session is started, which
+	// is when READY is received.
+	OnConnect func()
+	// OnResume, if not nil, is called after a session is resumed, which is
+	// when RESUMED is received.
+	OnResume func()
+	// OnDisconnect, if not nil, is called when the connection is lost, right
+	// before reconnecting. The code is the close code sent by Discord, such as
+	// 4000, or -1 if the connection was lost without one.
+	OnDisconnect func(code int)
+
 	// AfterClose is called after each close. Error can be non-nil, as this is
 	// called even when the Gateway is gracefully closed. It's used mainly for
 	// reconnections or any type of connection interruptions.
@@ -136,13 +182,48 @@ func NewCustomGateway(gatewayURL, token string) *Gateway {
 		Identifier: DefaultIdentifier(token),
 		Sequence:   NewSequence(),
 
+		ReconnectPolicy: DefaultReconnectPolicy,
+
 		ErrorLog:   wsutil.WSError,
 		AfterClose: func(error) {},
 	}
 }
 
+// IsFatalCloseCode returns true if the close code can't be recovered from by
+// reconnecting, such as 4004 for an invalid token or 4014 for privileged
+// intents that aren't enabled in the developer portal. Reconnecting after these
+// would only use up session starts.
+func IsFatalCloseCode(code int) bool {
+	switch code {
+	case 4004, // Authentication failed
+		4010, // Invalid shard
+		4011, // Sharding required
+		4012, // Invalid API version
+		4013, // Invalid intents
+		4014: // Disallowed intents
+		return true
+	}
+	return false
+}
+
+// resumableCloseCode is the close code sent by CloseResumable. Discord
+// invalidates the session when a connection is closed with 1000 or 1001, but
+// not with other codes.
+const resumableCloseCode = 4000
+
 // Close closes the underlying Websocket connection.
 func (g *Gateway) Close() error {
+	return g.close(g.WS.Close)
+}
+
+// CloseResumable closes the connection like Close, but tells Discord that the
+// session should be kept, so that a later Open resumes it with the same
+// SessionID and Sequence, and the events sent in between are replayed.
+func (g *Gateway) CloseResumable() error {
+	return g.close(func() error { return g.WS.CloseWithCode(resumableCloseCode) })
+}
+
+func (g *Gateway) close(closeWS func() error) error {
 	wsutil.WSDebug("Trying to close.")
 
 	// Check if the WS is already closed:
@@ -174,13 +255,15 @@ func (g *Gateway) Close() error {
 
 	wsutil.WSDebug("WaitGroup is done. Closing the websocket.")
 
-	err := g.WS.Close()
+	err := closeWS()
 	g.AfterClose(err)
 	return err
 }
 
 // Reconnect tries to reconnect forever. It will resume the connection if
-// possible. If an Invalid Session is received, it will start a fresh one.
+// possible. If an Invalid Session is received, it will start a fresh one. It
+// gives up right away if Discord closes the connection with a fatal close code.
+// Refer to IsFatalCloseCode.
 func (g *Gateway) Reconnect() error {
 	return g.ReconnectContext(context.Background())
 }
@@ -193,6 +276,21 @@ func (g *Gateway) ReconnectContext(ctx context.Context) error {
 	g.Close()
 
 	for i := 1; ; i++ {
+		if max := g.ReconnectPolicy.MaxAttempts; max > 0 && i > max {
+			return errors.Wrapf(ErrWSMaxTries, "failed to reconnect after %d attempts", max)
+		}
+
+		// The first attempt is immediate, and the ones after back off.
+		if delay := g.ReconnectPolicy.Delay(i - 1); delay > 0 {
+			wsutil.WSDebug("Waiting before dialing:", delay)
+
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return errors.Wrap(ctx.Err(), "failed to reconnect")
+			}
+		}
+
 		wsutil.WSDebug("Trying to dial, attempt", i)
 
 		// Condition: err == ErrInvalidSession:
@@ -200,11 +298,16 @@ func (g *Gateway) ReconnectContext(ctx context.Context) error {
 		// https://discordapp.com/developers/docs/topics/gateway#rate-limiting
 
 		if err := g.OpenContext(ctx); err != nil {
+			if code := wsutil.CloseCode(err); IsFatalCloseCode(code) {
+				return errors.Wrapf(err, "fatal close code %d", code)
+			}
+
 			g.ErrorLog(errors.Wrap(err, "failed to open gateway"))
 			continue
 		}
 
 		wsutil.WSDebug("Started after attempt:", i)
+		atomic.AddUint64(&g.reconnects, 1)
 		return nil
 	}
 }
@@ -282,6 +385,7 @@ func (g *Gateway) start() error {
 	wsutil.WSDebug("Waiting for either READY or RESUMED.")
 
 	// WaitForEvent should
+	var resumed bool
 	err := wsutil.WaitForEvent(g, ch, func(op *wsutil.OP) bool {
 		switch op.EventName {
 		case "READY":
@@ -289,6 +393,7 @@ func (g *Gateway) start() error {
 			return true
 		case "RESUMED":
 			wsutil.WSDebug("Found RESUMED event.")
+			resumed = true
 			return true
 		}
 		return false
@@ -310,12 +415,37 @@ func (g *Gateway) start() error {
 
 		if err != nil {
 			g.ErrorLog(err)
-			g.Reconnect()
+
+			var code = wsutil.CloseCode(err)
+
+			if g.OnDisconnect != nil {
+				g.OnDisconnect(code)
+			}
+
+			// Don't use up a session start on a connection that would be
+			// closed the same way again.
+			if IsFatalCloseCode(code) {
+				g.Close()
+				g.ErrorLog(errors.Errorf("not reconnecting after fatal close code %d", code))
+				return
+			}
+
+			if err := g.Reconnect(); err != nil {
+				g.ErrorLog(err)
+			}
 		}
 	})
 
 	wsutil.WSDebug("Started successfully.")
 
+	// The connection is fully up, so tell the callbacks.
+	if resumed && g.OnResume != nil {
+		g.OnResume()
+	}
+	if !resumed && g.OnConnect != nil {
+		g.OnConnect()
+	}
+
 	return nil
 }
 
@@ -325,7 +455,7 @@ func (g *Gateway) Send(code OPCode, v interface{}) error {
 	}
 
 	if v != nil {
-		b, err := json.Marshal(v)
+		b, err := g.jsonDriver().Marshal(v)
 		if err != nil {
 			return errors.Wrap(err, "failed to encode v")
 		}
@@ -333,11 +463,77 @@ func (g *Gateway) Send(code OPCode, v interface{}) error {
 		op.Data = b
 	}
 
-	b, err := json.Marshal(op)
+	b, err := g.jsonDriver().Marshal(op)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode payload")
 	}
 
+	// Heartbeats skip the SendLimiter, as their budget is reserved, so that
+	// they're never held back by other commands.
+	if code == HeartbeatOP {
+		return g.WS.SendUnlimited(context.Background(), b)
+	}
+
 	// WS should already be thread-safe.
 	return g.WS.Send(b)
 }
+
+// Latency returns the round trip time of the last heartbeat, or 0 if no
+// heartbeat has been acknowledged yet.
+func (g *Gateway) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&g.latency))
+}
+
+// Stats is a snapshot of a Gateway's health. It's encoded as JSON, so it can
+// be published with expvar or scraped into other metrics systems:
+//
+//    expvar.Publish("gateway", expvar.Func(func() interface{} {
+//        return g.Stats()
+//    }))
+type Stats struct {
+	ShardID int `json:"shard_id"`
+	// Latency is the round trip time of the last heartbeat in nanoseconds.
+	Latency time.Duration `json:"latency"`
+	// LastAck is when the last heartbeat was acknowledged. It's zero if none
+	// was.
+	LastAck time.Time `json:"last_ack"`
+	// Reconnects is the number of times the Gateway has reconnected.
+	Reconnects uint64 `json:"reconnects"`
+	// Events is the number of dispatched events received, including the ones
+	// dropped by the EventFilter. Its rate over time is the event throughput.
+	Events uint64 `json:"events"`
+}
+
+// Stats returns the current stats of the Gateway. It's safe to call from any
+// goroutine.
+func (g *Gateway) Stats() Stats {
+	var stats = Stats{
+		ShardID:    g.shardID(),
+		Latency:    g.Latency(),
+		Reconnects: atomic.LoadUint64(&g.reconnects),
+		Events:     atomic.LoadUint64(&g.events),
+	}
+
+	if ack := atomic.LoadInt64(&g.lastAck); ack > 0 {
+		stats.LastAck = time.Unix(0, ack)
+	}
+
+	return stats
+}
+
+// ackHeartbeat records a heartbeat acknowledgement for the stats.
+func (g *Gateway) ackHeartbeat() {
+	var now = time.Now().UnixNano()
+	atomic.StoreInt64(&g.lastAck, now)
+
+	if sent := atomic.LoadInt64(&g.sentBeat); sent > 0 {
+		atomic.StoreInt64(&g.latency, now-sent)
+	}
+}
+
+func (g *Gateway) jsonDriver() json.Driver {
+	if g.JSON != nil {
+		return g.JSON
+	}
+	return json.Default
+}
@@ -0,0 +1,176 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+)
+
+func TestUnknownEvent(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Error("Gateway error:", err) }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	if err := conn.Dispatch("NEW_FEATURE_CREATE", map[string]int{"id": 1}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case ev := <-g.Events:
+			unknown, ok := ev.(*gateway.UnknownEvent)
+			if !ok {
+				continue
+			}
+			if unknown.EventName() != "NEW_FEATURE_CREATE" || string(unknown.Raw) != `{"id":1}` {
+				t.Fatalf("Unexpected event %s: %s", unknown.Type, unknown.Raw)
+			}
+			return
+		case <-timeout:
+			t.Fatal("Timed out waiting for the unknown event")
+		}
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.EventFilter = gateway.IgnoreEvents("TYPING_START")
+	g.ErrorLog = func(err error) { t.Error("Gateway error:", err) }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	if err := conn.Dispatch("TYPING_START", gateway.TypingStartEvent{ChannelID: 1}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+	if err := conn.Dispatch("MESSAGE_CREATE", discord.Message{ID: 2}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case ev := <-g.Events:
+			switch ev.(type) {
+			case *gateway.TypingStartEvent:
+				t.Fatal("Filtered event was sent")
+			case *gateway.MessageCreateEvent:
+				if g.SessionID == "" {
+					t.Fatal("READY was filtered")
+				}
+				return
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for MESSAGE_CREATE")
+		}
+	}
+}
+
+func TestHeartbeatUnlimited(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	conn.HeartbeatInterval = 10 * time.Millisecond
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	// Use up the whole budget for commands.
+	g.WS.SendLimiter = rate.NewLimiter(0, 0)
+
+	if err := g.UpdateStatus(gateway.UpdateStatusData{Status: discord.OnlineStatus}); err == nil {
+		t.Fatal("Command was sent without budget")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := conn.WaitFor(ctx, gateway.HeartbeatOP); err != nil {
+		t.Fatal("Heartbeat was not sent:", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	conn.HeartbeatInterval = 10 * time.Millisecond
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	timeout := time.After(5 * time.Second)
+	tick := time.NewTicker(time.Millisecond)
+	defer tick.Stop()
+
+	for g.Stats().LastAck.IsZero() {
+		select {
+		case <-g.Events:
+		case <-tick.C:
+		case <-timeout:
+			t.Fatal("Timed out waiting for a heartbeat ACK")
+		}
+	}
+
+	stats := g.Stats()
+	if stats.Latency <= 0 || stats.Events < 1 || stats.Reconnects != 0 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
+
+type typingHandler struct {
+	typing []*gateway.TypingStartEvent
+}
+
+func (h *typingHandler) HandleTypingStart(ev *gateway.TypingStartEvent) {
+	h.typing = append(h.typing, ev)
+}
+
+func TestEventRegistry(t *testing.T) {
+	for _, name := range []string{"HELLO", "INVALID_SESSION"} {
+		if _, ok := gateway.EventCreator[name]; ok {
+			t.Error("Opcode event is registered as a dispatch:", name)
+		}
+	}
+
+	for name, fn := range gateway.EventCreator {
+		if ev, ok := fn().(gateway.NamedEvent); !ok || ev.EventName() != name {
+			t.Errorf("Event %T is registered as %q", fn(), name)
+		}
+	}
+
+	var h typingHandler
+	var ev = &gateway.TypingStartEvent{ChannelID: 1}
+
+	if !gateway.HandleEvent(&h, ev) || len(h.typing) != 1 || h.typing[0] != ev {
+		t.Fatal("TypingStartEvent was not handled:", h.typing)
+	}
+	if gateway.HandleEvent(&h, &gateway.MessageCreateEvent{}) {
+		t.Fatal("MessageCreateEvent was handled without a handler method")
+	}
+}
@@ -0,0 +1,278 @@
+// Package gatewaytest provides a fake gateway connection for testing code that
+// uses the Gateway, Session or State without connecting to Discord. The
+// connection acts as Discord would: it sends Hello after dialing, replies to
+// Identify with Ready and to Resume with Resumed, acknowledges heartbeats,
+// and records every command sent to it.
+//
+//    conn := gatewaytest.NewConn()
+//    conn.Ready.User = discord.User{ID: 1, Username: "bot"}
+//
+//    s := session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token"))
+//    if err := s.Open(); err != nil {
+//        return err
+//    }
+//
+//    conn.Dispatch("MESSAGE_CREATE", discord.Message{...})
+package gatewaytest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/utils/json"
+	"github.com/diamondburned/arikawa/utils/wsutil"
+)
+
+// URL is the fake address that gateways made by NewGateway connect to.
+const URL = "wss://gatewaytest.invalid"
+
+// Buffer is the number of events that can be queued before the gateway reads
+// them. Dispatch returns an error if the buffer is full.
+var Buffer = 100
+
+// ErrClosed is returned when sending to or dispatching into a closed Conn.
+var ErrClosed = errors.New("gatewaytest: connection is closed")
+
+// Conn is a fake gateway connection that implements wsutil.Connection.
+type Conn struct {
+	// Ready is the event sent after Identify. Its SessionID defaults to
+	// "gatewaytest" if empty.
+	Ready gateway.ReadyEvent
+	// HeartbeatInterval is sent in Hello. It defaults to a minute, which is
+	// long enough for heartbeats to not get in the way of most tests.
+	HeartbeatInterval time.Duration
+	// CloseCode, if not 0, makes the connection close with the code instead
+	// of replying to Identify and Resume, like Discord does for an invalid
+	// token (4004) or disallowed intents (4014).
+	CloseCode int
+
+	mutex  sync.Mutex
+	events chan wsutil.Event
+	closed bool
+	seq    int64
+
+	sent   []wsutil.OP
+	notify chan struct{} // closed and replaced on each Send
+}
+
+var _ wsutil.Connection = (*Conn)(nil)
+
+// NewConn creates a new fake connection.
+func NewConn() *Conn {
+	return &Conn{
+		HeartbeatInterval: time.Minute,
+		notify:            make(chan struct{}),
+	}
+}
+
+// NewGateway creates a gateway that uses the given fake connection. The dial
+// and identify rate limits and the reconnection delays are lifted, so tests
+// can reconnect without waiting.
+func NewGateway(conn *Conn, token string) *gateway.Gateway {
+	g := gateway.NewCustomGateway(URL, token)
+	g.Identifier.IdentifyShortLimit = rate.NewLimiter(rate.Inf, 1)
+	g.Identifier.IdentifyGlobalLimit = rate.NewLimiter(rate.Inf, 1)
+	g.ReconnectPolicy = gateway.ReconnectPolicy{}
+
+	g.WS = wsutil.NewCustom(conn, URL)
+	g.WS.DialLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	return g
+}
+
+// Dial implements wsutil.Connection. It queues a Hello event.
+func (c *Conn) Dial(ctx context.Context, addr string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.events = make(chan wsutil.Event, Buffer)
+	c.closed = false
+
+	return c.queue(gateway.HelloOP, "", gateway.HelloEvent{
+		HeartbeatInterval: discord.Milliseconds(c.HeartbeatInterval / time.Millisecond),
+	})
+}
+
+// Listen implements wsutil.Connection.
+func (c *Conn) Listen() <-chan wsutil.Event {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.events
+}
+
+// Send implements wsutil.Connection. The command is recorded, and replied to
+// like Discord would.
+func (c *Conn) Send(ctx context.Context, b []byte) error {
+	var op wsutil.OP
+	if err := json.Unmarshal(b, &op); err != nil {
+		return errors.Wrap(err, "gatewaytest: failed to decode OP")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	c.sent = append(c.sent, op)
+	close(c.notify)
+	c.notify = make(chan struct{})
+
+	if c.CloseCode != 0 && (op.Code == gateway.IdentifyOP || op.Code == gateway.ResumeOP) {
+		return c.closeWithCode(c.CloseCode)
+	}
+
+	switch op.Code {
+	case gateway.IdentifyOP:
+		var ready = c.Ready
+		if ready.SessionID == "" {
+			ready.SessionID = "gatewaytest"
+		}
+		return c.queue(gateway.DispatchOP, "READY", ready)
+
+	case gateway.ResumeOP:
+		return c.queue(gateway.DispatchOP, "RESUMED", struct{}{})
+
+	case gateway.HeartbeatOP:
+		return c.queue(gateway.HeartbeatAckOP, "", nil)
+	}
+
+	return nil
+}
+
+// Close implements wsutil.Connection.
+func (c *Conn) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.closed && c.events != nil {
+		c.closed = true
+		close(c.events)
+	}
+
+	return nil
+}
+
+// CloseWithCode closes the connection with the given close code, as if Discord
+// closed it.
+func (c *Conn) CloseWithCode(code int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.closeWithCode(code)
+}
+
+// closeWithCode must be called with the mutex acquired.
+func (c *Conn) closeWithCode(code int) error {
+	if c.closed || c.events == nil {
+		return ErrClosed
+	}
+
+	var err = &websocket.CloseError{Code: code}
+
+	select {
+	case c.events <- wsutil.Event{Error: errors.Wrap(err, "WS error")}:
+	default:
+		return errors.New("gatewaytest: event buffer is full")
+	}
+
+	c.closed = true
+	close(c.events)
+
+	return nil
+}
+
+// Dispatch sends the event with the given name, such as "MESSAGE_CREATE", to
+// the gateway. The data is marshaled into JSON.
+func (c *Conn) Dispatch(name string, data interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.queue(gateway.DispatchOP, name, data)
+}
+
+// SendOP sends an arbitrary OP to the gateway, such as a Reconnect or an
+// Invalid Session.
+func (c *Conn) SendOP(code wsutil.OPCode, data interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.queue(code, "", data)
+}
+
+// Sent returns all commands sent by the gateway, in order.
+func (c *Conn) Sent() []wsutil.OP {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]wsutil.OP(nil), c.sent...)
+}
+
+// WaitFor blocks until the gateway sends a command with the given OP code,
+// including ones sent before WaitFor was called, and returns it.
+func (c *Conn) WaitFor(ctx context.Context, code wsutil.OPCode) (*wsutil.OP, error) {
+	for i := 0; ; {
+		c.mutex.Lock()
+		sent, notify := c.sent, c.notify
+		c.mutex.Unlock()
+
+		for ; i < len(sent); i++ {
+			if sent[i].Code == code {
+				op := sent[i]
+				return &op, nil
+			}
+		}
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// queue must be called with the mutex acquired.
+func (c *Conn) queue(code wsutil.OPCode, name string, data interface{}) error {
+	if c.closed || c.events == nil {
+		return ErrClosed
+	}
+
+	var op = wsutil.OP{
+		Code:      code,
+		EventName: name,
+	}
+
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return errors.Wrap(err, "gatewaytest: failed to encode data")
+		}
+		op.Data = b
+	}
+
+	if code == gateway.DispatchOP {
+		c.seq++
+		op.Sequence = c.seq
+	}
+
+	b, err := json.Marshal(op)
+	if err != nil {
+		return errors.Wrap(err, "gatewaytest: failed to encode OP")
+	}
+
+	select {
+	case c.events <- wsutil.Event{Data: b}:
+		return nil
+	default:
+		return errors.New("gatewaytest: event buffer is full")
+	}
+}
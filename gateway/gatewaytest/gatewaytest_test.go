@@ -0,0 +1,103 @@
+package gatewaytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/session"
+	"github.com/diamondburned/arikawa/state"
+)
+
+func TestState(t *testing.T) {
+	conn := NewConn()
+	conn.Ready.User = discord.User{ID: 1, Username: "bot"}
+
+	s, err := state.NewFromSession(
+		session.NewWithGateway(NewGateway(conn, "Bot token")), state.NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+
+	ready := make(chan *gateway.ReadyEvent, 1)
+	s.AddHandler(func(r *gateway.ReadyEvent) { ready <- r })
+
+	msgs := make(chan *gateway.MessageCreateEvent, 1)
+	s.AddHandler(func(m *gateway.MessageCreateEvent) { msgs <- m })
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	op, err := conn.WaitFor(ctx, gateway.IdentifyOP)
+	if err != nil {
+		t.Fatal("Identify was not sent:", err)
+	}
+
+	var identify gateway.IdentifyData
+	if err := op.UnmarshalData(&identify); err != nil {
+		t.Fatal("Failed to decode Identify:", err)
+	}
+	if identify.Token != "Bot token" {
+		t.Fatalf("Unexpected token %q", identify.Token)
+	}
+
+	// The State handles events asynchronously, so the Ready event may not
+	// have been handled when Open returns.
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for READY")
+	}
+
+	if err := conn.Dispatch("MESSAGE_CREATE", discord.Message{
+		ID:        2,
+		ChannelID: 3,
+		Content:   "hi",
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	select {
+	case msg := <-msgs:
+		if msg.Content != "hi" {
+			t.Fatalf("Unexpected content %q", msg.Content)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for MESSAGE_CREATE")
+	}
+
+	me, err := s.Store.Me()
+	if err != nil {
+		t.Fatal("Failed to get myself:", err)
+	}
+	if me.ID != 1 {
+		t.Fatalf("Unexpected user ID %d", me.ID)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	conn := NewConn()
+	conn.HeartbeatInterval = 10 * time.Millisecond
+
+	g := NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := conn.WaitFor(ctx, gateway.HeartbeatOP); err != nil {
+		t.Fatal("Heartbeat was not sent:", err)
+	}
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -37,15 +38,19 @@ type IdentifyData struct {
 	Token      string             `json:"token"`
 	Properties IdentifyProperties `json:"properties"`
 
-	Compress           bool `json:"compress,omitempty"`        // true
-	LargeThreshold     uint `json:"large_threshold,omitempty"` // 50
-	GuildSubscriptions bool `json:"guild_subscriptions"`       // true
+	Compress       bool `json:"compress,omitempty"`        // true
+	LargeThreshold uint `json:"large_threshold,omitempty"` // 50
+
+	// Deprecated: Intents replace GuildSubscriptions since API v8.
+	GuildSubscriptions bool `json:"guild_subscriptions,omitempty"`
 
 	Shard *Shard `json:"shard,omitempty"` // [ shard_id, num_shards ]
 
 	Presence *UpdateStatusData `json:"presence,omitempty"`
 
-	Intents Intents `json:"intents,omitempty"`
+	// Intents are the events that the gateway sends. They are required since
+	// API v8; only READY and the events of these intents are sent.
+	Intents Intents `json:"intents"`
 }
 
 func (i *IdentifyData) SetShard(id, num int) {
@@ -75,13 +80,39 @@ const (
 	IntentDirectMessages
 	IntentDirectMessageReactions
 	IntentDirectMessageTyping
+	// IntentMessageContent is needed for the content, embeds, attachments and
+	// components of messages that don't mention the bot and aren't in DMs.
+	IntentMessageContent
+	IntentGuildScheduledEvents
 )
 
+const (
+	IntentAutoModerationConfiguration Intents = 1 << 20
+	IntentAutoModerationExecution     Intents = 1 << 21
+)
+
+// PrivilegedIntents are the intents that have to be enabled for the bot in the
+// developer portal. Identifying with them otherwise closes the gateway.
+const PrivilegedIntents = IntentGuildMembers | IntentGuildPresences | IntentMessageContent
+
+// DefaultIntents are the intents of DefaultIdentifier, which are all intents
+// that aren't privileged.
+const DefaultIntents = IntentGuilds | IntentGuildBans | IntentGuildEmojis |
+	IntentGuildIntegrations | IntentGuildWebhooks | IntentGuildInvites |
+	IntentGuildVoiceStates | IntentGuildMessages | IntentGuildMessageReactions |
+	IntentGuildMessageTyping | IntentDirectMessages | IntentDirectMessageReactions |
+	IntentDirectMessageTyping | IntentGuildScheduledEvents |
+	IntentAutoModerationConfiguration | IntentAutoModerationExecution
+
 type Identifier struct {
 	IdentifyData
 
 	IdentifyShortLimit  *rate.Limiter `json:"-"`
 	IdentifyGlobalLimit *rate.Limiter `json:"-"`
+
+	// Limiter, if not nil, is used instead of the short and global limits. It
+	// should be shared between all shards of the bot. Refer to IdentifyLimiter.
+	Limiter *IdentifyLimiter `json:"-"`
 }
 
 func DefaultIdentifier(token string) *Identifier {
@@ -91,9 +122,9 @@ func DefaultIdentifier(token string) *Identifier {
 		Shard:      DefaultShard(),
 		Presence:   Presence,
 
-		Compress:           true,
-		LargeThreshold:     50,
-		GuildSubscriptions: true,
+		Compress:       true,
+		LargeThreshold: 50,
+		Intents:        DefaultIntents,
 	})
 }
 
@@ -106,6 +137,14 @@ func NewIdentifier(data IdentifyData) *Identifier {
 }
 
 func (i *Identifier) Wait(ctx context.Context) error {
+	if i.Limiter != nil {
+		var shardID int
+		if i.Shard != nil {
+			shardID = i.Shard.ShardID()
+		}
+		return i.Limiter.Wait(ctx, shardID)
+	}
+
 	if err := i.IdentifyShortLimit.Wait(ctx); err != nil {
 		return errors.Wrap(err, "can't wait for short limit")
 	}
@@ -114,3 +153,95 @@ func (i *Identifier) Wait(ctx context.Context) error {
 	}
 	return nil
 }
+
+// ErrSessionStartLimit is returned by IdentifyLimiter when no more sessions can
+// be started until the limit resets.
+var ErrSessionStartLimit = errors.New("session start limit reached")
+
+// IdentifyLimiter limits identifies across all shards of a bot. Discord lets
+// MaxConcurrency shards identify every 5 seconds, where a shard's bucket is its
+// ID modulo MaxConcurrency, and only allows a number of sessions to be started
+// per day. Exceeding those gets the connection closed, and starting too many
+// sessions resets the bot's token.
+//
+// The limits are given by BotURL. All shards should share the same limiter:
+//
+//    bot, err := gateway.BotURL("Bot " + token)
+//    if err != nil {
+//        return err
+//    }
+//
+//    limiter := gateway.NewIdentifyLimiter(*bot.StartLimit)
+//
+//    for _, g := range shards {
+//        g.Identifier.Limiter = limiter
+//    }
+type IdentifyLimiter struct {
+	buckets []*rate.Limiter
+
+	mutex     sync.Mutex
+	total     int
+	remaining int
+	resetAt   time.Time
+}
+
+// NewIdentifyLimiter creates a limiter from the session start limit returned
+// by Discord.
+func NewIdentifyLimiter(limit SessionStartLimit) *IdentifyLimiter {
+	var concurrency = limit.MaxConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var buckets = make([]*rate.Limiter, concurrency)
+	for i := range buckets {
+		buckets[i] = rate.NewLimiter(rate.Every(5*time.Second), 1)
+	}
+
+	return &IdentifyLimiter{
+		buckets:   buckets,
+		total:     limit.Total,
+		remaining: limit.Remaining,
+		resetAt:   time.Now().Add(limit.ResetAfter.Duration()),
+	}
+}
+
+// Wait blocks until the shard with the given ID can identify. It returns
+// ErrSessionStartLimit without blocking if no sessions are left until the
+// limit resets.
+func (l *IdentifyLimiter) Wait(ctx context.Context, shardID int) error {
+	if err := l.take(); err != nil {
+		return err
+	}
+
+	var bucket = l.buckets[shardID%len(l.buckets)]
+
+	if err := bucket.Wait(ctx); err != nil {
+		// The session wasn't started, so give it back.
+		l.mutex.Lock()
+		l.remaining++
+		l.mutex.Unlock()
+
+		return errors.Wrap(err, "can't wait for identify bucket")
+	}
+
+	return nil
+}
+
+// take takes a session from the daily limit.
+func (l *IdentifyLimiter) take() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if now := time.Now(); !now.Before(l.resetAt) {
+		l.remaining = l.total
+		l.resetAt = now.Add(24 * time.Hour)
+	}
+
+	if l.remaining < 1 {
+		return errors.Wrapf(ErrSessionStartLimit, "resets at %v", l.resetAt)
+	}
+
+	l.remaining--
+	return nil
+}
@@ -0,0 +1,36 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+)
+
+func TestIdentifyLimiter(t *testing.T) {
+	limiter := gateway.NewIdentifyLimiter(gateway.SessionStartLimit{
+		Total:          2,
+		Remaining:      2,
+		ResetAfter:     discord.Milliseconds(time.Hour / time.Millisecond),
+		MaxConcurrency: 2,
+	})
+
+	// Shards 0 and 1 are in different buckets, so they don't wait.
+	for shard := 0; shard < 2; shard++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := limiter.Wait(ctx, shard)
+		cancel()
+
+		if err != nil {
+			t.Fatalf("Shard %d failed to identify: %v", shard, err)
+		}
+	}
+
+	if err := limiter.Wait(context.Background(), 2); errors.Cause(err) != gateway.ErrSessionStartLimit {
+		t.Fatal("Unexpected error after the session start limit:", err)
+	}
+}
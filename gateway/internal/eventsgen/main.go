@@ -0,0 +1,221 @@
+// Command eventsgen generates the event registry of the gateway package. It is
+// run by go generate inside the package directory:
+//
+//    go generate ./gateway
+//
+// Every type in the package whose name ends with "Event" is an event, and its
+// name in Discord's dispatches is derived from the type name, so that
+// VoiceStateUpdateEvent is VOICE_STATE_UPDATE. Adding an event is therefore
+// only a matter of declaring its type and running go generate again.
+//
+// Types that declare their own EventName method, such as UnknownEvent, aren't
+// registered, and neither are the events that are sent with their own opcodes
+// instead of being dispatched, such as HelloEvent.
+//
+// Each event also gets a handler interface, such as VoiceStateUpdateHandler,
+// which HandleEvent calls, and EventCaller lets the handler package call
+// handler functions of the events without reflection.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const output = "events_gen.go"
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by eventsgen. DO NOT EDIT.
+
+package gateway
+
+// EventCreator maps the names of dispatched events to functions that create
+// a new event to be decoded into.
+var EventCreator = map[string]func() Event{
+{{- range .}}
+	"{{.Name}}": func() Event { return new({{.Type}}) },
+{{- end}}
+}
+{{range .}}
+// EventName returns "{{.Name}}".
+func (*{{.Type}}) EventName() string { return "{{.Name}}" }
+{{end}}
+{{- range .}}
+// {{.Handler}} is implemented by types that handle {{.Type}}s.
+type {{.Handler}} interface {
+	{{.Method}}(*{{.Type}})
+}
+{{end}}
+// HandleEvent calls the method of h that handles the event, if h implements
+// the event's handler interface, such as MessageCreateHandler. It returns
+// false if h doesn't handle the event. It allows a single type to handle many
+// events:
+//
+//    s.AddHandler(func(ev gateway.Event) { gateway.HandleEvent(bot, ev) })
+func HandleEvent(h interface{}, ev Event) bool {
+	switch ev := ev.(type) {
+{{- range .}}
+	case *{{.Type}}:
+		if h, ok := h.({{.Handler}}); ok {
+			h.{{.Method}}(ev)
+			return true
+		}
+{{- end}}
+	}
+
+	return false
+}
+
+// EventCaller returns a function that calls fn, a handler function of one of
+// the events, without reflection, or nil if fn isn't such a function. It is a
+// handler.CallerFunc, which the session package registers.
+func EventCaller(fn interface{}) func(interface{}) {
+	switch fn := fn.(type) {
+{{- range .}}
+	case func(*{{.Type}}):
+		return func(ev interface{}) { fn(ev.(*{{.Type}})) }
+{{- end}}
+	}
+
+	return nil
+}
+`))
+
+type event struct {
+	Type string
+	Name string
+}
+
+// Handler returns the name of the event's handler interface.
+func (ev event) Handler() string {
+	return strings.TrimSuffix(ev.Type, "Event") + "Handler"
+}
+
+// Method returns the name of the method of the event's handler interface.
+func (ev event) Method() string {
+	return "Handle" + strings.TrimSuffix(ev.Type, "Event")
+}
+
+// opcodeEvents are the events that are sent with their own opcodes instead of
+// DispatchOP, so they don't have a name in dispatches.
+var opcodeEvents = map[string]bool{
+	"HelloEvent":          true,
+	"InvalidSessionEvent": true,
+}
+
+func main() {
+	var fset = token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, 0)
+	if err != nil {
+		log.Fatalln("Failed to parse package:", err)
+	}
+
+	pkg, ok := pkgs["gateway"]
+	if !ok {
+		log.Fatalln("Package gateway not found in the current directory")
+	}
+
+	var named = namedTypes(pkg)
+	var events []event
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.Assign.IsValid() || !isEvent(spec.Name.Name) ||
+					named[spec.Name.Name] || opcodeEvents[spec.Name.Name] {
+					continue
+				}
+
+				// Interfaces such as NamedEvent can't have methods.
+				if _, ok := spec.Type.(*ast.InterfaceType); ok {
+					continue
+				}
+
+				events = append(events, event{
+					Type: spec.Name.Name,
+					Name: eventName(spec.Name.Name),
+				})
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, events); err != nil {
+		log.Fatalln("Failed to render template:", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalln("Failed to format the generated code:", err)
+	}
+
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatalln("Failed to write "+output+":", err)
+	}
+}
+
+// namedTypes returns the types with a handwritten EventName method.
+func namedTypes(pkg *ast.Package) map[string]bool {
+	var named = map[string]bool{}
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "EventName" {
+				continue
+			}
+
+			var recv = fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				named[ident.Name] = true
+			}
+		}
+	}
+
+	return named
+}
+
+func isEvent(name string) bool {
+	return name != "Event" && strings.HasSuffix(name, "Event") && ast.IsExported(name)
+}
+
+// eventName converts a type name such as VoiceStateUpdateEvent to the event
+// name VOICE_STATE_UPDATE.
+func eventName(typ string) string {
+	var runes = []rune(strings.TrimSuffix(typ, "Event"))
+	var name strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			name.WriteByte('_')
+		}
+		name.WriteRune(unicode.ToUpper(r))
+	}
+
+	return name.String()
+}
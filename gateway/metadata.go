@@ -0,0 +1,37 @@
+package gateway
+
+import "time"
+
+// EventMetadata contains information about the connection that received an
+// event and when it was received.
+type EventMetadata struct {
+	// ShardID is the ID of the shard that received the event.
+	ShardID int
+	// Sequence is the sequence number of the event.
+	Sequence int64
+	// ReceivedAt is when the event was received and parsed by the Gateway.
+	ReceivedAt time.Time
+}
+
+// Latency returns the time elapsed since the event was received. It can be
+// called inside event handlers to measure the dispatch latency.
+func (m EventMetadata) Latency() time.Duration {
+	return time.Since(m.ReceivedAt)
+}
+
+// EventWithMetadata wraps an event with its metadata. It is sent into Events
+// instead of the plain event if the Gateway's SendMetadata is true.
+//
+// Sessions will call handlers with both the EventWithMetadata and the wrapped
+// event, so existing handlers keep working:
+//
+//    s.AddHandler(func(ev *gateway.EventWithMetadata) {
+//        if m, ok := ev.Event.(*gateway.MessageCreateEvent); ok {
+//            log.Println("shard", ev.ShardID, "received", m.ID)
+//        }
+//    })
+//
+type EventWithMetadata struct {
+	Event Event
+	EventMetadata
+}
@@ -3,9 +3,9 @@ package gateway
 import (
 	"fmt"
 	"math/rand"
+	"sync/atomic"
 	"time"
 
-	"github.com/diamondburned/arikawa/utils/json"
 	"github.com/diamondburned/arikawa/utils/wsutil"
 	"github.com/pkg/errors"
 )
@@ -34,6 +34,7 @@ func (g *Gateway) HandleOP(op *wsutil.OP) error {
 	case HeartbeatAckOP:
 		// Heartbeat from the server?
 		g.PacerLoop.Echo()
+		g.ackHeartbeat()
 
 	case HeartbeatOP:
 		// Server requesting a heartbeat.
@@ -70,21 +71,30 @@ func (g *Gateway) HandleOP(op *wsutil.OP) error {
 			g.Sequence.Set(op.Sequence)
 		}
 
-		// Check if we know the event
-		fn, ok := EventCreator[op.EventName]
-		if !ok {
-			return fmt.Errorf(
-				"Unknown event %s: %s",
-				op.EventName, string(op.Data),
-			)
-		}
+		atomic.AddUint64(&g.events, 1)
 
-		// Make a new pointer to the event
-		var ev = fn()
+		// Drop the event before decoding it if it's filtered out.
+		if !g.wantsEvent(op.EventName) {
+			return nil
+		}
 
-		// Try and parse the event
-		if err := json.Unmarshal(op.Data, ev); err != nil {
-			return errors.Wrap(err, "failed to parse event "+op.EventName)
+		// Check if we know the event. Events we don't know are sent as they
+		// are, for users that want to handle them.
+		var ev Event
+
+		if fn, ok := EventCreator[op.EventName]; ok {
+			// Make a new pointer to the event
+			ev = fn()
+
+			// Try and parse the event
+			if err := g.jsonDriver().Unmarshal(op.Data, ev); err != nil {
+				return errors.Wrap(err, "failed to parse event "+op.EventName)
+			}
+		} else {
+			ev = &UnknownEvent{
+				Type: op.EventName,
+				Raw:  op.Data,
+			}
 		}
 
 		// If the event is a ready, we'll want its sessionID
@@ -92,6 +102,19 @@ func (g *Gateway) HandleOP(op *wsutil.OP) error {
 			g.SessionID = ev.SessionID
 		}
 
+		// Wrap the event with its metadata if requested.
+		if g.SendMetadata {
+			g.Events <- &EventWithMetadata{
+				Event: ev,
+				EventMetadata: EventMetadata{
+					ShardID:    g.shardID(),
+					Sequence:   op.Sequence,
+					ReceivedAt: time.Now(),
+				},
+			}
+			return nil
+		}
+
 		// Throw the event into a channel, it's valid now.
 		g.Events <- ev
 		return nil
@@ -102,3 +125,29 @@ func (g *Gateway) HandleOP(op *wsutil.OP) error {
 
 	return nil
 }
+
+// wantsEvent returns true if the event passes the EventFilter.
+func (g *Gateway) wantsEvent(name string) bool {
+	switch {
+	case g.EventFilter == nil:
+		return true
+	case name == "READY", name == "RESUMED":
+		return true
+	default:
+		return g.EventFilter(name)
+	}
+}
+
+// IgnoreEvents returns an EventFilter that drops the events with the given
+// names.
+func IgnoreEvents(names ...string) func(name string) bool {
+	var ignored = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		ignored[name] = struct{}{}
+	}
+
+	return func(name string) bool {
+		_, ok := ignored[name]
+		return !ok
+	}
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ReconnectPolicy controls how the Gateway retries connecting after the
+// connection is lost. The first attempt is made right away. After it fails,
+// the delay before each attempt doubles from MinDelay up to MaxDelay, and
+// Jitter randomizes it so that shards that disconnected together don't
+// reconnect together.
+type ReconnectPolicy struct {
+	// MinDelay is the delay after the first failed attempt. No delay is waited
+	// for if it's 0, though the Websocket's DialLimiter still applies.
+	MinDelay time.Duration
+	// MaxDelay caps the delay. It's not capped if it's 0.
+	MaxDelay time.Duration
+	// Jitter is the fraction of the delay that's random, from 0 to 1. A Jitter
+	// of 0.5 gives delays between 50% and 100% of the backoff.
+	Jitter float64
+	// MaxAttempts is the number of failed attempts after which Reconnect gives
+	// up and returns ErrWSMaxTries. It retries forever if it's 0.
+	MaxAttempts int
+}
+
+// DefaultReconnectPolicy is the ReconnectPolicy of new Gateways.
+var DefaultReconnectPolicy = ReconnectPolicy{
+	MinDelay: time.Second,
+	MaxDelay: 2 * time.Minute,
+	Jitter:   0.5,
+}
+
+// Delay returns the delay to wait after the given number of failed attempts.
+func (p ReconnectPolicy) Delay(failures int) time.Duration {
+	if p.MinDelay <= 0 || failures < 1 {
+		return 0
+	}
+
+	var delay = p.MinDelay
+	for i := 1; i < failures; i++ {
+		// Saturate instead of overflowing if the delay isn't capped.
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+
+		delay *= 2
+
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+
+	if p.Jitter > 0 {
+		var jitter = p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	return delay
+}
@@ -0,0 +1,150 @@
+package gateway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+	"github.com/diamondburned/arikawa/utils/wsutil"
+)
+
+func TestReconnectCallbacks(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	var connected = make(chan struct{}, 1)
+	var resumed = make(chan struct{}, 1)
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+	g.OnConnect = func() { connected <- struct{}{} }
+	g.OnResume = func() { resumed <- struct{}{} }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	select {
+	case <-connected:
+	case <-ctx.Done():
+		t.Fatal("OnConnect was not called")
+	}
+
+	// Discord asks us to reconnect, which should resume the session.
+	if err := conn.SendOP(gateway.ReconnectOP, nil); err != nil {
+		t.Fatal("Failed to send Reconnect:", err)
+	}
+
+	select {
+	case <-resumed:
+	case <-ctx.Done():
+		t.Fatal("OnResume was not called")
+	}
+}
+
+func TestReconnectPolicyDelay(t *testing.T) {
+	policy := gateway.ReconnectPolicy{
+		MinDelay: time.Second,
+		MaxDelay: 5 * time.Second,
+	}
+
+	var expect = []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+
+	for failures, delay := range expect {
+		if d := policy.Delay(failures); d != delay {
+			t.Fatalf("Delay after %d failures is %v, expected %v", failures, d, delay)
+		}
+	}
+
+	policy.Jitter = 0.5
+
+	for i := 0; i < 100; i++ {
+		if d := policy.Delay(2); d < time.Second || d > 2*time.Second {
+			t.Fatal("Jittered delay is out of range:", d)
+		}
+	}
+}
+
+func TestReconnectPolicyDelayUncapped(t *testing.T) {
+	policy := gateway.ReconnectPolicy{MinDelay: time.Second}
+
+	var last time.Duration
+	for failures := 1; failures < 100; failures++ {
+		d := policy.Delay(failures)
+		if d < last {
+			t.Fatalf("Delay after %d failures is %v, shorter than %v", failures, d, last)
+		}
+		last = d
+	}
+}
+
+func TestReconnectFatalCloseCode(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	conn.CloseCode = 4014
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+	g.ReconnectPolicy.MaxAttempts = 3
+
+	err := g.Reconnect()
+	if err == nil {
+		t.Fatal("Unexpected success reconnecting with disallowed intents")
+	}
+	if code := wsutil.CloseCode(err); code != 4014 {
+		t.Fatal("Unexpected close code:", code, err)
+	}
+
+	var identifies int
+	for _, op := range conn.Sent() {
+		if op.Code == gateway.IdentifyOP {
+			identifies++
+		}
+	}
+
+	if identifies != 1 {
+		t.Fatalf("Identified %d times, expected once", identifies)
+	}
+}
+
+func TestFatalCloseCodeDisconnect(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	var disconnected = make(chan int, 1)
+
+	g := gatewaytest.NewGateway(conn, "Bot token")
+	g.ErrorLog = func(err error) { t.Log("Gateway error:", err) }
+	g.OnDisconnect = func(code int) { disconnected <- code }
+	g.OnResume = func() { t.Error("Resumed after a fatal close code") }
+
+	if err := g.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer g.Close()
+
+	if err := conn.CloseWithCode(4004); err != nil {
+		t.Fatal("Failed to close:", err)
+	}
+
+	select {
+	case code := <-disconnected:
+		if code != 4004 {
+			t.Fatal("Unexpected close code:", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnDisconnect was not called")
+	}
+
+	// Give the event loop the chance to wrongly reconnect.
+	time.Sleep(50 * time.Millisecond)
+
+	for _, op := range conn.Sent() {
+		if op.Code == gateway.ResumeOP {
+			t.Fatal("Resume was sent after a fatal close code")
+		}
+	}
+}
@@ -14,3 +14,11 @@ func (s Shard) ShardID() int {
 func (s Shard) NumShards() int {
 	return s[1]
 }
+
+// shardID returns the ID of the shard that the Gateway identifies as.
+func (g *Gateway) shardID() int {
+	if g.Identifier.Shard == nil {
+		return 0
+	}
+	return g.Identifier.Shard.ShardID()
+}
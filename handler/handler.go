@@ -3,11 +3,14 @@
 //
 // Performance
 //
-// Each call to the event would take 156 ns/op for roughly each handler. Scaling
-// that up to 100 handlers is multiplying 156 ns by 100, which gives 15600 ns,
-// or 0.0156 ms.
+// Handlers of Gateway events are called without reflection, through the
+// callers registered with RegisterCaller, which takes about 7 ns/op for each
+// handler. Other handlers are called with reflection, which takes 156 ns/op.
+// Scaling that up to 100 handlers is multiplying 156 ns by 100, which gives
+// 15600 ns, or 0.0156 ms.
 //
-//    BenchmarkReflect-8  7260909  156 ns/op
+//    BenchmarkReflect-8  7260909    156 ns/op
+//    BenchmarkCaller-8   173063388  6.9 ns/op
 //
 // Usage
 //
@@ -41,6 +44,12 @@ type Handler struct {
 	horders  []uint64
 	hserial  uint64
 	hmutex   sync.RWMutex
+
+	// inflight counts the asynchronous handlers that are running. idle is
+	// closed when it drops to 0, and is nil if nothing waits for it.
+	inflight int
+	idle     chan struct{}
+	imutex   sync.Mutex
 }
 
 func New() *Handler {
@@ -50,8 +59,7 @@ func New() *Handler {
 }
 
 func (h *Handler) Call(ev interface{}) {
-	var evV = reflect.ValueOf(ev)
-	var evT = evV.Type()
+	var evT = reflect.TypeOf(ev)
 
 	h.hmutex.RLock()
 	defer h.hmutex.RUnlock()
@@ -67,23 +75,70 @@ func (h *Handler) Call(ev interface{}) {
 			continue
 		}
 
-		if h.Synchronous {
-			handler.call(evV)
+		if h.Synchronous || handler.sync {
+			handler.call(ev)
 		} else {
-			go handler.call(evV)
+			h.imutex.Lock()
+			h.inflight++
+			h.imutex.Unlock()
+
+			go func(call func(interface{})) {
+				defer h.done()
+				call(ev)
+			}(handler.call)
 		}
 	}
 }
 
+// Wait blocks until all handlers that were started asynchronously by Call have
+// returned, or until the context expires, in which case its error is returned.
+// Handlers started while waiting are also waited for.
+func (h *Handler) Wait(ctx context.Context) error {
+	h.imutex.Lock()
+	if h.inflight == 0 {
+		h.imutex.Unlock()
+		return nil
+	}
+	if h.idle == nil {
+		h.idle = make(chan struct{})
+	}
+	var idle = h.idle
+	h.imutex.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// done marks an asynchronous handler as returned.
+func (h *Handler) done() {
+	h.imutex.Lock()
+	defer h.imutex.Unlock()
+
+	h.inflight--
+	if h.inflight == 0 && h.idle != nil {
+		close(h.idle)
+		h.idle = nil
+	}
+}
+
 // WaitFor blocks until there's an event. It's advised to use ChanFor instead,
 // as WaitFor may skip some events if it's not ran fast enough after the event
-// arrived.
+// arrived. The first event matching fn is returned, or nil if the context
+// expired first.
 func (h *Handler) WaitFor(ctx context.Context, fn func(interface{}) bool) interface{} {
-	var result = make(chan interface{})
+	// Buffered, so handlers that match after WaitFor has returned don't hang.
+	var result = make(chan interface{}, 1)
 
 	cancel := h.AddHandler(func(v interface{}) {
 		if fn(v) {
-			result <- v
+			select {
+			case result <- v:
+			default:
+			}
 		}
 	})
 	defer cancel()
@@ -135,6 +190,19 @@ func (h *Handler) AddHandler(handler interface{}) (rm func()) {
 	return rm
 }
 
+// AddSyncHandler adds the handler like AddHandler, except the handler will
+// always be called synchronously in the same goroutine as Call, regardless of
+// Synchronous. As such, this handler should not block.
+func (h *Handler) AddSyncHandler(handler interface{}) (rm func()) {
+	r, err := reflectFn(handler)
+	if err != nil {
+		panic(err)
+	}
+	r.sync = true
+
+	return h.register(r)
+}
+
 // AddHandlerCheck adds the handler, but safe-guards reflect panics with a
 // recoverer, returning the error.
 func (h *Handler) AddHandlerCheck(handler interface{}) (rm func(), err error) {
@@ -160,6 +228,10 @@ func (h *Handler) addHandler(fn interface{}) (rm func(), err error) {
 		return nil, errors.Wrap(err, "handler reflect failed")
 	}
 
+	return h.register(r), nil
+}
+
+func (h *Handler) register(r *handler) (rm func()) {
 	h.hmutex.Lock()
 	defer h.hmutex.Unlock()
 
@@ -192,13 +264,56 @@ func (h *Handler) addHandler(fn interface{}) (rm func(), err error) {
 				break
 			}
 		}
-	}, nil
+	}
+}
+
+// CallerFunc returns a function that calls the given handler function with an
+// event without reflection, or nil if it doesn't know the function's type.
+type CallerFunc func(fn interface{}) func(interface{})
+
+var (
+	callerFuncs []CallerFunc
+	callerMutex sync.RWMutex
+)
+
+// RegisterCaller adds a CallerFunc that is used when handlers are added, so
+// that they're called without reflection on every event. The session package
+// registers gateway.EventCaller for all Gateway events. Handlers of other
+// types are still called with reflection.
+func RegisterCaller(fn CallerFunc) {
+	callerMutex.Lock()
+	callerFuncs = append(callerFuncs, fn)
+	callerMutex.Unlock()
+}
+
+// findCaller returns the function that calls fn without reflection, or nil if
+// there's none.
+func findCaller(fn interface{}) func(interface{}) {
+	if fn, ok := fn.(func(interface{})); ok {
+		return fn
+	}
+
+	callerMutex.RLock()
+	defer callerMutex.RUnlock()
+
+	for _, callerFn := range callerFuncs {
+		if caller := callerFn(fn); caller != nil {
+			return caller
+		}
+	}
+
+	return nil
 }
 
 type handler struct {
 	event    reflect.Type
 	callback reflect.Value
 	isIface  bool
+	sync     bool
+
+	// caller calls the callback without reflection. It is nil if no
+	// CallerFunc knows the callback's type.
+	caller func(interface{})
 }
 
 func reflectFn(function interface{}) (*handler, error) {
@@ -229,6 +344,7 @@ func reflectFn(function interface{}) (*handler, error) {
 		event:    argT,
 		callback: fnV,
 		isIface:  kind == reflect.Interface,
+		caller:   findCaller(function),
 	}, nil
 }
 
@@ -240,6 +356,11 @@ func (h handler) not(event reflect.Type) bool {
 	return h.event != event
 }
 
-func (h handler) call(event reflect.Value) {
-	h.callback.Call([]reflect.Value{event})
+func (h handler) call(event interface{}) {
+	if h.caller != nil {
+		h.caller(event)
+		return
+	}
+
+	h.callback.Call([]reflect.Value{reflect.ValueOf(event)})
 }
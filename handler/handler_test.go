@@ -11,6 +11,10 @@ import (
 	"github.com/diamondburned/arikawa/gateway"
 )
 
+func init() {
+	RegisterCaller(gateway.EventCaller)
+}
+
 func newMessage(content string) *gateway.MessageCreateEvent {
 	return &gateway.MessageCreateEvent{
 		Message: discord.Message{Content: content},
@@ -73,20 +77,33 @@ func TestHandler(t *testing.T) {
 	const result = "Hime Arikawa"
 	var msg = newMessage(result)
 
-	var msgV = reflect.ValueOf(msg)
-	var msgT = msgV.Type()
-
-	if h.not(msgT) {
+	if h.not(reflect.TypeOf(msg)) {
 		t.Fatal("Event type mismatch")
 	}
 
-	go h.call(msgV)
+	go h.call(msg)
 
 	if results := <-results; results != result {
 		t.Fatal("Unexpected results:", results)
 	}
 }
 
+func TestSyncHandler(t *testing.T) {
+	var h = New()
+	var called bool
+
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		called = true
+	})
+
+	// Call should not return until the handler is done.
+	h.Call(newMessage("hime arikawa"))
+
+	if !called {
+		t.Fatal("Sync handler was not called synchronously")
+	}
+}
+
 func TestHandlerInterface(t *testing.T) {
 	var results = make(chan interface{})
 
@@ -100,14 +117,11 @@ func TestHandlerInterface(t *testing.T) {
 	const result = "Hime Arikawa"
 	var msg = newMessage(result)
 
-	var msgV = reflect.ValueOf(msg)
-	var msgT = msgV.Type()
-
-	if h.not(msgT) {
+	if h.not(reflect.TypeOf(msg)) {
 		t.Fatal("Event type mismatch")
 	}
 
-	go h.call(msgV)
+	go h.call(msg)
 	recv := <-results
 
 	if msg, ok := recv.(*gateway.MessageCreateEvent); ok {
@@ -173,6 +187,38 @@ func TestHandlerWait(t *testing.T) {
 	}
 }
 
+func TestWaitInflight(t *testing.T) {
+	var done = make(chan struct{})
+	var release = make(chan struct{})
+
+	h := New()
+	h.AddHandler(func(m *gateway.MessageCreateEvent) {
+		<-release
+		close(done)
+	})
+
+	h.Call(newMessage("hime arikawa"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	if err := h.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatal("Wait returned before the handler did:", err)
+	}
+
+	close(release)
+
+	if err := h.Wait(context.Background()); err != nil {
+		t.Fatal("Failed to wait:", err)
+	}
+
+	select {
+	case <-done:
+	default:
+		t.Fatal("Wait returned before the handler did")
+	}
+}
+
 func TestHandlerChan(t *testing.T) {
 	h := New()
 
@@ -207,24 +253,72 @@ func TestHandlerChan(t *testing.T) {
 	}
 }
 
+func TestHandlerCaller(t *testing.T) {
+	h, err := reflectFn(func(m *gateway.MessageCreateEvent) {})
+	if err != nil {
+		t.Fatal("Failed to reflect handler:", err)
+	}
+	if h.caller == nil {
+		t.Fatal("Gateway event handler has no caller")
+	}
+
+	type customEvent struct{}
+
+	var called bool
+
+	h, err = reflectFn(func(*customEvent) { called = true })
+	if err != nil {
+		t.Fatal("Failed to reflect handler:", err)
+	}
+	if h.caller != nil {
+		t.Fatal("Unknown event handler has a caller")
+	}
+
+	h.call(&customEvent{})
+
+	if !called {
+		t.Fatal("Unknown event handler was not called with reflection")
+	}
+}
+
 func BenchmarkReflect(b *testing.B) {
 	h, err := reflectFn(func(m *gateway.MessageCreateEvent) {})
 	if err != nil {
 		b.Fatal(err)
 	}
 
+	// Force the reflection path, which is used for events that no CallerFunc
+	// knows.
+	h.caller = nil
+
 	var msg = &gateway.MessageCreateEvent{}
 
 	b.ResetTimer()
 
 	for n := 0; n < b.N; n++ {
-		var msgV = reflect.ValueOf(msg)
-		var msgT = msgV.Type()
+		if h.not(reflect.TypeOf(msg)) {
+			b.Fatal("Event type mismatch")
+		}
+
+		h.call(msg)
+	}
+}
+
+func BenchmarkCaller(b *testing.B) {
+	h, err := reflectFn(func(m *gateway.MessageCreateEvent) {})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var msg = &gateway.MessageCreateEvent{}
 
-		if h.not(msgT) {
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if h.not(reflect.TypeOf(msg)) {
 			b.Fatal("Event type mismatch")
 		}
 
-		h.call(msgV)
+		h.call(msg)
 	}
 }
@@ -4,7 +4,11 @@
 package session
 
 import (
+	"context"
+	"sync"
+
 	"github.com/diamondburned/arikawa/api"
+	"github.com/diamondburned/arikawa/discord"
 	"github.com/diamondburned/arikawa/gateway"
 	"github.com/diamondburned/arikawa/handler"
 	"github.com/pkg/errors"
@@ -24,6 +28,11 @@ type Closed struct {
 
 var ErrMFA = errors.New("account has 2FA enabled")
 
+func init() {
+	// Call the handlers of Gateway events without reflection.
+	handler.RegisterCaller(gateway.EventCaller)
+}
+
 // Session manages both the API and Gateway. As such, Session inherits all of
 // API's methods, as well has the Handler used for Gateway.
 type Session struct {
@@ -38,6 +47,14 @@ type Session struct {
 	Ticket string
 
 	hstop chan struct{}
+	hdone chan struct{}
+
+	closeHooks []func(context.Context) error
+	hookMutex  sync.Mutex
+
+	closeCtx    context.Context
+	closeCancel context.CancelFunc
+	closeMutex  sync.Mutex
 }
 
 func New(token string) (*Session, error) {
@@ -98,8 +115,10 @@ func NewWithGateway(gw *gateway.Gateway) *Session {
 func (s *Session) Open() error {
 	// Start the handler beforehand so no events are missed.
 	stop := make(chan struct{})
+	done := make(chan struct{})
 	s.hstop = stop
-	go s.startHandler(stop)
+	s.hdone = done
+	go s.startHandler(stop, done)
 
 	// Set the AfterClose's handler.
 	s.Gateway.AfterClose = func(err error) {
@@ -115,27 +134,146 @@ func (s *Session) Open() error {
 	return nil
 }
 
-func (s *Session) startHandler(stop <-chan struct{}) {
+// UpdateStatus sends a Presence Update command to the Gateway. Refer to
+// (*gateway.Gateway).UpdateStatus.
+func (s *Session) UpdateStatus(data gateway.UpdateStatusData) error {
+	return s.Gateway.UpdateStatus(data)
+}
+
+// UpdateVoiceState sends a Voice State Update command to the Gateway. Refer to
+// (*gateway.Gateway).UpdateVoiceState.
+func (s *Session) UpdateVoiceState(data gateway.UpdateVoiceStateData) error {
+	return s.Gateway.UpdateVoiceState(data)
+}
+
+// SetPresence sets the current user's activity while keeping them online.
+// Activities are usually created with helpers such as gateway.Game():
+//
+//    s.SetPresence(gateway.Game("with arikawa"))
+//
+func (s *Session) SetPresence(activity discord.Activity) error {
+	return s.SetStatus(discord.OnlineStatus, activity)
+}
+
+// SetStatus sets the current user's status and activities. Bots may only
+// have one activity, and no activities clears it:
+//
+//    s.SetStatus(discord.DoNotDisturbStatus, gateway.Watching("the logs"))
+//
+func (s *Session) SetStatus(status discord.Status, activities ...discord.Activity) error {
+	return s.UpdateStatus(gateway.UpdateStatusData{
+		Activities: &activities,
+		Status:     status,
+	})
+}
+
+func (s *Session) startHandler(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
 	for {
 		select {
 		case <-stop:
 			return
 		case ev := <-s.Gateway.Events:
 			s.Handler.Call(ev)
+
+			// Also call handlers with the plain event, so handlers that don't
+			// care about the metadata still work.
+			if m, ok := ev.(*gateway.EventWithMetadata); ok {
+				s.Handler.Call(m.Event)
+			}
 		}
 	}
 }
 
+// CloseContext returns a context that is canceled when the Session is closed,
+// either with Close or once the handlers have returned in CloseGracefully.
+// Long-running handlers can use it to notice a shutdown. Once canceled, a new
+// context is returned for the next close.
+func (s *Session) CloseContext() context.Context {
+	s.closeMutex.Lock()
+	defer s.closeMutex.Unlock()
+
+	if s.closeCtx == nil {
+		s.closeCtx, s.closeCancel = context.WithCancel(context.Background())
+	}
+	return s.closeCtx
+}
+
+func (s *Session) cancelCloseContext() {
+	s.closeMutex.Lock()
+	defer s.closeMutex.Unlock()
+
+	if s.closeCancel != nil {
+		s.closeCancel()
+		s.closeCtx, s.closeCancel = nil, nil
+	}
+}
+
 func (s *Session) Close() error {
 	// Stop the event handler
 	s.close()
+	s.cancelCloseContext()
 
 	// Close the websocket
 	return s.Gateway.Close()
 }
 
+// AddCloseHook adds a function that CloseGracefully calls after the event
+// handlers have returned, but before the Gateway is closed, so it can still send
+// commands. The voice package uses it to leave voice channels.
+func (s *Session) AddCloseHook(hook func(ctx context.Context) error) {
+	s.hookMutex.Lock()
+	s.closeHooks = append(s.closeHooks, hook)
+	s.hookMutex.Unlock()
+}
+
+// CloseGracefully closes the Session without dropping work. It stops taking
+// new events, waits for the running handlers to return, calls the hooks added
+// with AddCloseHook, and then closes the Gateway in a way that keeps the
+// session resumable, so that events sent until the next Open aren't lost.
+//
+// If the context expires first, the Gateway is closed anyway, and the
+// context's error is returned.
+func (s *Session) CloseGracefully(ctx context.Context) error {
+	var err = s.drain(ctx)
+	s.cancelCloseContext()
+
+	s.hookMutex.Lock()
+	var hooks = s.closeHooks
+	s.hookMutex.Unlock()
+
+	for _, hook := range hooks {
+		if hookErr := hook(ctx); hookErr != nil && err == nil {
+			err = errors.Wrap(hookErr, "close hook failed")
+		}
+	}
+
+	if closeErr := s.Gateway.CloseResumable(); closeErr != nil && err == nil {
+		err = errors.Wrap(closeErr, "failed to close gateway")
+	}
+
+	return err
+}
+
+// drain stops the event loop and waits for the handlers to return.
+func (s *Session) drain(ctx context.Context) error {
+	if s.hstop != nil {
+		s.close()
+
+		select {
+		case <-s.hdone:
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "failed to stop the event loop")
+		}
+	}
+
+	return errors.Wrap(s.Handler.Wait(ctx), "failed to wait for handlers")
+}
+
 func (s *Session) close() {
 	if s.hstop != nil {
 		close(s.hstop)
+		s.hstop = nil
 	}
 }
@@ -0,0 +1,87 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+	"github.com/diamondburned/arikawa/session"
+)
+
+func TestCloseGracefully(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	s := session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token"))
+
+	var started = make(chan struct{})
+	var finished bool
+
+	s.AddHandler(func(m *gateway.MessageCreateEvent) {
+		close(started)
+		time.Sleep(10 * time.Millisecond)
+		finished = true
+	})
+
+	var hooked bool
+	s.AddCloseHook(func(context.Context) error {
+		hooked = true
+		return nil
+	})
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+
+	if err := conn.Dispatch("MESSAGE_CREATE", discord.Message{ID: 1}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	select {
+	case <-started:
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for MESSAGE_CREATE")
+	}
+
+	if err := s.CloseGracefully(ctx); err != nil {
+		t.Fatal("Failed to close:", err)
+	}
+
+	if !finished || !hooked {
+		t.Fatal("CloseGracefully returned before the handler or hook ran")
+	}
+	if s.Gateway.SessionID == "" {
+		t.Fatal("Session was not kept for resuming")
+	}
+}
+
+func TestCloseContext(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	s := session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token"))
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+
+	ctx := s.CloseContext()
+	if err := ctx.Err(); err != nil {
+		t.Fatal("Context canceled before closing:", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal("Failed to close:", err)
+	}
+
+	if err := ctx.Err(); err != context.Canceled {
+		t.Fatal("Context not canceled after closing:", err)
+	}
+	if err := s.CloseContext().Err(); err != nil {
+		t.Fatal("Context of the next close is already canceled:", err)
+	}
+}
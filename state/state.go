@@ -27,6 +27,12 @@ type State struct {
 	// Ready is not updated by the state.
 	Ready gateway.ReadyEvent
 
+	// SplitBulkDeletes, if true, makes the State also dispatch a
+	// MessageDeleteEvent of both the Gateway and the State for each message of
+	// a MessageDeleteBulkEvent, after the bulk event itself. This lets
+	// handlers of single deletions see bulk deletions too.
+	SplitBulkDeletes bool
+
 	// StateLog logs all errors that come from the state cache. This includes
 	// not found errors. Defaults to a no-op, as state errors aren't that
 	// important.
@@ -44,9 +50,15 @@ type State struct {
 
 	unhooker func()
 
+	// roles keeps track of members per role for RoleMemberCount.
+	roles *roleIndex
+
+	// availability keeps track of unavailable guilds for GuildIsAvailable.
+	availability *guildAvailability
+
 	// List of channels with few messages, so it doesn't bother hitting the API
 	// again.
-	fewMessages map[discord.Snowflake]struct{}
+	fewMessages map[discord.ChannelID]struct{}
 	fewMutex    *sync.Mutex
 }
 
@@ -69,8 +81,11 @@ func NewFromSession(s *session.Session, store Store) (*State, error) {
 		Store:       store,
 		Handler:     handler.New(),
 		StateLog:    func(err error) {},
-		fewMessages: map[discord.Snowflake]struct{}{},
+		fewMessages: map[discord.ChannelID]struct{}{},
 		fewMutex:    new(sync.Mutex),
+		roles:       newRoleIndex(),
+
+		availability: newGuildAvailability(),
 	}
 
 	return state, state.hookSession()
@@ -108,7 +123,7 @@ func (s *State) AuthorDisplayName(message *gateway.MessageCreateEvent) string {
 	return n
 }
 
-func (s *State) MemberDisplayName(guildID, userID discord.Snowflake) (string, error) {
+func (s *State) MemberDisplayName(guildID discord.GuildID, userID discord.UserID) (string, error) {
 	member, err := s.Member(guildID, userID)
 	if err != nil {
 		return "", err
@@ -137,7 +152,7 @@ func (s *State) AuthorColor(message *gateway.MessageCreateEvent) (discord.Color,
 	return s.MemberColor(message.GuildID, message.Author.ID)
 }
 
-func (s *State) MemberColor(guildID, userID discord.Snowflake) (discord.Color, error) {
+func (s *State) MemberColor(guildID discord.GuildID, userID discord.UserID) (discord.Color, error) {
 	var wg sync.WaitGroup
 
 	g, gerr := s.Store.Guild(guildID)
@@ -160,7 +175,7 @@ func (s *State) MemberColor(guildID, userID discord.Snowflake) (discord.Color, e
 	wg.Wait()
 
 	if gerr != nil {
-		return 0, errors.Wrap(merr, "failed to get guild")
+		return 0, errors.Wrap(gerr, "failed to get guild")
 	}
 
 	return discord.MemberColor(*g, *m), nil
@@ -168,7 +183,10 @@ func (s *State) MemberColor(guildID, userID discord.Snowflake) (discord.Color, e
 
 ////
 
-func (s *State) Permissions(channelID, userID discord.Snowflake) (discord.Permissions, error) {
+// Permissions returns the effective permissions of the user in the guild
+// channel, calculated with discord.CalcOverwrites. The channel, guild and
+// member are taken from the store if possible, and fetched otherwise.
+func (s *State) Permissions(channelID discord.ChannelID, userID discord.UserID) (discord.Permissions, error) {
 	ch, err := s.Channel(channelID)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get channel")
@@ -196,7 +214,7 @@ func (s *State) Permissions(channelID, userID discord.Snowflake) (discord.Permis
 	wg.Wait()
 
 	if gerr != nil {
-		return 0, errors.Wrap(merr, "failed to get guild")
+		return 0, errors.Wrap(gerr, "failed to get guild")
 	}
 
 	return discord.CalcOverwrites(*g, *ch, *m), nil
@@ -220,7 +238,7 @@ func (s *State) Me() (*discord.User, error) {
 
 ////
 
-func (s *State) Channel(id discord.Snowflake) (*discord.Channel, error) {
+func (s *State) Channel(id discord.ChannelID) (*discord.Channel, error) {
 	c, err := s.Store.Channel(id)
 	if err == nil {
 		return c, nil
@@ -234,7 +252,7 @@ func (s *State) Channel(id discord.Snowflake) (*discord.Channel, error) {
 	return c, s.Store.ChannelSet(c)
 }
 
-func (s *State) Channels(guildID discord.Snowflake) ([]discord.Channel, error) {
+func (s *State) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
 	c, err := s.Store.Channels(guildID)
 	if err == nil {
 		return c, nil
@@ -256,7 +274,7 @@ func (s *State) Channels(guildID discord.Snowflake) ([]discord.Channel, error) {
 	return c, nil
 }
 
-func (s *State) CreatePrivateChannel(recipient discord.Snowflake) (*discord.Channel, error) {
+func (s *State) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel, error) {
 	c, err := s.Store.CreatePrivateChannel(recipient)
 	if err == nil {
 		return c, nil
@@ -295,7 +313,7 @@ func (s *State) PrivateChannels() ([]discord.Channel, error) {
 ////
 
 func (s *State) Emoji(
-	guildID, emojiID discord.Snowflake) (*discord.Emoji, error) {
+	guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
 
 	e, err := s.Store.Emoji(guildID, emojiID)
 	if err == nil {
@@ -320,7 +338,7 @@ func (s *State) Emoji(
 	return nil, ErrStoreNotFound
 }
 
-func (s *State) Emojis(guildID discord.Snowflake) ([]discord.Emoji, error) {
+func (s *State) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
 	e, err := s.Store.Emojis(guildID)
 	if err == nil {
 		return e, nil
@@ -336,7 +354,7 @@ func (s *State) Emojis(guildID discord.Snowflake) ([]discord.Emoji, error) {
 
 ////
 
-func (s *State) Guild(id discord.Snowflake) (*discord.Guild, error) {
+func (s *State) Guild(id discord.GuildID) (*discord.Guild, error) {
 	c, err := s.Store.Guild(id)
 	if err == nil {
 		return c, nil
@@ -376,7 +394,7 @@ func (s *State) Guilds() ([]discord.Guild, error) {
 ////
 
 func (s *State) Member(
-	guildID, userID discord.Snowflake) (*discord.Member, error) {
+	guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 
 	m, err := s.Store.Member(guildID, userID)
 	if err == nil {
@@ -391,7 +409,7 @@ func (s *State) Member(
 	return m, s.Store.MemberSet(guildID, m)
 }
 
-func (s *State) Members(guildID discord.Snowflake) ([]discord.Member, error) {
+func (s *State) Members(guildID discord.GuildID) ([]discord.Member, error) {
 	ms, err := s.Store.Members(guildID)
 	if err == nil {
 		return ms, nil
@@ -409,7 +427,7 @@ func (s *State) Members(guildID discord.Snowflake) ([]discord.Member, error) {
 	}
 
 	return ms, s.Gateway.RequestGuildMembers(gateway.RequestGuildMembersData{
-		GuildID:   []discord.Snowflake{guildID},
+		GuildID:   []discord.GuildID{guildID},
 		Presences: true,
 	})
 }
@@ -417,7 +435,7 @@ func (s *State) Members(guildID discord.Snowflake) ([]discord.Member, error) {
 ////
 
 func (s *State) Message(
-	channelID, messageID discord.Snowflake) (*discord.Message, error) {
+	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 
 	m, err := s.Store.Message(channelID, messageID)
 	if err == nil {
@@ -441,10 +459,15 @@ func (s *State) Message(
 
 // Messages fetches maximum 100 messages from the API, if it has to. There is no
 // limit if it's from the State storage.
-func (s *State) Messages(channelID discord.Snowflake) ([]discord.Message, error) {
+func (s *State) Messages(channelID discord.ChannelID) ([]discord.Message, error) {
 	// TODO: Think of a design that doesn't rely on MaxMessages().
 	var maxMsgs = s.MaxMessages()
 
+	// Message caching is disabled, so always fetch from the API.
+	if maxMsgs == 0 {
+		return s.Session.Messages(channelID, 100)
+	}
+
 	ms, err := s.Store.Messages(channelID)
 	if err == nil {
 		// If the state already has as many messages as it can, skip the API.
@@ -470,7 +493,7 @@ func (s *State) Messages(channelID discord.Snowflake) ([]discord.Message, error)
 
 	// New messages fetched weirdly does not have GuildID filled. We'll try and
 	// get it for consistency with incoming message creates.
-	var guildID discord.Snowflake
+	var guildID discord.GuildID
 
 	// A bit too convoluted, but whatever.
 	c, err := s.Channel(channelID)
@@ -508,7 +531,7 @@ func (s *State) Messages(channelID discord.Snowflake) ([]discord.Message, error)
 
 // Presence checks the state for user presences. If no guildID is given, it will
 // look for the presence in all guilds.
-func (s *State) Presence(guildID, userID discord.Snowflake) (*discord.Presence, error) {
+func (s *State) Presence(guildID discord.GuildID, userID discord.UserID) (*discord.Presence, error) {
 	p, err := s.Store.Presence(guildID, userID)
 	if err == nil {
 		return p, nil
@@ -533,8 +556,10 @@ func (s *State) Presence(guildID, userID discord.Snowflake) (*discord.Presence,
 
 ////
 
-func (s *State) Role(guildID, roleID discord.Snowflake) (*discord.Role, error) {
-
+// Role returns the role from the state, or fetches all of the guild's roles
+// from the API if it isn't cached. ErrStoreNotFound is returned if the guild
+// doesn't have the role.
+func (s *State) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
 	r, err := s.Store.Role(guildID, roleID)
 	if err == nil {
 		return r, nil
@@ -559,10 +584,14 @@ func (s *State) Role(guildID, roleID discord.Snowflake) (*discord.Role, error) {
 		}
 	}
 
+	if role == nil {
+		return nil, ErrStoreNotFound
+	}
+
 	return role, nil
 }
 
-func (s *State) Roles(guildID discord.Snowflake) ([]discord.Role, error) {
+func (s *State) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	rs, err := s.Store.Roles(guildID)
 	if err == nil {
 		return rs, nil
@@ -11,8 +11,20 @@ func (s *State) hookSession() error {
 		if s.PreHandler != nil {
 			s.PreHandler.Call(iface)
 		}
+
+		// Grab the old entity before the store is updated.
+		old := s.oldEvent(iface)
+
 		s.onEvent(iface)
 		s.Handler.Call(iface)
+
+		if old != nil {
+			s.Handler.Call(old)
+		}
+
+		if bulk, ok := old.(*MessageDeleteBulkEvent); ok && s.SplitBulkDeletes {
+			s.splitBulkDelete(bulk)
+		}
 	})
 
 	return nil
@@ -36,6 +48,8 @@ func (s *State) onEvent(iface interface{}) {
 		// Handle guilds
 		for i := range ev.Guilds {
 			s.batchLog(handleGuildCreate(s.Store, &ev.Guilds[i])...)
+			s.indexGuildMembers(&ev.Guilds[i])
+			s.onGuildCreate(&ev.Guilds[i], true)
 		}
 
 		// Handle private channels
@@ -51,7 +65,12 @@ func (s *State) onEvent(iface interface{}) {
 		}
 
 	case *gateway.GuildCreateEvent:
+		_, err := s.Store.Guild(ev.ID)
+		cached := err == nil
+
 		s.batchLog(handleGuildCreate(s.Store, ev)...)
+		s.indexGuildMembers(ev)
+		s.onGuildCreate(ev, cached)
 
 	case *gateway.GuildUpdateEvent:
 		if err := s.Store.GuildSet((*discord.Guild)(ev)); err != nil {
@@ -59,15 +78,25 @@ func (s *State) onEvent(iface interface{}) {
 		}
 
 	case *gateway.GuildDeleteEvent:
+		// Keep the guild's data during outages, as the guild will be sent
+		// again once it's available.
+		if !s.onGuildDelete(ev) {
+			break
+		}
+
 		if err := s.Store.GuildRemove(ev.ID); err != nil {
 			s.stateErr(err, "failed to delete guild in state")
 		}
 
+		s.roles.removeGuild(ev.ID)
+
 	case *gateway.GuildMemberAddEvent:
 		if err := s.Store.MemberSet(ev.GuildID, &ev.Member); err != nil {
 			s.stateErr(err, "failed to add a member in state")
 		}
 
+		s.roles.set(ev.GuildID, &ev.Member)
+
 	case *gateway.GuildMemberUpdateEvent:
 		m, err := s.Store.Member(ev.GuildID, ev.User.ID)
 		if err != nil {
@@ -82,11 +111,15 @@ func (s *State) onEvent(iface interface{}) {
 			s.stateErr(err, "failed to update a member in state")
 		}
 
+		s.roles.set(ev.GuildID, m)
+
 	case *gateway.GuildMemberRemoveEvent:
 		if err := s.Store.MemberRemove(ev.GuildID, ev.User.ID); err != nil {
 			s.stateErr(err, "failed to remove a member in state")
 		}
 
+		s.roles.remove(ev.GuildID, ev.User.ID)
+
 	case *gateway.GuildMembersChunkEvent:
 		for _, m := range ev.Members {
 			m := m
@@ -94,6 +127,8 @@ func (s *State) onEvent(iface interface{}) {
 			if err := s.Store.MemberSet(ev.GuildID, &m); err != nil {
 				s.stateErr(err, "failed to add a member from chunk in state")
 			}
+
+			s.roles.set(ev.GuildID, &m)
 		}
 
 		for _, p := range ev.Presences {
@@ -119,11 +154,18 @@ func (s *State) onEvent(iface interface{}) {
 			s.stateErr(err, "failed to remove a role in state")
 		}
 
+		s.roles.removeRole(ev.GuildID, ev.RoleID)
+
 	case *gateway.GuildEmojisUpdateEvent:
 		if err := s.Store.EmojiSet(ev.GuildID, ev.Emojis); err != nil {
 			s.stateErr(err, "failed to update emojis in state")
 		}
 
+	case *gateway.GuildStickersUpdateEvent:
+		if err := s.guildStickersSet(ev.GuildID, ev.Stickers); err != nil {
+			s.stateErr(err, "failed to update stickers in state")
+		}
+
 	case *gateway.ChannelCreateEvent:
 		if err := s.Store.ChannelSet((*discord.Channel)(ev)); err != nil {
 			s.stateErr(err, "failed to create a channel in state")
@@ -160,7 +202,7 @@ func (s *State) onEvent(iface interface{}) {
 	case *gateway.MessageDeleteBulkEvent:
 		for _, id := range ev.IDs {
 			if err := s.Store.MessageRemove(ev.ChannelID, id); err != nil {
-				s.stateErr(err, "failed to delete bulk meessages in state")
+				s.stateErr(err, "failed to delete bulk messages in state")
 			}
 		}
 
@@ -282,7 +324,7 @@ func (s *State) batchLog(errors ...error) {
 
 // Helper functions
 
-func (s *State) editMessage(ch, msg discord.Snowflake, fn func(m *discord.Message) bool) {
+func (s *State) editMessage(ch discord.ChannelID, msg discord.MessageID, fn func(m *discord.Message) bool) {
 	m, err := s.Store.Message(ch, msg)
 	if err != nil {
 		return
@@ -295,6 +337,35 @@ func (s *State) editMessage(ch, msg discord.Snowflake, fn func(m *discord.Messag
 	}
 }
 
+// guildStickersSet replaces the stickers of the guild in the store. Stickers
+// don't have their own store, so they're kept in the guild.
+func (s *State) guildStickersSet(guildID discord.GuildID, stickers []discord.Sticker) error {
+	g, err := s.Store.Guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	// GuildSet keeps the old stickers if they're nil.
+	if stickers == nil {
+		stickers = []discord.Sticker{}
+	}
+
+	cp := *g
+	cp.Stickers = stickers
+	return s.Store.GuildSet(&cp)
+}
+
+func (s *State) indexGuildMembers(guild *gateway.GuildCreateEvent) {
+	// Unavailable guilds aren't populated; see handleGuildCreate.
+	if guild.Unavailable {
+		return
+	}
+
+	for i := range guild.Members {
+		s.roles.set(guild.ID, &guild.Members[i])
+	}
+}
+
 func findReaction(rs []discord.Reaction, emoji discord.Emoji) int {
 	for i := range rs {
 		if rs[i].Emoji.ID == emoji.ID && rs[i].Emoji.Name == emoji.Name {
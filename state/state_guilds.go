@@ -0,0 +1,121 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+)
+
+// GuildAvailableEvent is dispatched by the State when a guild becomes available
+// again after an outage. It is not dispatched for guilds that are still being
+// loaded after Ready.
+type GuildAvailableEvent struct {
+	*gateway.GuildCreateEvent
+}
+
+// GuildUnavailableEvent is dispatched by the State when a guild becomes
+// unavailable because of an outage. The guild's cached data is kept until it
+// becomes available again.
+type GuildUnavailableEvent struct {
+	*gateway.GuildDeleteEvent
+}
+
+// GuildJoinEvent is dispatched by the State when the bot joins a new guild.
+// Unlike GuildCreateEvent, it is not dispatched for guilds that are loaded
+// after Ready or that become available again after an outage, which makes it
+// suitable for "thanks for adding me" messages.
+type GuildJoinEvent struct {
+	*gateway.GuildCreateEvent
+}
+
+// GuildLeaveEvent is dispatched by the State when the bot leaves or is removed
+// from a guild. It is not dispatched for outages.
+type GuildLeaveEvent struct {
+	*gateway.GuildDeleteEvent
+}
+
+// guildAvailability tracks guilds that are currently unavailable.
+type guildAvailability struct {
+	mutex sync.RWMutex
+	// guilds maps unavailable guilds to whether or not the guild was available
+	// before, which is false for guilds sent in Ready.
+	guilds map[discord.GuildID]bool
+}
+
+func newGuildAvailability() *guildAvailability {
+	return &guildAvailability{
+		guilds: map[discord.GuildID]bool{},
+	}
+}
+
+// unavailable marks the guild as unavailable. outage should be true if the
+// guild was available before. A guild that's already unavailable because of an
+// outage stays that way, such as when it's sent in the Ready of a new session.
+func (ga *guildAvailability) unavailable(guildID discord.GuildID, outage bool) {
+	ga.mutex.Lock()
+	defer ga.mutex.Unlock()
+
+	ga.guilds[guildID] = ga.guilds[guildID] || outage
+}
+
+// available marks the guild as available. It returns true for tracked if the
+// guild was unavailable before, and true for outage if that was because of an
+// outage.
+func (ga *guildAvailability) available(guildID discord.GuildID) (tracked, outage bool) {
+	ga.mutex.Lock()
+	defer ga.mutex.Unlock()
+
+	outage, tracked = ga.guilds[guildID]
+	delete(ga.guilds, guildID)
+	return
+}
+
+func (ga *guildAvailability) isAvailable(guildID discord.GuildID) bool {
+	ga.mutex.RLock()
+	defer ga.mutex.RUnlock()
+
+	_, unavailable := ga.guilds[guildID]
+	return !unavailable
+}
+
+// GuildIsAvailable returns false if the guild is unavailable, either because
+// it hasn't been loaded after Ready or because of an outage. Per-guild work
+// should be paused until the guild becomes available, which is signaled by a
+// GuildAvailableEvent.
+func (s *State) GuildIsAvailable(guildID discord.GuildID) bool {
+	return s.availability.isAvailable(guildID)
+}
+
+// onGuildCreate handles the availability of the guild. cached should be true if
+// the guild was in the store before the event.
+func (s *State) onGuildCreate(ev *gateway.GuildCreateEvent, cached bool) {
+	if ev.Unavailable {
+		// Guilds in Ready are unavailable until their GuildCreate is received.
+		s.availability.unavailable(ev.ID, false)
+		return
+	}
+
+	tracked, outage := s.availability.available(ev.ID)
+
+	switch {
+	case outage:
+		s.Handler.Call(&GuildAvailableEvent{ev})
+	case !tracked && !cached:
+		// The guild is neither from Ready nor already known, so it must be new.
+		s.Handler.Call(&GuildJoinEvent{ev})
+	}
+}
+
+func (s *State) onGuildDelete(ev *gateway.GuildDeleteEvent) (removed bool) {
+	if !ev.Unavailable {
+		// The guild was removed, so it's no longer tracked.
+		s.availability.available(ev.ID)
+		s.Handler.Call(&GuildLeaveEvent{ev})
+		return true
+	}
+
+	s.availability.unavailable(ev.ID, true)
+	s.Handler.Call(&GuildUnavailableEvent{ev})
+	return false
+}
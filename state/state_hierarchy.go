@@ -0,0 +1,105 @@
+package state
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// RoleManageReason is the reason why a role can't be managed.
+type RoleManageReason uint8
+
+const (
+	// RoleMissingPermission means the bot lacks MANAGE_ROLES in the guild.
+	RoleMissingPermission RoleManageReason = iota + 1
+	// RoleHierarchy means the role is not below the bot's highest role.
+	RoleHierarchy
+	// RoleManaged means the role belongs to an integration, such as a bot or
+	// a Twitch subscription, and can't be assigned manually.
+	RoleManaged
+)
+
+// RoleManageError is returned by CanManageRole. Its message is meant to be
+// shown to server admins, as it explains what needs to be changed.
+type RoleManageError struct {
+	Reason RoleManageReason
+	Role   discord.Role
+	// HighestRole is the bot's highest role. It's only set for RoleHierarchy,
+	// and it's the zero value if the bot has no roles.
+	HighestRole discord.Role
+}
+
+func (err *RoleManageError) Error() string {
+	switch err.Reason {
+	case RoleMissingPermission:
+		return "the bot needs the Manage Roles permission"
+	case RoleHierarchy:
+		if !err.HighestRole.ID.Valid() {
+			return "the bot needs a role above " + err.Role.Name
+		}
+		return "the bot's highest role " + err.HighestRole.Name +
+			" must be moved above " + err.Role.Name
+	case RoleManaged:
+		return err.Role.Name + " is managed by an integration and can't be assigned"
+	default:
+		return "cannot manage role " + err.Role.Name
+	}
+}
+
+// CanManageRole checks if the bot can add the role to or remove it from
+// members. It returns a *RoleManageError if it can't, which explains whether a
+// permission is missing or the bot's role is too low. This should be checked
+// before role grants, such as in reaction role menus, to tell admins what's
+// wrong instead of failing with a generic Missing Permissions error.
+func (s *State) CanManageRole(guildID discord.GuildID, roleID discord.RoleID) error {
+	g, err := s.Guild(guildID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get guild")
+	}
+
+	me, err := s.Me()
+	if err != nil {
+		return errors.Wrap(err, "failed to get self")
+	}
+
+	m, err := s.Member(guildID, me.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get self as member")
+	}
+
+	var target *discord.Role
+	for i, role := range g.Roles {
+		if role.ID == roleID {
+			target = &g.Roles[i]
+			break
+		}
+	}
+
+	highest, _ := m.HighestRole(g.Roles)
+
+	if target == nil {
+		return ErrStoreNotFound
+	}
+
+	if target.Managed {
+		return &RoleManageError{Reason: RoleManaged, Role: *target}
+	}
+
+	// The owner can manage every role.
+	if g.OwnerID == me.ID {
+		return nil
+	}
+
+	// Without a channel, CalcOverwrites returns the guild-wide permissions.
+	perms := discord.CalcOverwrites(*g, discord.Channel{}, *m)
+	if !perms.Has(discord.PermissionManageRoles) {
+		return &RoleManageError{Reason: RoleMissingPermission, Role: *target}
+	}
+
+	// Administrators are still bound by the hierarchy.
+	if !highest.ID.Valid() || !highest.Above(*target) {
+		return &RoleManageError{Reason: RoleHierarchy, Role: *target, HighestRole: highest}
+	}
+
+	return nil
+}
@@ -0,0 +1,420 @@
+package state
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+// JoinAction is an action done for a member that joined a guild, such as
+// granting a role or sending a welcome DM. Actions are retried when they
+// return an error, so they should be safe to repeat.
+type JoinAction func(ctx context.Context, s *State, m JoinedMember) error
+
+// JoinedMember is a member waiting in a JoinQueue.
+type JoinedMember struct {
+	GuildID discord.GuildID   `json:"guild_id"`
+	UserID  discord.UserID    `json:"user_id"`
+	Joined  discord.Timestamp `json:"joined_at"`
+
+	// Done is the number of actions that succeeded, so that they're not
+	// repeated when a later action is retried.
+	Done int `json:"done"`
+	// Attempts is the number of times the current action failed.
+	Attempts int `json:"attempts"`
+	// RetryAt is the earliest time the current action is retried.
+	RetryAt discord.Timestamp `json:"retry_at"`
+}
+
+// JoinQueueStore saves the members of a JoinQueue, so that they're not lost if
+// the bot crashes or restarts before their actions are done.
+type JoinQueueStore interface {
+	Load() ([]JoinedMember, error)
+	// Save replaces the saved members. It's called by Run after the queue
+	// changes, at most once every SaveDelay, and never concurrently.
+	Save([]JoinedMember) error
+}
+
+// JoinQueue queues the members that join guilds, and runs the actions for one
+// member at a time. A gap between members spreads join waves out, so that the
+// actions of thousands of members don't exhaust the rate limits of other
+// requests. It must be created with NewJoinQueue.
+type JoinQueue struct {
+	State   *State
+	Actions []JoinAction
+
+	// Interval is the time between two members. It defaults to 1 second.
+	Interval time.Duration
+	// Retries is the number of times a failing action is retried before the
+	// member is dropped. It defaults to 3.
+	Retries int
+	// RetryDelay is the time before a failed action is retried. It's doubled
+	// with every attempt, and defaults to 10 seconds.
+	RetryDelay time.Duration
+
+	// Store, if not nil, saves the queue.
+	Store JoinQueueStore
+	// SaveDelay is the time changes to the queue are collected for before it's
+	// saved, so that a join wave doesn't save the queue for every member. It
+	// defaults to 1 second.
+	SaveDelay time.Duration
+	// ErrorLog is called when an action fails, or the queue can't be saved.
+	// The member is only valid if the error is from an action.
+	ErrorLog func(m JoinedMember, err error)
+
+	mutex  sync.Mutex
+	queue  []JoinedMember
+	notify chan struct{}
+
+	dirty     bool
+	saveQueue chan struct{}
+}
+
+// NewJoinQueue creates a new JoinQueue with the given actions, which are run
+// in order for every member.
+func NewJoinQueue(s *State, actions ...JoinAction) *JoinQueue {
+	return &JoinQueue{
+		State:      s,
+		Actions:    actions,
+		Interval:   time.Second,
+		Retries:    3,
+		RetryDelay: 10 * time.Second,
+		SaveDelay:  time.Second,
+		notify:     make(chan struct{}, 1),
+		saveQueue:  make(chan struct{}, 1),
+	}
+}
+
+// Len returns the number of members in the queue.
+func (q *JoinQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.queue)
+}
+
+// Add queues the member, unless it's already queued. Members are queued
+// automatically while Run is running.
+func (q *JoinQueue) Add(m JoinedMember) {
+	q.mutex.Lock()
+	for _, queued := range q.queue {
+		if queued.GuildID == m.GuildID && queued.UserID == m.UserID {
+			q.mutex.Unlock()
+			return
+		}
+	}
+	q.queue = append(q.queue, m)
+	q.save()
+	q.mutex.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run loads the saved queue, then queues every member that joins and runs
+// their actions until the context is canceled. The queue is saved one last time
+// before Run returns.
+func (q *JoinQueue) Run(ctx context.Context) error {
+	if q.Store != nil {
+		saved, err := q.Store.Load()
+		if err != nil {
+			return errors.Wrap(err, "failed to load queue")
+		}
+
+		q.mutex.Lock()
+		q.queue = mergeJoined(saved, q.queue)
+		q.mutex.Unlock()
+
+		// Save the last changes after the members being processed are done.
+		var saving = make(chan struct{})
+		defer func() {
+			<-saving
+			q.flush()
+		}()
+
+		go func() {
+			q.saveLoop(ctx)
+			close(saving)
+		}()
+	}
+
+	rm := q.State.AddHandler(func(ev *gateway.GuildMemberAddEvent) {
+		q.Add(JoinedMember{
+			GuildID: ev.GuildID,
+			UserID:  ev.User.ID,
+			Joined:  ev.Joined,
+		})
+	})
+	defer rm()
+
+	var interval = q.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for {
+		var err error
+
+		m, ok := q.next()
+		switch {
+		case ok:
+			q.process(ctx, m)
+			err = q.wait(ctx, time.After(interval), nil)
+		case m.RetryAt.Valid():
+			// Only members waiting for a retry are left, but new members
+			// don't have to wait for them.
+			err = q.wait(ctx, time.After(time.Until(m.RetryAt.Time())), q.notify)
+		default:
+			err = q.wait(ctx, nil, q.notify)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (q *JoinQueue) wait(ctx context.Context, timer <-chan time.Time, notify <-chan struct{}) error {
+	select {
+	case <-timer:
+		return nil
+	case <-notify:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// next returns the first member that's not waiting for a retry. If there's
+// none, it returns false and the member that's retried the soonest, if any.
+func (q *JoinQueue) next() (JoinedMember, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var now = time.Now()
+	var soonest JoinedMember
+
+	for _, m := range q.queue {
+		if !m.RetryAt.Valid() || !m.RetryAt.Time().After(now) {
+			return m, true
+		}
+		if !soonest.RetryAt.Valid() || m.RetryAt.Time().Before(soonest.RetryAt.Time()) {
+			soonest = m
+		}
+	}
+
+	return soonest, false
+}
+
+func (q *JoinQueue) process(ctx context.Context, m JoinedMember) {
+	for m.Done < len(q.Actions) {
+		err := q.Actions[m.Done](ctx, q.State, m)
+		if err == nil {
+			m.Done++
+			m.Attempts = 0
+			m.RetryAt = discord.Timestamp{}
+			continue
+		}
+
+		// Leave the member in the queue as-is if Run is stopping.
+		if ctx.Err() != nil {
+			return
+		}
+
+		q.logError(m, errors.Wrapf(err, "action %d failed", m.Done))
+
+		var retries = q.Retries
+		if retries <= 0 {
+			retries = 3
+		}
+
+		if m.Attempts++; m.Attempts > retries {
+			break
+		}
+
+		var delay = q.RetryDelay
+		if delay <= 0 {
+			delay = 10 * time.Second
+		}
+
+		m.RetryAt = discord.NewTimestamp(time.Now().Add(delay << uint(m.Attempts-1)))
+		q.replace(m, true)
+		return
+	}
+
+	q.replace(m, false)
+}
+
+// replace updates the member in the queue, or removes it if keep is false.
+func (q *JoinQueue) replace(m JoinedMember, keep bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, queued := range q.queue {
+		if queued.GuildID != m.GuildID || queued.UserID != m.UserID {
+			continue
+		}
+
+		if keep {
+			q.queue[i] = m
+		} else {
+			q.queue = append(q.queue[:i], q.queue[i+1:]...)
+		}
+		break
+	}
+
+	q.save()
+}
+
+// mergeJoined returns the saved members followed by the queued ones that
+// aren't saved. The saved members keep their progress.
+func mergeJoined(saved, queued []JoinedMember) []JoinedMember {
+	type key struct {
+		guildID discord.GuildID
+		userID  discord.UserID
+	}
+
+	var merged = make([]JoinedMember, 0, len(saved)+len(queued))
+	var seen = make(map[key]struct{}, len(saved)+len(queued))
+
+	for _, members := range [][]JoinedMember{saved, queued} {
+		for _, m := range members {
+			k := key{m.GuildID, m.UserID}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+			merged = append(merged, m)
+		}
+	}
+
+	return merged
+}
+
+// save marks the queue to be saved by saveLoop. The mutex must be held.
+func (q *JoinQueue) save() {
+	if q.Store == nil {
+		return
+	}
+
+	q.dirty = true
+
+	select {
+	case q.saveQueue <- struct{}{}:
+	default:
+	}
+}
+
+// saveLoop saves the queue after it changes, at most once every SaveDelay,
+// until the context is canceled.
+func (q *JoinQueue) saveLoop(ctx context.Context) {
+	var delay = q.SaveDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	for {
+		select {
+		case <-q.saveQueue:
+		case <-ctx.Done():
+			return
+		}
+
+		// Collect the changes made in the meantime.
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		q.flush()
+	}
+}
+
+// flush saves the queue if it changed since it was last saved.
+func (q *JoinQueue) flush() {
+	q.mutex.Lock()
+	if !q.dirty {
+		q.mutex.Unlock()
+		return
+	}
+	var members = append([]JoinedMember(nil), q.queue...)
+	q.dirty = false
+	q.mutex.Unlock()
+
+	if err := q.Store.Save(members); err != nil {
+		q.logError(JoinedMember{}, errors.Wrap(err, "failed to save queue"))
+	}
+}
+
+func (q *JoinQueue) logError(m JoinedMember, err error) {
+	if q.ErrorLog != nil {
+		q.ErrorLog(m, err)
+	}
+}
+
+// JoinQueueFile is a JoinQueueStore that saves the queue as a JSON file. The
+// file is synced to disk and replaced atomically, so a crash while saving keeps
+// the old queue.
+type JoinQueueFile string
+
+var _ JoinQueueStore = JoinQueueFile("")
+
+// Load reads the queue from the file. A missing file is an empty queue.
+func (f JoinQueueFile) Load() ([]JoinedMember, error) {
+	b, err := ioutil.ReadFile(string(f))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var members []JoinedMember
+	return members, json.Unmarshal(b, &members)
+}
+
+// Save writes the queue to a temporary file, syncs it, then renames it over the
+// file.
+func (f JoinQueueFile) Save(members []JoinedMember) error {
+	b, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(string(f)), filepath.Base(string(f))+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), string(f))
+}
@@ -0,0 +1,181 @@
+package state_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+	"github.com/diamondburned/arikawa/session"
+	"github.com/diamondburned/arikawa/state"
+)
+
+type memJoinQueue struct {
+	mutex sync.Mutex
+	saved []state.JoinedMember
+	saves int
+}
+
+func (m *memJoinQueue) Load() ([]state.JoinedMember, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.saved, nil
+}
+
+func (m *memJoinQueue) Save(members []state.JoinedMember) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.saved = members
+	m.saves++
+	return nil
+}
+
+func newTestState(t *testing.T) *state.State {
+	s, err := state.NewFromSession(
+		session.NewWithGateway(gatewaytest.NewGateway(gatewaytest.NewConn(), "Bot token")),
+		state.NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+	return s
+}
+
+func TestJoinQueue(t *testing.T) {
+	store := &memJoinQueue{
+		// Member 10 was saved after its first action succeeded.
+		saved: []state.JoinedMember{{GuildID: 1, UserID: 10, Done: 1}},
+	}
+
+	var mutex sync.Mutex
+	var calls []string
+	var done = make(chan struct{})
+
+	record := func(action string, m state.JoinedMember) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		calls = append(calls, action+" "+m.UserID.String())
+		if len(calls) == 4 {
+			close(done)
+		}
+	}
+
+	q := state.NewJoinQueue(newTestState(t),
+		func(ctx context.Context, s *state.State, m state.JoinedMember) error {
+			record("greet", m)
+			return nil
+		},
+		func(ctx context.Context, s *state.State, m state.JoinedMember) error {
+			record("role", m)
+			if m.UserID == 11 && m.Attempts == 0 {
+				return errors.New("rate limited")
+			}
+			return nil
+		},
+	)
+	q.Interval = time.Millisecond
+	q.RetryDelay = time.Millisecond
+	q.SaveDelay = 10 * time.Millisecond
+	q.Store = store
+
+	// Member 10 is also queued before Run, but shouldn't be run twice.
+	q.Add(state.JoinedMember{GuildID: 1, UserID: 10})
+	q.Add(state.JoinedMember{GuildID: 1, UserID: 11})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var stopped = make(chan error)
+	go func() { stopped <- q.Run(ctx) }()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the actions")
+	}
+
+	cancel()
+	if err := <-stopped; err != context.Canceled {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var expect = []string{"role 10", "greet 11", "role 11", "role 11"}
+	for i, call := range expect {
+		if calls[i] != call {
+			t.Fatalf("Unexpected calls %q, expected %q", calls, expect)
+		}
+	}
+
+	if q.Len() != 0 || len(store.saved) != 0 {
+		t.Fatal("Members are left in the queue:", q.Len(), store.saved)
+	}
+}
+
+func TestJoinQueueSaveBatch(t *testing.T) {
+	store := &memJoinQueue{}
+
+	q := state.NewJoinQueue(newTestState(t))
+	q.Interval = time.Hour
+	q.SaveDelay = 50 * time.Millisecond
+	q.Store = store
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var stopped = make(chan error)
+	go func() { stopped <- q.Run(ctx) }()
+
+	for i := 0; i < 100; i++ {
+		q.Add(state.JoinedMember{GuildID: 1, UserID: discord.UserID(i + 1)})
+	}
+
+	cancel()
+	<-stopped
+
+	if store.saves < 1 || store.saves > 3 {
+		t.Fatal("Unexpected number of saves:", store.saves)
+	}
+	// The first member may be processed right away, as it has no actions.
+	if len(store.saved) != q.Len() || q.Len() < 99 {
+		t.Fatal("Unexpected saved members:", len(store.saved), q.Len())
+	}
+}
+
+func TestJoinQueueFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "joinqueue")
+	if err != nil {
+		t.Fatal("Failed to create dir:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	f := state.JoinQueueFile(filepath.Join(dir, "queue.json"))
+
+	members, err := f.Load()
+	if err != nil || members != nil {
+		t.Fatal("Unexpected queue of a missing file:", members, err)
+	}
+
+	if err := f.Save([]state.JoinedMember{{GuildID: 1, UserID: 2, Done: 1}}); err != nil {
+		t.Fatal("Failed to save:", err)
+	}
+
+	members, err = f.Load()
+	if err != nil {
+		t.Fatal("Failed to load:", err)
+	}
+	if len(members) != 1 || members[0].UserID != 2 || members[0].Done != 1 {
+		t.Fatal("Unexpected members:", members)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatal("Temporary files are left:", len(files))
+	}
+}
@@ -0,0 +1,175 @@
+package state
+
+import (
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+)
+
+// The following events are dispatched by the State after it handles their
+// Gateway counterparts. Each event carries the cached version of the entity
+// from before the update or deletion, so handlers can diff the changes. Old is
+// nil if the entity wasn't in the store.
+//
+//    s.AddHandler(func(ev *state.MessageDeleteEvent) {
+//        if ev.Old != nil {
+//            log.Println("deleted message:", ev.Old.Content)
+//        }
+//    })
+//
+type (
+	GuildUpdateEvent struct {
+		*gateway.GuildUpdateEvent
+		Old *discord.Guild
+	}
+
+	GuildMemberUpdateEvent struct {
+		*gateway.GuildMemberUpdateEvent
+		Old *discord.Member
+	}
+	GuildMemberRemoveEvent struct {
+		*gateway.GuildMemberRemoveEvent
+		Old *discord.Member
+	}
+
+	GuildRoleUpdateEvent struct {
+		*gateway.GuildRoleUpdateEvent
+		Old *discord.Role
+	}
+	GuildRoleDeleteEvent struct {
+		*gateway.GuildRoleDeleteEvent
+		Old *discord.Role
+	}
+
+	ChannelUpdateEvent struct {
+		*gateway.ChannelUpdateEvent
+		Old *discord.Channel
+	}
+	ChannelDeleteEvent struct {
+		*gateway.ChannelDeleteEvent
+		Old *discord.Channel
+	}
+
+	MessageUpdateEvent struct {
+		*gateway.MessageUpdateEvent
+		Old *discord.Message
+	}
+	MessageDeleteEvent struct {
+		*gateway.MessageDeleteEvent
+		Old *discord.Message
+	}
+	// MessageDeleteBulkEvent carries the deleted messages that were in the
+	// store. Messages that weren't are missing from Old.
+	MessageDeleteBulkEvent struct {
+		*gateway.MessageDeleteBulkEvent
+		Old []discord.Message
+	}
+)
+
+// oldEvent returns the event carrying the old version of the entity that the
+// given Gateway event updates or deletes. It must be called before the store
+// is updated. Nil is returned for other events.
+func (s *State) oldEvent(iface interface{}) interface{} {
+	switch ev := iface.(type) {
+	case *gateway.GuildUpdateEvent:
+		var old *discord.Guild
+		if g, err := s.Store.Guild(ev.ID); err == nil {
+			cp := *g
+			old = &cp
+		}
+		return &GuildUpdateEvent{ev, old}
+
+	case *gateway.GuildMemberUpdateEvent:
+		return &GuildMemberUpdateEvent{ev, s.oldMember(ev.GuildID, ev.User.ID)}
+
+	case *gateway.GuildMemberRemoveEvent:
+		return &GuildMemberRemoveEvent{ev, s.oldMember(ev.GuildID, ev.User.ID)}
+
+	case *gateway.GuildRoleUpdateEvent:
+		return &GuildRoleUpdateEvent{ev, s.oldRole(ev.GuildID, ev.Role.ID)}
+
+	case *gateway.GuildRoleDeleteEvent:
+		return &GuildRoleDeleteEvent{ev, s.oldRole(ev.GuildID, ev.RoleID)}
+
+	case *gateway.ChannelUpdateEvent:
+		return &ChannelUpdateEvent{ev, s.oldChannel(ev.ID)}
+
+	case *gateway.ChannelDeleteEvent:
+		return &ChannelDeleteEvent{ev, s.oldChannel(ev.ID)}
+
+	case *gateway.MessageUpdateEvent:
+		return &MessageUpdateEvent{ev, s.oldMessage(ev.ChannelID, ev.ID)}
+
+	case *gateway.MessageDeleteEvent:
+		return &MessageDeleteEvent{ev, s.oldMessage(ev.ChannelID, ev.ID)}
+
+	case *gateway.MessageDeleteBulkEvent:
+		var old = make([]discord.Message, 0, len(ev.IDs))
+		for _, id := range ev.IDs {
+			if m := s.oldMessage(ev.ChannelID, id); m != nil {
+				old = append(old, *m)
+			}
+		}
+		return &MessageDeleteBulkEvent{ev, old}
+	}
+
+	return nil
+}
+
+func (s *State) oldMember(guildID discord.GuildID, userID discord.UserID) *discord.Member {
+	m, err := s.Store.Member(guildID, userID)
+	if err != nil {
+		return nil
+	}
+	cp := *m
+	return &cp
+}
+
+func (s *State) oldRole(guildID discord.GuildID, roleID discord.RoleID) *discord.Role {
+	r, err := s.Store.Role(guildID, roleID)
+	if err != nil {
+		return nil
+	}
+	cp := *r
+	return &cp
+}
+
+func (s *State) oldChannel(channelID discord.ChannelID) *discord.Channel {
+	c, err := s.Store.Channel(channelID)
+	if err != nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+func (s *State) oldMessage(channelID discord.ChannelID, messageID discord.MessageID) *discord.Message {
+	m, err := s.Store.Message(channelID, messageID)
+	if err != nil {
+		return nil
+	}
+	cp := *m
+	return &cp
+}
+
+// splitBulkDelete dispatches a MessageDeleteEvent of both the Gateway and the
+// State for each message of the bulk deletion.
+func (s *State) splitBulkDelete(bulk *MessageDeleteBulkEvent) {
+	for _, id := range bulk.IDs {
+		ev := &gateway.MessageDeleteEvent{
+			ID:        id,
+			ChannelID: bulk.ChannelID,
+			GuildID:   bulk.GuildID,
+		}
+
+		var old *discord.Message
+		for i := range bulk.Old {
+			if bulk.Old[i].ID == id {
+				old = &bulk.Old[i]
+				break
+			}
+		}
+
+		s.Handler.Call(ev)
+		s.Handler.Call(&MessageDeleteEvent{ev, old})
+	}
+}
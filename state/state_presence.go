@@ -0,0 +1,173 @@
+package state
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+)
+
+// PresenceRotator cycles the bot's activity through a list of activities on
+// an interval. The names of the activities may contain these variables:
+//
+//    {guilds}     the number of guilds the bot is in
+//    {members}    the number of members in all of these guilds
+//
+// Presence updates are sent through the gateway, so they share its send rate
+// limiter. Discord also limits presence updates separately, so intervals
+// shorter than 15 seconds are raised to 15 seconds.
+type PresenceRotator struct {
+	State      *State
+	Activities []discord.Activity
+	// Status is the status sent with every activity. It defaults to online.
+	Status discord.Status
+	// Interval is the time each activity is shown. It defaults to 1 minute.
+	Interval time.Duration
+	// ErrorLog is called when the presence can't be updated.
+	ErrorLog func(err error)
+
+	mutex   sync.Mutex
+	members map[discord.GuildID]uint64
+}
+
+// MinPresenceInterval is the shortest interval a PresenceRotator uses.
+const MinPresenceInterval = 15 * time.Second
+
+// minPresenceInterval is MinPresenceInterval, which tests lower.
+var minPresenceInterval = MinPresenceInterval
+
+// NewPresenceRotator creates a new PresenceRotator with the given activities.
+func NewPresenceRotator(s *State, activities ...discord.Activity) *PresenceRotator {
+	return &PresenceRotator{
+		State:      s,
+		Activities: activities,
+		Status:     discord.OnlineStatus,
+		Interval:   time.Minute,
+		members:    map[discord.GuildID]uint64{},
+	}
+}
+
+// Run shows the first activity right away, then the next one on every
+// interval until the context is canceled. Member counts are kept up to date
+// from gateway events while Run is running, so it should be started before the
+// State is opened.
+func (r *PresenceRotator) Run(ctx context.Context) error {
+	if len(r.Activities) == 0 {
+		return errors.New("no activities to rotate")
+	}
+
+	defer r.countMembers()()
+
+	var interval = r.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if interval < minPresenceInterval {
+		interval = minPresenceInterval
+	}
+
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 0; ; i = (i + 1) % len(r.Activities) {
+		if err := r.update(r.Activities[i]); err != nil {
+			r.logError(errors.Wrap(err, "failed to update presence"))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *PresenceRotator) update(activity discord.Activity) error {
+	activity.Name = r.replacer().Replace(activity.Name)
+
+	var status = r.Status
+	if status == discord.UnknownStatus {
+		status = discord.OnlineStatus
+	}
+
+	return r.State.SetStatus(status, activity)
+}
+
+func (r *PresenceRotator) replacer() *strings.Replacer {
+	guilds, _ := r.State.Store.Guilds()
+
+	var members uint64
+
+	r.mutex.Lock()
+	for _, g := range guilds {
+		if n, ok := r.members[g.ID]; ok {
+			members += n
+			continue
+		}
+
+		// Guilds that were created before Run only have their cached members.
+		m, _ := r.State.Store.Members(g.ID)
+		members += uint64(len(m))
+	}
+	r.mutex.Unlock()
+
+	return strings.NewReplacer(
+		"{guilds}", strconv.Itoa(len(guilds)),
+		"{members}", strconv.FormatUint(members, 10),
+	)
+}
+
+// countMembers adds the handlers that count members, and returns a function
+// that removes them.
+func (r *PresenceRotator) countMembers() func() {
+	var rms = []func(){
+		r.State.AddHandler(func(ev *gateway.GuildCreateEvent) {
+			if ev.Unavailable {
+				return
+			}
+			r.mutex.Lock()
+			if r.members == nil {
+				r.members = map[discord.GuildID]uint64{}
+			}
+			r.members[ev.ID] = ev.MemberCount
+			r.mutex.Unlock()
+		}),
+		r.State.AddHandler(func(ev *gateway.GuildDeleteEvent) {
+			r.mutex.Lock()
+			delete(r.members, ev.ID)
+			r.mutex.Unlock()
+		}),
+		r.State.AddHandler(func(ev *gateway.GuildMemberAddEvent) {
+			r.mutex.Lock()
+			if n, ok := r.members[ev.GuildID]; ok {
+				r.members[ev.GuildID] = n + 1
+			}
+			r.mutex.Unlock()
+		}),
+		r.State.AddHandler(func(ev *gateway.GuildMemberRemoveEvent) {
+			r.mutex.Lock()
+			if n, ok := r.members[ev.GuildID]; ok && n > 0 {
+				r.members[ev.GuildID] = n - 1
+			}
+			r.mutex.Unlock()
+		}),
+	}
+
+	return func() {
+		for _, rm := range rms {
+			rm()
+		}
+	}
+}
+
+func (r *PresenceRotator) logError(err error) {
+	if r.ErrorLog != nil {
+		r.ErrorLog(err)
+	}
+}
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+	"github.com/diamondburned/arikawa/session"
+)
+
+func TestPresenceRotator(t *testing.T) {
+	minPresenceInterval = time.Millisecond
+	defer func() { minPresenceInterval = MinPresenceInterval }()
+
+	conn := gatewaytest.NewConn()
+
+	s, err := NewFromSession(
+		session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token")), NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer s.Close()
+
+	// The rotator isn't made by the constructor, so that struct literals are
+	// tested.
+	r := &PresenceRotator{
+		State: s,
+		Activities: []discord.Activity{
+			gateway.Game("with {members} members in {guilds} guilds"),
+			gateway.Watching("the logs"),
+		},
+		Interval: time.Hour,
+	}
+
+	// Count the members without rotating yet.
+	defer r.countMembers()()
+
+	if err := conn.Dispatch("GUILD_CREATE", gateway.GuildCreateEvent{
+		Guild:       discord.Guild{ID: 1},
+		MemberCount: 10,
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	waitMembers := func(expect uint64) {
+		for start := time.Now(); ; time.Sleep(time.Millisecond) {
+			r.mutex.Lock()
+			n := r.members[1]
+			r.mutex.Unlock()
+
+			if n == expect {
+				return
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Fatalf("Counted %d members, expected %d", n, expect)
+			}
+		}
+	}
+
+	// Handlers are called concurrently, so the guild has to be counted before
+	// members are added to it.
+	waitMembers(10)
+
+	if err := conn.Dispatch("GUILD_MEMBER_ADD", gateway.GuildMemberAddEvent{
+		Member:  discord.Member{User: discord.User{ID: 2}},
+		GuildID: 1,
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	waitMembers(11)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.Interval = time.Millisecond
+	go r.Run(ctx)
+
+	var expect = []string{"with 11 members in 1 guilds", "the logs", "with 11 members in 1 guilds"}
+	var names []string
+
+	for start := time.Now(); len(names) < len(expect); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("Timed out waiting for presence updates, got", names)
+		}
+
+		names = names[:0]
+
+		for _, op := range conn.Sent() {
+			if op.Code != gateway.StatusUpdateOP {
+				continue
+			}
+
+			var data gateway.UpdateStatusData
+			if err := op.UnmarshalData(&data); err != nil {
+				t.Fatal("Failed to decode presence update:", err)
+			}
+			if data.Activities == nil || len(*data.Activities) != 1 {
+				t.Fatal("Unexpected activities:", data.Activities)
+			}
+
+			names = append(names, (*data.Activities)[0].Name)
+		}
+	}
+
+	for i, name := range expect {
+		if names[i] != name {
+			t.Fatalf("Unexpected activities %q, expected %q", names, expect)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// roleIndex keeps track of which members have which roles. It is maintained
+// incrementally from member events, so counting the members of a role does not
+// require scanning the whole member list of a guild.
+type roleIndex struct {
+	mutex  sync.RWMutex
+	guilds map[discord.GuildID]*guildRoles
+}
+
+type guildRoles struct {
+	members map[discord.UserID][]discord.RoleID            // userID:roleIDs
+	roles   map[discord.RoleID]map[discord.UserID]struct{} // roleID:userIDs
+}
+
+func newRoleIndex() *roleIndex {
+	return &roleIndex{
+		guilds: map[discord.GuildID]*guildRoles{},
+	}
+}
+
+// set replaces the roles of the given member with the member's RoleIDs.
+func (ri *roleIndex) set(guildID discord.GuildID, m *discord.Member) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+
+	gr, ok := ri.guilds[guildID]
+	if !ok {
+		gr = &guildRoles{
+			members: map[discord.UserID][]discord.RoleID{},
+			roles:   map[discord.RoleID]map[discord.UserID]struct{}{},
+		}
+		ri.guilds[guildID] = gr
+	}
+
+	gr.unset(m.User.ID)
+
+	for _, roleID := range m.RoleIDs {
+		users, ok := gr.roles[roleID]
+		if !ok {
+			users = map[discord.UserID]struct{}{}
+			gr.roles[roleID] = users
+		}
+		users[m.User.ID] = struct{}{}
+	}
+
+	// Copy the slice, as the member may be mutated later on.
+	gr.members[m.User.ID] = append([]discord.RoleID(nil), m.RoleIDs...)
+}
+
+// remove removes the member from all roles.
+func (ri *roleIndex) remove(guildID discord.GuildID, userID discord.UserID) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+
+	if gr, ok := ri.guilds[guildID]; ok {
+		gr.unset(userID)
+	}
+}
+
+// removeRole removes the role from the index. Members that had the role will
+// no longer be counted.
+func (ri *roleIndex) removeRole(guildID discord.GuildID, roleID discord.RoleID) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+
+	gr, ok := ri.guilds[guildID]
+	if !ok {
+		return
+	}
+
+	for userID := range gr.roles[roleID] {
+		gr.members[userID] = removeRoleID(gr.members[userID], roleID)
+	}
+
+	delete(gr.roles, roleID)
+}
+
+// removeGuild removes everything known about the guild.
+func (ri *roleIndex) removeGuild(guildID discord.GuildID) {
+	ri.mutex.Lock()
+	defer ri.mutex.Unlock()
+
+	delete(ri.guilds, guildID)
+}
+
+func (ri *roleIndex) count(guildID discord.GuildID, roleID discord.RoleID) int {
+	ri.mutex.RLock()
+	defer ri.mutex.RUnlock()
+
+	if gr, ok := ri.guilds[guildID]; ok {
+		return len(gr.roles[roleID])
+	}
+	return 0
+}
+
+func (ri *roleIndex) userIDs(guildID discord.GuildID, roleID discord.RoleID) []discord.UserID {
+	ri.mutex.RLock()
+	defer ri.mutex.RUnlock()
+
+	gr, ok := ri.guilds[guildID]
+	if !ok {
+		return nil
+	}
+
+	var ids = make([]discord.UserID, 0, len(gr.roles[roleID]))
+	for userID := range gr.roles[roleID] {
+		ids = append(ids, userID)
+	}
+
+	return ids
+}
+
+// unset removes the user from all of its roles. The mutex must be held.
+func (gr *guildRoles) unset(userID discord.UserID) {
+	for _, roleID := range gr.members[userID] {
+		if users, ok := gr.roles[roleID]; ok {
+			delete(users, userID)
+
+			if len(users) == 0 {
+				delete(gr.roles, roleID)
+			}
+		}
+	}
+
+	delete(gr.members, userID)
+}
+
+func removeRoleID(ids []discord.RoleID, id discord.RoleID) []discord.RoleID {
+	for i := range ids {
+		if ids[i] == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// RoleMemberCount returns the number of cached members that have the given
+// role. The count is maintained from Gateway events, so it only includes
+// members that the State has seen. The @everyone role, which has the same ID
+// as the guild, is never counted.
+func (s *State) RoleMemberCount(guildID discord.GuildID, roleID discord.RoleID) int {
+	return s.roles.count(guildID, roleID)
+}
+
+// RoleMembers returns the cached members that have the given role. Like
+// RoleMemberCount, this only includes members that the State has seen.
+func (s *State) RoleMembers(guildID discord.GuildID, roleID discord.RoleID) ([]discord.Member, error) {
+	var ids = s.roles.userIDs(guildID, roleID)
+	var members = make([]discord.Member, 0, len(ids))
+
+	for _, id := range ids {
+		m, err := s.Store.Member(guildID, id)
+		if err != nil {
+			if err == ErrStoreNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		members = append(members, *m)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,225 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/gateway/gatewaytest"
+	"github.com/diamondburned/arikawa/session"
+	"github.com/diamondburned/arikawa/state"
+)
+
+func TestStateBulkDelete(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	s, err := state.NewFromSession(
+		session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token")), state.NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+	s.SplitBulkDeletes = true
+
+	for _, id := range []discord.MessageID{2, 3} {
+		if err := s.Store.MessageSet(&discord.Message{ID: id, ChannelID: 1}); err != nil {
+			t.Fatal("Failed to set message:", err)
+		}
+	}
+
+	bulks := make(chan *state.MessageDeleteBulkEvent, 1)
+	s.AddHandler(func(ev *state.MessageDeleteBulkEvent) { bulks <- ev })
+
+	deletes := make(chan *state.MessageDeleteEvent, 3)
+	s.AddHandler(func(ev *state.MessageDeleteEvent) { deletes <- ev })
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer s.Close()
+
+	if err := conn.Dispatch("MESSAGE_DELETE_BULK", gateway.MessageDeleteBulkEvent{
+		IDs:       []discord.MessageID{2, 3, 4},
+		ChannelID: 1,
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	select {
+	case ev := <-bulks:
+		if len(ev.Old) != 2 {
+			t.Fatal("Unexpected old messages:", ev.Old)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for MESSAGE_DELETE_BULK")
+	}
+
+	var cached int
+	for i := 0; i < 3; i++ {
+		select {
+		case ev := <-deletes:
+			if ev.Old != nil {
+				if ev.Old.ID != ev.ID {
+					t.Fatalf("Message %d has the old message %d", ev.ID, ev.Old.ID)
+				}
+				cached++
+			}
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for split deletes")
+		}
+	}
+
+	if cached != 2 {
+		t.Fatalf("Got %d old messages in split deletes, expected 2", cached)
+	}
+
+	if _, err := s.Store.Message(1, 2); err == nil {
+		t.Fatal("Message 2 is still in the store")
+	}
+}
+
+func TestStateEmojisStickers(t *testing.T) {
+	conn := gatewaytest.NewConn()
+
+	s, err := state.NewFromSession(
+		session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token")), state.NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+
+	if err := s.Store.GuildSet(&discord.Guild{ID: 1}); err != nil {
+		t.Fatal("Failed to set guild:", err)
+	}
+
+	updates := make(chan interface{}, 2)
+	s.AddHandler(func(ev *gateway.GuildEmojisUpdateEvent) { updates <- ev })
+	s.AddHandler(func(ev *gateway.GuildStickersUpdateEvent) { updates <- ev })
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer s.Close()
+
+	if err := conn.Dispatch("GUILD_EMOJIS_UPDATE", gateway.GuildEmojisUpdateEvent{
+		GuildID: 1,
+		Emojis:  []discord.Emoji{{ID: 2, Name: "arikawa"}},
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+	if err := conn.Dispatch("GUILD_STICKERS_UPDATE", gateway.GuildStickersUpdateEvent{
+		GuildID:  1,
+		Stickers: []discord.Sticker{{ID: 3, Name: "hime"}},
+	}); err != nil {
+		t.Fatal("Failed to dispatch:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for updates")
+		}
+	}
+
+	if e, err := s.Store.Emoji(1, 2); err != nil || e.Name != "arikawa" {
+		t.Fatal("Unexpected emoji:", e, err)
+	}
+
+	g, err := s.Store.Guild(1)
+	if err != nil {
+		t.Fatal("Failed to get guild:", err)
+	}
+	if len(g.Stickers) != 1 || g.Stickers[0].Name != "hime" {
+		t.Fatal("Unexpected stickers:", g.Stickers)
+	}
+}
+
+func TestStateGuildOutage(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	conn.Ready.Guilds = []gateway.GuildCreateEvent{{Guild: discord.Guild{ID: 1}, Unavailable: true}}
+
+	s, err := state.NewFromSession(
+		session.NewWithGateway(gatewaytest.NewGateway(conn, "Bot token")), state.NewDefaultStore(nil))
+	if err != nil {
+		t.Fatal("Failed to create state:", err)
+	}
+
+	ready := make(chan struct{}, 1)
+	s.AddHandler(func(*gateway.ReadyEvent) { ready <- struct{}{} })
+
+	events := make(chan interface{}, 1)
+	s.AddHandler(func(ev *state.GuildJoinEvent) { events <- ev })
+	s.AddHandler(func(ev *state.GuildAvailableEvent) { events <- ev })
+	s.AddHandler(func(ev *state.GuildUnavailableEvent) { events <- ev })
+
+	if err := s.Open(); err != nil {
+		t.Fatal("Failed to open:", err)
+	}
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wait := func(what string, ch <-chan struct{}) {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for", what)
+		}
+	}
+
+	dispatch := func(name string, data interface{}) {
+		if err := conn.Dispatch(name, data); err != nil {
+			t.Fatal("Failed to dispatch:", err)
+		}
+	}
+
+	wait("READY", ready)
+
+	// The guild is loaded after Ready, which isn't an outage or a join.
+	dispatch("GUILD_CREATE", gateway.GuildCreateEvent{Guild: discord.Guild{ID: 1}})
+
+	for !s.GuildIsAvailable(1) {
+		select {
+		case ev := <-events:
+			t.Fatalf("Unexpected event %T", ev)
+		case <-ctx.Done():
+			t.Fatal("Timed out waiting for the guild to be loaded")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	dispatch("GUILD_DELETE", gateway.GuildDeleteEvent{ID: 1, Unavailable: true})
+
+	select {
+	case ev := <-events:
+		if _, ok := ev.(*state.GuildUnavailableEvent); !ok {
+			t.Fatalf("Unexpected event %T", ev)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the outage")
+	}
+
+	// A new session is started during the outage, so the guild is in Ready
+	// again.
+	dispatch("READY", gateway.ReadyEvent{SessionID: "new", Guilds: conn.Ready.Guilds})
+	wait("READY", ready)
+
+	dispatch("GUILD_CREATE", gateway.GuildCreateEvent{Guild: discord.Guild{ID: 1}})
+
+	select {
+	case ev := <-events:
+		if _, ok := ev.(*state.GuildAvailableEvent); !ok {
+			t.Fatalf("Unexpected event %T", ev)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for the guild to be available")
+	}
+}
@@ -0,0 +1,218 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+)
+
+// VanityCodeUpdateEvent is dispatched by a VanityWatcher when the vanity URL
+// code of a guild changes. Old or New is empty if the vanity URL was added or
+// removed.
+type VanityCodeUpdateEvent struct {
+	GuildID discord.GuildID
+	Old     string
+	New     string
+}
+
+// VanityUsesUpdateEvent is dispatched by a VanityWatcher when the number of
+// times the vanity URL of a guild was used changes.
+type VanityUsesUpdateEvent struct {
+	GuildID discord.GuildID
+	Code    string
+	Old     int
+	New     int
+}
+
+// VanityWatcher watches the vanity URLs of guilds and dispatches
+// VanityCodeUpdateEvent and VanityUsesUpdateEvent into the State's handler.
+// Code changes are picked up from GUILD_UPDATE right away, while the number of
+// uses is only known by polling, as Discord doesn't send an event for it.
+//
+// The first time a guild is seen, its vanity URL is only recorded, so no
+// events are dispatched on startup.
+type VanityWatcher struct {
+	State *State
+	// GuildIDs are the guilds to watch. If empty, all guilds in the Store with
+	// the VANITY_URL feature are watched.
+	GuildIDs []discord.GuildID
+	// Interval is the time between polls. It defaults to 5 minutes.
+	Interval time.Duration
+	// ErrorLog is called when a guild can't be polled, for example because
+	// the bot is missing the MANAGE_GUILD permission.
+	ErrorLog func(err error)
+
+	mutex  sync.Mutex
+	vanity map[discord.GuildID]discord.Invite
+}
+
+// NewVanityWatcher creates a new VanityWatcher. If no guild IDs are given, all
+// guilds with the VANITY_URL feature are watched.
+func NewVanityWatcher(s *State, guildIDs ...discord.GuildID) *VanityWatcher {
+	return &VanityWatcher{
+		State:    s,
+		GuildIDs: guildIDs,
+		Interval: 5 * time.Minute,
+		vanity:   map[discord.GuildID]discord.Invite{},
+	}
+}
+
+// Run polls the vanity URLs right away, then on every interval until the
+// context is canceled. GUILD_UPDATE events are only watched while Run is
+// running.
+func (w *VanityWatcher) Run(ctx context.Context) error {
+	rm := w.State.AddHandler(func(ev *gateway.GuildUpdateEvent) {
+		// Guilds that are already recorded are still updated if they lost the
+		// VANITY_URL feature, so that the removal of the code is dispatched.
+		if _, ok := w.Vanity(ev.ID); ok || w.watches(discord.Guild(*ev)) {
+			w.update(ev.ID, ev.VanityURLCode, -1)
+		}
+	})
+	defer rm()
+
+	var interval = w.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.Poll(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Poll fetches the vanity URLs of all watched guilds once, and dispatches an
+// event for each change since the last poll.
+func (w *VanityWatcher) Poll(ctx context.Context) {
+	guildIDs, err := w.guildIDs()
+	if err != nil {
+		w.logError(errors.Wrap(err, "failed to get guilds"))
+		return
+	}
+
+	var client = w.State.WithContext(ctx)
+
+	for _, guildID := range guildIDs {
+		inv, err := client.GuildVanityURL(guildID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			w.logError(errors.Wrap(err, "failed to get vanity URL of "+guildID.String()))
+			continue
+		}
+
+		w.update(guildID, inv.Code, inv.Uses)
+	}
+}
+
+// Vanity returns the last known vanity URL of a guild. Only Code and Uses are
+// filled.
+func (w *VanityWatcher) Vanity(guildID discord.GuildID) (discord.Invite, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	inv, ok := w.vanity[guildID]
+	return inv, ok
+}
+
+// update records the vanity URL of a guild and dispatches the events for what
+// changed. A negative uses means the number of uses is unknown.
+func (w *VanityWatcher) update(guildID discord.GuildID, code string, uses int) {
+	w.mutex.Lock()
+
+	old, ok := w.vanity[guildID]
+	if !ok && uses < 0 {
+		// Wait for the first poll, so that the uses are known when the guild
+		// is first recorded.
+		w.mutex.Unlock()
+		return
+	}
+
+	var inv = old
+	inv.Code = code
+	if uses >= 0 {
+		inv.Uses = uses
+	}
+
+	if w.vanity == nil {
+		w.vanity = map[discord.GuildID]discord.Invite{}
+	}
+	w.vanity[guildID] = inv
+
+	w.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if old.Code != inv.Code {
+		w.State.Handler.Call(&VanityCodeUpdateEvent{
+			GuildID: guildID,
+			Old:     old.Code,
+			New:     inv.Code,
+		})
+	}
+
+	if old.Uses != inv.Uses {
+		w.State.Handler.Call(&VanityUsesUpdateEvent{
+			GuildID: guildID,
+			Code:    inv.Code,
+			Old:     old.Uses,
+			New:     inv.Uses,
+		})
+	}
+}
+
+func (w *VanityWatcher) guildIDs() ([]discord.GuildID, error) {
+	if len(w.GuildIDs) > 0 {
+		return w.GuildIDs, nil
+	}
+
+	guilds, err := w.State.Store.Guilds()
+	if err != nil {
+		return nil, err
+	}
+
+	var guildIDs = make([]discord.GuildID, 0, len(guilds))
+	for _, g := range guilds {
+		if g.HasFeature(discord.VanityURL) {
+			guildIDs = append(guildIDs, g.ID)
+		}
+	}
+
+	return guildIDs, nil
+}
+
+func (w *VanityWatcher) watches(g discord.Guild) bool {
+	if len(w.GuildIDs) == 0 {
+		return g.HasFeature(discord.VanityURL)
+	}
+
+	for _, id := range w.GuildIDs {
+		if id == g.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *VanityWatcher) logError(err error) {
+	if w.ErrorLog != nil {
+		w.ErrorLog(err)
+	}
+}
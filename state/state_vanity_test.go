@@ -0,0 +1,93 @@
+package state_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/api/apitest"
+	"github.com/diamondburned/arikawa/discord"
+	"github.com/diamondburned/arikawa/gateway"
+	"github.com/diamondburned/arikawa/state"
+)
+
+func TestVanityWatcher(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("GET", "/guilds/1/vanity-url", http.StatusOK, discord.Invite{
+		Code:           "arikawa",
+		InviteMetadata: discord.InviteMetadata{Uses: 5},
+	})
+
+	s := newTestState(t)
+	s.Client = srv.Client("Bot token")
+
+	if err := s.Store.GuildSet(&discord.Guild{
+		ID:            1,
+		Features:      []discord.GuildFeature{discord.VanityURL},
+		VanityURLCode: "arikawa",
+	}); err != nil {
+		t.Fatal("Failed to set guild:", err)
+	}
+
+	codes := make(chan *state.VanityCodeUpdateEvent, 2)
+	s.AddHandler(func(ev *state.VanityCodeUpdateEvent) { codes <- ev })
+
+	uses := make(chan *state.VanityUsesUpdateEvent, 1)
+	s.AddHandler(func(ev *state.VanityUsesUpdateEvent) { uses <- ev })
+
+	// The watcher isn't made by the constructor, so that struct literals are
+	// tested.
+	w := &state.VanityWatcher{State: s, Interval: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go w.Run(ctx)
+
+	// Wait for the first poll, which only records the vanity URL.
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		if _, ok := w.Vanity(1); ok {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("Timed out waiting for the first poll")
+		}
+	}
+
+	srv.Handle("GET", "/guilds/1/vanity-url", http.StatusOK, discord.Invite{
+		Code:           "arikawa",
+		InviteMetadata: discord.InviteMetadata{Uses: 7},
+	})
+	w.Poll(ctx)
+
+	select {
+	case ev := <-uses:
+		if ev.Old != 5 || ev.New != 7 || ev.Code != "arikawa" {
+			t.Fatal("Unexpected uses update:", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the uses update")
+	}
+
+	var updates = []*gateway.GuildUpdateEvent{
+		{ID: 1, Features: []discord.GuildFeature{discord.VanityURL}, VanityURLCode: "hime"},
+		// The guild lost its vanity URL.
+		{ID: 1},
+	}
+
+	for i, expect := range [][2]string{{"arikawa", "hime"}, {"hime", ""}} {
+		s.Handler.Call(updates[i])
+
+		select {
+		case ev := <-codes:
+			if ev.Old != expect[0] || ev.New != expect[1] {
+				t.Fatalf("Unexpected code update %q to %q, expected %q", ev.Old, ev.New, expect)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the code update")
+		}
+	}
+}
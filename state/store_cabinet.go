@@ -0,0 +1,42 @@
+package state
+
+// Cabinet combines per-resource stores into a single Store. This allows mixing
+// different store implementations, such as keeping messages in memory while
+// persisting members in a database:
+//
+//    cab := state.NewCabinet(state.NewDefaultStore(nil))
+//    cab.MemberStore = redisMemberStore
+//    cab.PresenceStore = state.NoopStore{}
+//
+//    s, err := state.NewWithStore(token, cab)
+//
+// All fields must be non-nil.
+type Cabinet struct {
+	MeStore
+	ChannelStore
+	EmojiStore
+	GuildStore
+	MemberStore
+	MessageStore
+	PresenceStore
+	RoleStore
+	VoiceStateStore
+}
+
+var _ Store = (*Cabinet)(nil)
+
+// NewCabinet creates a new Cabinet with all of its fields set to the given
+// store. The fields can then be replaced individually.
+func NewCabinet(store Store) *Cabinet {
+	return &Cabinet{
+		MeStore:         store,
+		ChannelStore:    store,
+		EmojiStore:      store,
+		GuildStore:      store,
+		MemberStore:     store,
+		MessageStore:    store,
+		PresenceStore:   store,
+		RoleStore:       store,
+		VoiceStateStore: store,
+	}
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/discord"
 )
@@ -15,22 +16,50 @@ type DefaultStore struct {
 	self discord.User
 
 	// includes normal and private
-	privates map[discord.Snowflake]*discord.Channel // channelID:channel
-	guilds   map[discord.Snowflake]*discord.Guild   // guildID:guild
+	privates map[discord.ChannelID]*discord.Channel // channelID:channel
+	guilds   map[discord.GuildID]*discord.Guild     // guildID:guild
 
-	channels    map[discord.Snowflake][]discord.Channel    // guildID:channels
-	members     map[discord.Snowflake][]discord.Member     // guildID:members
-	presences   map[discord.Snowflake][]discord.Presence   // guildID:presences
-	messages    map[discord.Snowflake][]discord.Message    // channelID:messages
-	voiceStates map[discord.Snowflake][]discord.VoiceState // guildID:voiceStates
+	channels    map[discord.GuildID][]discord.Channel    // guildID:channels
+	members     map[discord.GuildID][]discord.Member     // guildID:members
+	presences   map[discord.GuildID][]discord.Presence   // guildID:presences
+	messages    map[discord.ChannelID][]discord.Message  // channelID:messages
+	voiceStates map[discord.GuildID][]discord.VoiceState // guildID:voiceStates
+
+	// messageUses keeps track of when each message was last used, only if the
+	// eviction policy is EvictLeastRecentlyUsed.
+	messageUses map[discord.MessageID]uint64 // messageID:tick
+	messageTick uint64
 
 	mut sync.Mutex
 }
 
 type DefaultStoreOptions struct {
+	// MaxMessages is the maximum number of messages cached per channel. If
+	// this is 0, messages won't be cached at all.
 	MaxMessages uint // default 50
+
+	// MessageEviction is the policy used to pick which message to drop when a
+	// channel's cache is full.
+	MessageEviction MessageEviction // default EvictOldest
+
+	// MessageTTL, if non-zero, drops messages that were sent longer than the
+	// given duration ago.
+	MessageTTL time.Duration
 }
 
+// MessageEviction is the policy used by DefaultStore to evict messages when a
+// channel's message cache is full.
+type MessageEviction uint8
+
+const (
+	// EvictOldest drops the oldest message, which makes the cache act like a
+	// ring buffer.
+	EvictOldest MessageEviction = iota
+	// EvictLeastRecentlyUsed drops the message that was least recently added
+	// or fetched from the store.
+	EvictLeastRecentlyUsed
+)
+
 var _ Store = (*DefaultStore)(nil)
 
 func NewDefaultStore(opts *DefaultStoreOptions) *DefaultStore {
@@ -54,14 +83,17 @@ func (s *DefaultStore) Reset() error {
 
 	s.self = discord.User{}
 
-	s.privates = map[discord.Snowflake]*discord.Channel{}
-	s.guilds = map[discord.Snowflake]*discord.Guild{}
+	s.privates = map[discord.ChannelID]*discord.Channel{}
+	s.guilds = map[discord.GuildID]*discord.Guild{}
+
+	s.channels = map[discord.GuildID][]discord.Channel{}
+	s.members = map[discord.GuildID][]discord.Member{}
+	s.presences = map[discord.GuildID][]discord.Presence{}
+	s.messages = map[discord.ChannelID][]discord.Message{}
+	s.voiceStates = map[discord.GuildID][]discord.VoiceState{}
 
-	s.channels = map[discord.Snowflake][]discord.Channel{}
-	s.members = map[discord.Snowflake][]discord.Member{}
-	s.presences = map[discord.Snowflake][]discord.Presence{}
-	s.messages = map[discord.Snowflake][]discord.Message{}
-	s.voiceStates = map[discord.Snowflake][]discord.VoiceState{}
+	s.messageUses = map[discord.MessageID]uint64{}
+	s.messageTick = 0
 
 	return nil
 }
@@ -89,7 +121,7 @@ func (s *DefaultStore) MyselfSet(me *discord.User) error {
 
 ////
 
-func (s *DefaultStore) Channel(id discord.Snowflake) (*discord.Channel, error) {
+func (s *DefaultStore) Channel(id discord.ChannelID) (*discord.Channel, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -108,7 +140,7 @@ func (s *DefaultStore) Channel(id discord.Snowflake) (*discord.Channel, error) {
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Channels(guildID discord.Snowflake) ([]discord.Channel, error) {
+func (s *DefaultStore) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -122,7 +154,7 @@ func (s *DefaultStore) Channels(guildID discord.Snowflake) ([]discord.Channel, e
 
 // CreatePrivateChannel searches in the cache for a private channel. It makes no
 // API calls.
-func (s *DefaultStore) CreatePrivateChannel(recipient discord.Snowflake) (*discord.Channel, error) {
+func (s *DefaultStore) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -205,7 +237,7 @@ func (s *DefaultStore) ChannelRemove(channel *discord.Channel) error {
 
 ////
 
-func (s *DefaultStore) Emoji(guildID, emojiID discord.Snowflake) (*discord.Emoji, error) {
+func (s *DefaultStore) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -223,7 +255,7 @@ func (s *DefaultStore) Emoji(guildID, emojiID discord.Snowflake) (*discord.Emoji
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Emojis(guildID discord.Snowflake) ([]discord.Emoji, error) {
+func (s *DefaultStore) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -235,7 +267,7 @@ func (s *DefaultStore) Emojis(guildID discord.Snowflake) ([]discord.Emoji, error
 	return append([]discord.Emoji{}, gd.Emojis...), nil
 }
 
-func (s *DefaultStore) EmojiSet(guildID discord.Snowflake, emojis []discord.Emoji) error {
+func (s *DefaultStore) EmojiSet(guildID discord.GuildID, emojis []discord.Emoji) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -269,7 +301,7 @@ Main:
 
 ////
 
-func (s *DefaultStore) Guild(id discord.Snowflake) (*discord.Guild, error) {
+func (s *DefaultStore) Guild(id discord.GuildID) (*discord.Guild, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -315,13 +347,16 @@ func (s *DefaultStore) GuildSet(guild *discord.Guild) error {
 		if guild.Emojis == nil {
 			guild.Emojis = g.Emojis
 		}
+		if guild.Stickers == nil {
+			guild.Stickers = g.Stickers
+		}
 	}
 
 	s.guilds[guild.ID] = guild
 	return nil
 }
 
-func (s *DefaultStore) GuildRemove(id discord.Snowflake) error {
+func (s *DefaultStore) GuildRemove(id discord.GuildID) error {
 	s.mut.Lock()
 	delete(s.guilds, id)
 	s.mut.Unlock()
@@ -331,7 +366,7 @@ func (s *DefaultStore) GuildRemove(id discord.Snowflake) error {
 
 ////
 
-func (s *DefaultStore) Member(guildID, userID discord.Snowflake) (*discord.Member, error) {
+func (s *DefaultStore) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -349,7 +384,7 @@ func (s *DefaultStore) Member(guildID, userID discord.Snowflake) (*discord.Membe
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Members(guildID discord.Snowflake) ([]discord.Member, error) {
+func (s *DefaultStore) Members(guildID discord.GuildID) ([]discord.Member, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -361,7 +396,7 @@ func (s *DefaultStore) Members(guildID discord.Snowflake) ([]discord.Member, err
 	return append([]discord.Member{}, ms...), nil
 }
 
-func (s *DefaultStore) MemberSet(guildID discord.Snowflake, member *discord.Member) error {
+func (s *DefaultStore) MemberSet(guildID discord.GuildID, member *discord.Member) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -385,7 +420,7 @@ func (s *DefaultStore) MemberSet(guildID discord.Snowflake, member *discord.Memb
 	return nil
 }
 
-func (s *DefaultStore) MemberRemove(guildID, userID discord.Snowflake) error {
+func (s *DefaultStore) MemberRemove(guildID discord.GuildID, userID discord.UserID) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -409,7 +444,7 @@ func (s *DefaultStore) MemberRemove(guildID, userID discord.Snowflake) error {
 
 ////
 
-func (s *DefaultStore) Message(channelID, messageID discord.Snowflake) (*discord.Message, error) {
+func (s *DefaultStore) Message(channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -418,8 +453,11 @@ func (s *DefaultStore) Message(channelID, messageID discord.Snowflake) (*discord
 		return nil, ErrStoreNotFound
 	}
 
+	ms = s.expireMessages(channelID, ms)
+
 	for _, m := range ms {
 		if m.ID == messageID {
+			s.useMessage(m.ID)
 			return &m, nil
 		}
 	}
@@ -427,7 +465,7 @@ func (s *DefaultStore) Message(channelID, messageID discord.Snowflake) (*discord
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Messages(channelID discord.Snowflake) ([]discord.Message, error) {
+func (s *DefaultStore) Messages(channelID discord.ChannelID) ([]discord.Message, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -436,6 +474,8 @@ func (s *DefaultStore) Messages(channelID discord.Snowflake) ([]discord.Message,
 		return nil, ErrStoreNotFound
 	}
 
+	ms = s.expireMessages(channelID, ms)
+
 	cp := make([]discord.Message, len(ms))
 	copy(cp, ms)
 	return cp, nil
@@ -446,6 +486,11 @@ func (s *DefaultStore) MaxMessages() int {
 }
 
 func (s *DefaultStore) MessageSet(message *discord.Message) error {
+	// Message caching is disabled.
+	if s.MaxMessages() == 0 {
+		return nil
+	}
+
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -454,11 +499,14 @@ func (s *DefaultStore) MessageSet(message *discord.Message) error {
 		ms = make([]discord.Message, 0, s.MaxMessages()+1)
 	}
 
+	ms = s.expireMessages(message.ChannelID, ms)
+
 	// Check if we already have the message.
 	for i, m := range ms {
 		if m.ID == message.ID {
 			DiffMessage(*message, &m)
 			ms[i] = m
+			s.useMessage(m.ID)
 			return nil
 		}
 	}
@@ -469,6 +517,17 @@ func (s *DefaultStore) MessageSet(message *discord.Message) error {
 	if max := s.MaxMessages(); end >= max {
 		// If the end (length) is larger than the maximum amount, then cap it.
 		end = max
+
+		// Make room by evicting a message according to the policy. The
+		// oldest message is at the end, which is overridden below.
+		var evict = end - 1
+		if s.MessageEviction == EvictLeastRecentlyUsed {
+			evict = s.leastUsedMessage(ms[:end])
+		}
+
+		delete(s.messageUses, ms[evict].ID)
+		// Move the evicted message to the end to be overridden.
+		copy(ms[evict:end], ms[evict+1:end])
 	} else {
 		// Else, append an empty message to the end.
 		ms = append(ms, discord.Message{})
@@ -481,12 +540,63 @@ func (s *DefaultStore) MessageSet(message *discord.Message) error {
 	copy(ms[1:end], ms[0:end-1])
 	// Then, set the 0th entry.
 	ms[0] = *message
+	s.useMessage(message.ID)
 
-	s.messages[message.ChannelID] = ms
+	s.messages[message.ChannelID] = ms[:end]
 	return nil
 }
 
-func (s *DefaultStore) MessageRemove(channelID, messageID discord.Snowflake) error {
+// expireMessages drops messages older than MessageTTL. The mutex must be held.
+func (s *DefaultStore) expireMessages(
+	channelID discord.ChannelID, ms []discord.Message) []discord.Message {
+
+	if s.MessageTTL <= 0 {
+		return ms
+	}
+
+	var deadline = time.Now().Add(-s.MessageTTL)
+	var end = len(ms)
+
+	// Messages are ordered from latest to earliest, so the expired messages
+	// are at the end.
+	for end > 0 && ms[end-1].ID.Time().Before(deadline) {
+		delete(s.messageUses, ms[end-1].ID)
+		end--
+	}
+
+	if end != len(ms) {
+		ms = ms[:end]
+		s.messages[channelID] = ms
+	}
+
+	return ms
+}
+
+// useMessage marks the message as recently used. The mutex must be held.
+func (s *DefaultStore) useMessage(messageID discord.MessageID) {
+	if s.MessageEviction != EvictLeastRecentlyUsed {
+		return
+	}
+
+	s.messageTick++
+	s.messageUses[messageID] = s.messageTick
+}
+
+// leastUsedMessage returns the index of the least recently used message. The
+// mutex must be held.
+func (s *DefaultStore) leastUsedMessage(ms []discord.Message) int {
+	var least = len(ms) - 1
+
+	for i := range ms {
+		if s.messageUses[ms[i].ID] < s.messageUses[ms[least].ID] {
+			least = i
+		}
+	}
+
+	return least
+}
+
+func (s *DefaultStore) MessageRemove(channelID discord.ChannelID, messageID discord.MessageID) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -499,6 +609,7 @@ func (s *DefaultStore) MessageRemove(channelID, messageID discord.Snowflake) err
 		if m.ID == messageID {
 			ms = append(ms[:i], ms[i+1:]...)
 			s.messages[channelID] = ms
+			delete(s.messageUses, messageID)
 			return nil
 		}
 	}
@@ -508,7 +619,7 @@ func (s *DefaultStore) MessageRemove(channelID, messageID discord.Snowflake) err
 
 ////
 
-func (s *DefaultStore) Presence(guildID, userID discord.Snowflake) (*discord.Presence, error) {
+func (s *DefaultStore) Presence(guildID discord.GuildID, userID discord.UserID) (*discord.Presence, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -526,7 +637,7 @@ func (s *DefaultStore) Presence(guildID, userID discord.Snowflake) (*discord.Pre
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Presences(guildID discord.Snowflake) ([]discord.Presence, error) {
+func (s *DefaultStore) Presences(guildID discord.GuildID) ([]discord.Presence, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -538,7 +649,7 @@ func (s *DefaultStore) Presences(guildID discord.Snowflake) ([]discord.Presence,
 	return ps, nil
 }
 
-func (s *DefaultStore) PresenceSet(guildID discord.Snowflake, presence *discord.Presence) error {
+func (s *DefaultStore) PresenceSet(guildID discord.GuildID, presence *discord.Presence) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -558,7 +669,7 @@ func (s *DefaultStore) PresenceSet(guildID discord.Snowflake, presence *discord.
 	return nil
 }
 
-func (s *DefaultStore) PresenceRemove(guildID, userID discord.Snowflake) error {
+func (s *DefaultStore) PresenceRemove(guildID discord.GuildID, userID discord.UserID) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -581,7 +692,7 @@ func (s *DefaultStore) PresenceRemove(guildID, userID discord.Snowflake) error {
 
 ////
 
-func (s *DefaultStore) Role(guildID, roleID discord.Snowflake) (*discord.Role, error) {
+func (s *DefaultStore) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -599,7 +710,7 @@ func (s *DefaultStore) Role(guildID, roleID discord.Snowflake) (*discord.Role, e
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) Roles(guildID discord.Snowflake) ([]discord.Role, error) {
+func (s *DefaultStore) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -611,7 +722,7 @@ func (s *DefaultStore) Roles(guildID discord.Snowflake) ([]discord.Role, error)
 	return append([]discord.Role{}, gd.Roles...), nil
 }
 
-func (s *DefaultStore) RoleSet(guildID discord.Snowflake, role *discord.Role) error {
+func (s *DefaultStore) RoleSet(guildID discord.GuildID, role *discord.Role) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -631,7 +742,7 @@ func (s *DefaultStore) RoleSet(guildID discord.Snowflake, role *discord.Role) er
 	return nil
 }
 
-func (s *DefaultStore) RoleRemove(guildID, roleID discord.Snowflake) error {
+func (s *DefaultStore) RoleRemove(guildID discord.GuildID, roleID discord.RoleID) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -652,7 +763,7 @@ func (s *DefaultStore) RoleRemove(guildID, roleID discord.Snowflake) error {
 
 ////
 
-func (s *DefaultStore) VoiceState(guildID, userID discord.Snowflake) (*discord.VoiceState, error) {
+func (s *DefaultStore) VoiceState(guildID discord.GuildID, userID discord.UserID) (*discord.VoiceState, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -670,7 +781,7 @@ func (s *DefaultStore) VoiceState(guildID, userID discord.Snowflake) (*discord.V
 	return nil, ErrStoreNotFound
 }
 
-func (s *DefaultStore) VoiceStates(guildID discord.Snowflake) ([]discord.VoiceState, error) {
+func (s *DefaultStore) VoiceStates(guildID discord.GuildID) ([]discord.VoiceState, error) {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -682,7 +793,7 @@ func (s *DefaultStore) VoiceStates(guildID discord.Snowflake) ([]discord.VoiceSt
 	return append([]discord.VoiceState{}, states...), nil
 }
 
-func (s *DefaultStore) VoiceStateSet(guildID discord.Snowflake, voiceState *discord.VoiceState) error {
+func (s *DefaultStore) VoiceStateSet(guildID discord.GuildID, voiceState *discord.VoiceState) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
@@ -702,7 +813,7 @@ func (s *DefaultStore) VoiceStateSet(guildID discord.Snowflake, voiceState *disc
 	return nil
 }
 
-func (s *DefaultStore) VoiceStateRemove(guildID, userID discord.Snowflake) error {
+func (s *DefaultStore) VoiceStateRemove(guildID discord.GuildID, userID discord.UserID) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
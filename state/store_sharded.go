@@ -0,0 +1,318 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+// ShardedStore partitions guild data into one Store per shard, which reduces
+// lock contention when many shards run in the same process, and allows a
+// shard's cache to be dropped when the shard is restarted. Guilds are assigned
+// to shards with the same formula Discord uses, so each partition holds the
+// guilds of exactly one shard.
+//
+// Data that doesn't belong to a guild, such as the current user and private
+// channels, is kept in the first partition.
+type ShardedStore struct {
+	newStore func() Store
+
+	mutex  sync.RWMutex
+	shards []Store
+
+	// channels maps channel IDs to guild IDs, since channel and message
+	// lookups don't have the guild ID.
+	channels sync.Map
+}
+
+var _ Store = (*ShardedStore)(nil)
+
+// NewShardedStore creates a new ShardedStore with numShards partitions, each
+// created with newStore.
+func NewShardedStore(numShards int, newStore func() Store) *ShardedStore {
+	if numShards < 1 {
+		numShards = 1
+	}
+
+	var shards = make([]Store, numShards)
+	for i := range shards {
+		shards[i] = newStore()
+	}
+
+	return &ShardedStore{
+		newStore: newStore,
+		shards:   shards,
+	}
+}
+
+// ShardID returns the ID of the shard that receives the events of the given
+// guild, out of numShards shards.
+func ShardID(guildID discord.GuildID, numShards int) int {
+	return int((uint64(guildID) >> 22) % uint64(numShards))
+}
+
+// NumShards returns the number of partitions, which is the number of shards.
+func (s *ShardedStore) NumShards() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.shards)
+}
+
+// Shard returns the Store of the given shard, or nil if there's no such shard.
+func (s *ShardedStore) Shard(shardID int) Store {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if shardID < 0 || shardID >= len(s.shards) {
+		return nil
+	}
+	return s.shards[shardID]
+}
+
+// ResetShard drops the whole cache of a shard by replacing its Store with a new
+// one. This should be called when the shard is restarted without resuming, as
+// the shard will receive all of its guilds again.
+func (s *ShardedStore) ResetShard(shardID int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if shardID < 0 || shardID >= len(s.shards) {
+		return
+	}
+
+	var store = s.newStore()
+
+	// Keep the data that doesn't belong to any guild.
+	if shardID == 0 {
+		if me, err := s.shards[0].Me(); err == nil {
+			store.MyselfSet(me)
+		}
+
+		if chs, err := s.shards[0].PrivateChannels(); err == nil {
+			for i := range chs {
+				store.ChannelSet(&chs[i])
+			}
+		}
+	}
+
+	s.shards[shardID] = store
+
+	s.channels.Range(func(k, v interface{}) bool {
+		if guildID := v.(discord.GuildID); guildID.Valid() &&
+			ShardID(guildID, len(s.shards)) == shardID {
+
+			s.channels.Delete(k)
+		}
+		return true
+	})
+}
+
+// guild returns the Store of the given guild. An invalid guild ID returns the
+// first partition.
+func (s *ShardedStore) guild(guildID discord.GuildID) Store {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if !guildID.Valid() {
+		return s.shards[0]
+	}
+	return s.shards[ShardID(guildID, len(s.shards))]
+}
+
+// channel returns the Store of the given channel.
+func (s *ShardedStore) channel(channelID discord.ChannelID) Store {
+	if v, ok := s.channels.Load(channelID); ok {
+		return s.guild(v.(discord.GuildID))
+	}
+	return s.guild(0)
+}
+
+// all returns all partitions.
+func (s *ShardedStore) all() []Store {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]Store(nil), s.shards...)
+}
+
+////
+
+func (s *ShardedStore) Me() (*discord.User, error) {
+	return s.guild(0).Me()
+}
+
+func (s *ShardedStore) MyselfSet(me *discord.User) error {
+	return s.guild(0).MyselfSet(me)
+}
+
+////
+
+func (s *ShardedStore) Channel(id discord.ChannelID) (*discord.Channel, error) {
+	return s.channel(id).Channel(id)
+}
+
+func (s *ShardedStore) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
+	return s.guild(guildID).Channels(guildID)
+}
+
+func (s *ShardedStore) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel, error) {
+	return s.guild(0).CreatePrivateChannel(recipient)
+}
+
+func (s *ShardedStore) PrivateChannels() ([]discord.Channel, error) {
+	return s.guild(0).PrivateChannels()
+}
+
+func (s *ShardedStore) ChannelSet(channel *discord.Channel) error {
+	s.channels.Store(channel.ID, channel.GuildID)
+	return s.guild(channel.GuildID).ChannelSet(channel)
+}
+
+func (s *ShardedStore) ChannelRemove(channel *discord.Channel) error {
+	s.channels.Delete(channel.ID)
+	return s.guild(channel.GuildID).ChannelRemove(channel)
+}
+
+////
+
+func (s *ShardedStore) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*discord.Emoji, error) {
+	return s.guild(guildID).Emoji(guildID, emojiID)
+}
+
+func (s *ShardedStore) Emojis(guildID discord.GuildID) ([]discord.Emoji, error) {
+	return s.guild(guildID).Emojis(guildID)
+}
+
+func (s *ShardedStore) EmojiSet(guildID discord.GuildID, emojis []discord.Emoji) error {
+	return s.guild(guildID).EmojiSet(guildID, emojis)
+}
+
+////
+
+func (s *ShardedStore) Guild(id discord.GuildID) (*discord.Guild, error) {
+	return s.guild(id).Guild(id)
+}
+
+func (s *ShardedStore) Guilds() ([]discord.Guild, error) {
+	var guilds []discord.Guild
+
+	for _, store := range s.all() {
+		g, err := store.Guilds()
+		if err != nil {
+			return nil, err
+		}
+		guilds = append(guilds, g...)
+	}
+
+	return guilds, nil
+}
+
+func (s *ShardedStore) GuildSet(guild *discord.Guild) error {
+	return s.guild(guild.ID).GuildSet(guild)
+}
+
+func (s *ShardedStore) GuildRemove(id discord.GuildID) error {
+	s.channels.Range(func(k, v interface{}) bool {
+		if v.(discord.GuildID) == id {
+			s.channels.Delete(k)
+		}
+		return true
+	})
+
+	return s.guild(id).GuildRemove(id)
+}
+
+////
+
+func (s *ShardedStore) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
+	return s.guild(guildID).Member(guildID, userID)
+}
+
+func (s *ShardedStore) Members(guildID discord.GuildID) ([]discord.Member, error) {
+	return s.guild(guildID).Members(guildID)
+}
+
+func (s *ShardedStore) MemberSet(guildID discord.GuildID, member *discord.Member) error {
+	return s.guild(guildID).MemberSet(guildID, member)
+}
+
+func (s *ShardedStore) MemberRemove(guildID discord.GuildID, userID discord.UserID) error {
+	return s.guild(guildID).MemberRemove(guildID, userID)
+}
+
+////
+
+func (s *ShardedStore) Message(channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
+	return s.channel(channelID).Message(channelID, messageID)
+}
+
+func (s *ShardedStore) Messages(channelID discord.ChannelID) ([]discord.Message, error) {
+	return s.channel(channelID).Messages(channelID)
+}
+
+func (s *ShardedStore) MaxMessages() int {
+	return s.guild(0).MaxMessages()
+}
+
+func (s *ShardedStore) MessageSet(message *discord.Message) error {
+	return s.channel(message.ChannelID).MessageSet(message)
+}
+
+func (s *ShardedStore) MessageRemove(channelID discord.ChannelID, messageID discord.MessageID) error {
+	return s.channel(channelID).MessageRemove(channelID, messageID)
+}
+
+////
+
+func (s *ShardedStore) Presence(guildID discord.GuildID, userID discord.UserID) (*discord.Presence, error) {
+	return s.guild(guildID).Presence(guildID, userID)
+}
+
+func (s *ShardedStore) Presences(guildID discord.GuildID) ([]discord.Presence, error) {
+	return s.guild(guildID).Presences(guildID)
+}
+
+func (s *ShardedStore) PresenceSet(guildID discord.GuildID, presence *discord.Presence) error {
+	return s.guild(guildID).PresenceSet(guildID, presence)
+}
+
+func (s *ShardedStore) PresenceRemove(guildID discord.GuildID, userID discord.UserID) error {
+	return s.guild(guildID).PresenceRemove(guildID, userID)
+}
+
+////
+
+func (s *ShardedStore) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
+	return s.guild(guildID).Role(guildID, roleID)
+}
+
+func (s *ShardedStore) Roles(guildID discord.GuildID) ([]discord.Role, error) {
+	return s.guild(guildID).Roles(guildID)
+}
+
+func (s *ShardedStore) RoleSet(guildID discord.GuildID, role *discord.Role) error {
+	return s.guild(guildID).RoleSet(guildID, role)
+}
+
+func (s *ShardedStore) RoleRemove(guildID discord.GuildID, roleID discord.RoleID) error {
+	return s.guild(guildID).RoleRemove(guildID, roleID)
+}
+
+////
+
+func (s *ShardedStore) VoiceState(guildID discord.GuildID, userID discord.UserID) (*discord.VoiceState, error) {
+	return s.guild(guildID).VoiceState(guildID, userID)
+}
+
+func (s *ShardedStore) VoiceStates(guildID discord.GuildID) ([]discord.VoiceState, error) {
+	return s.guild(guildID).VoiceStates(guildID)
+}
+
+func (s *ShardedStore) VoiceStateSet(guildID discord.GuildID, voiceState *discord.VoiceState) error {
+	return s.guild(guildID).VoiceStateSet(guildID, voiceState)
+}
+
+func (s *ShardedStore) VoiceStateRemove(guildID discord.GuildID, userID discord.UserID) error {
+	return s.guild(guildID).VoiceStateRemove(guildID, userID)
+}
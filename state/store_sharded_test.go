@@ -0,0 +1,107 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/discord"
+)
+
+func newTestShardedStore() *ShardedStore {
+	return NewShardedStore(2, func() Store { return NewDefaultStore(nil) })
+}
+
+func shardedChannels(s *ShardedStore) int {
+	var n int
+	s.channels.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func TestShardedStoreRouting(t *testing.T) {
+	const (
+		guild0 discord.GuildID = 2 << 22 // shard 0
+		guild1 discord.GuildID = 1 << 22 // shard 1
+	)
+
+	s := newTestShardedStore()
+
+	if err := s.GuildSet(&discord.Guild{ID: guild1}); err != nil {
+		t.Fatal("Failed to set guild:", err)
+	}
+	if err := s.ChannelSet(&discord.Channel{ID: 10, GuildID: guild1}); err != nil {
+		t.Fatal("Failed to set channel:", err)
+	}
+	if err := s.ChannelSet(&discord.Channel{ID: 20, GuildID: guild0}); err != nil {
+		t.Fatal("Failed to set channel:", err)
+	}
+
+	if _, err := s.Shard(1).Guild(guild1); err != nil {
+		t.Fatal("Guild is not in its shard:", err)
+	}
+	if _, err := s.Shard(0).Guild(guild1); err == nil {
+		t.Fatal("Guild is in the wrong shard")
+	}
+
+	// Messages are routed by their channel's guild.
+	if err := s.MessageSet(&discord.Message{ID: 1, ChannelID: 10, GuildID: guild1}); err != nil {
+		t.Fatal("Failed to set message:", err)
+	}
+	if _, err := s.Shard(1).Message(10, 1); err != nil {
+		t.Fatal("Message is not in its channel's shard:", err)
+	}
+	if _, err := s.Message(10, 1); err != nil {
+		t.Fatal("Failed to get message:", err)
+	}
+
+	s.ResetShard(1)
+
+	if _, err := s.Guild(guild1); err == nil {
+		t.Fatal("Reset shard still has the guild")
+	}
+	if _, err := s.Channel(20); err != nil {
+		t.Fatal("Other shard lost its channel:", err)
+	}
+	if n := shardedChannels(s); n != 1 {
+		t.Fatal("Unexpected number of channels after the reset:", n)
+	}
+}
+
+func TestShardedStoreRemove(t *testing.T) {
+	const guildID discord.GuildID = 1 << 22
+
+	s := newTestShardedStore()
+
+	if err := s.GuildSet(&discord.Guild{ID: guildID}); err != nil {
+		t.Fatal("Failed to set guild:", err)
+	}
+
+	var chs = []discord.Channel{
+		{ID: 10, GuildID: guildID},
+		{ID: 11, GuildID: guildID},
+		{ID: 12, GuildID: 2 << 22},
+	}
+	for i := range chs {
+		if err := s.ChannelSet(&chs[i]); err != nil {
+			t.Fatal("Failed to set channel:", err)
+		}
+	}
+
+	if err := s.ChannelRemove(&chs[0]); err != nil {
+		t.Fatal("Failed to remove channel:", err)
+	}
+	if n := shardedChannels(s); n != 2 {
+		t.Fatal("Removed channel was not pruned:", n)
+	}
+
+	if err := s.GuildRemove(guildID); err != nil {
+		t.Fatal("Failed to remove guild:", err)
+	}
+	if _, ok := s.channels.Load(discord.ChannelID(11)); ok {
+		t.Fatal("Channel of the removed guild was not pruned")
+	}
+	if _, ok := s.channels.Load(discord.ChannelID(12)); !ok {
+		t.Fatal("Channel of another guild was pruned")
+	}
+}
@@ -0,0 +1,280 @@
+// Package assetcache downloads and caches CDN assets, such as avatars, emojis
+// and attachments, on disk. Files are content-addressed by the SHA-256 hash of
+// their URL, so the same asset is only ever downloaded once, and the cache is
+// kept under a size bound by evicting the least recently used files.
+package assetcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MaxAssetSize is the default maximum size of a single asset, which is
+// Discord's attachment limit for Nitro users.
+const MaxAssetSize = 100 * 1024 * 1024
+
+// ErrTooLarge is returned if an asset is larger than MaxAssetSize, or larger
+// than the cache itself.
+var ErrTooLarge = errors.New("asset is too large")
+
+// Cache is an on-disk cache of CDN assets. The zero value is not usable; use
+// New instead.
+type Cache struct {
+	// Client is the HTTP client used to download assets.
+	Client *http.Client
+	// MaxAssetSize is the maximum size of a single asset.
+	MaxAssetSize int64
+
+	dir     string
+	maxSize int64
+
+	mutex sync.Mutex
+	size  int64
+	lru   *list.List // of *entry, most recently used first
+	files map[string]*list.Element
+	// downloading contains a channel for each asset being downloaded, which is
+	// closed once the download is done.
+	downloading map[string]chan struct{}
+}
+
+type entry struct {
+	key  string
+	size int64
+}
+
+// New creates a new cache in the given directory, creating it if needed. The
+// cache will hold at most maxSize bytes; if maxSize is 0 or less, the cache is
+// unbounded. Files already in the directory are picked up.
+func New(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create cache directory")
+	}
+
+	c := &Cache{
+		Client:       http.DefaultClient,
+		MaxAssetSize: MaxAssetSize,
+
+		dir:         dir,
+		maxSize:     maxSize,
+		lru:         list.New(),
+		files:       map[string]*list.Element{},
+		downloading: map[string]chan struct{}{},
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read cache directory")
+	}
+
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || len(info.Name()) != sha256.Size*2 {
+			continue
+		}
+
+		c.files[info.Name()] = c.lru.PushBack(&entry{info.Name(), info.Size()})
+		c.size += info.Size()
+	}
+
+	c.mutex.Lock()
+	c.evict()
+	c.mutex.Unlock()
+
+	return c, nil
+}
+
+// Key returns the key of the given URL, which is also its file name.
+func Key(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// Size returns the total size of the cached assets.
+func (c *Cache) Size() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.size
+}
+
+// Path returns the path to the cached asset of the given URL, downloading it
+// first if it's not cached. The file may be evicted by later calls, so callers
+// that need it to stay around should use Open instead.
+func (c *Cache) Path(ctx context.Context, url string) (string, error) {
+	var key = Key(url)
+
+	for {
+		c.mutex.Lock()
+
+		if e, ok := c.files[key]; ok {
+			c.lru.MoveToFront(e)
+			c.mutex.Unlock()
+			return c.path(key), nil
+		}
+
+		// Wait for any concurrent download of the same asset.
+		if wait, ok := c.downloading[key]; ok {
+			c.mutex.Unlock()
+
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		done := make(chan struct{})
+		c.downloading[key] = done
+		c.mutex.Unlock()
+
+		size, err := c.download(ctx, url, key)
+
+		c.mutex.Lock()
+		delete(c.downloading, key)
+		close(done)
+
+		if err == nil {
+			c.files[key] = c.lru.PushFront(&entry{key, size})
+			c.size += size
+			c.evict()
+		}
+
+		c.mutex.Unlock()
+
+		if err != nil {
+			return "", err
+		}
+
+		return c.path(key), nil
+	}
+}
+
+// Open returns a reader of the asset of the given URL, downloading it first if
+// it's not cached. The caller must close the file. On most platforms, the file
+// stays readable even if it's evicted while open.
+func (c *Cache) Open(ctx context.Context, url string) (*os.File, error) {
+	p, err := c.Path(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(p)
+}
+
+// Remove removes the asset of the given URL from the cache.
+func (c *Cache) Remove(url string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.files[Key(url)]
+	if !ok {
+		return nil
+	}
+
+	return c.remove(e)
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+func (c *Cache) download(ctx context.Context, url, key string) (int64, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request")
+	}
+
+	r, err := c.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to download asset")
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode < 200 || r.StatusCode > 299 {
+		return 0, fmt.Errorf("unexpected status code %d", r.StatusCode)
+	}
+
+	var max = c.MaxAssetSize
+	if c.maxSize > 0 && (max <= 0 || c.maxSize < max) {
+		max = c.maxSize
+	}
+
+	if max > 0 && r.ContentLength > max {
+		return 0, ErrTooLarge
+	}
+
+	// Download into a temporary file first, so that a failed download never
+	// leaves a partial file behind under the final name.
+	f, err := ioutil.TempFile(c.dir, "download-")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(f.Name())
+
+	var body io.Reader = r.Body
+	if max > 0 {
+		body = io.LimitReader(r.Body, max+1)
+	}
+
+	n, err := io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to download asset")
+	}
+
+	if max > 0 && n > max {
+		return 0, ErrTooLarge
+	}
+
+	if err := os.Rename(f.Name(), c.path(key)); err != nil {
+		return 0, errors.Wrap(err, "failed to move downloaded asset")
+	}
+
+	return n, nil
+}
+
+// evict removes the least recently used assets until the cache is within its
+// size bound. It must be called with the mutex acquired.
+func (c *Cache) evict() {
+	if c.maxSize <= 0 {
+		return
+	}
+
+	for c.size > c.maxSize {
+		e := c.lru.Back()
+		if e == nil {
+			return
+		}
+
+		// There's nothing better to do with the error than to forget about
+		// the file.
+		c.remove(e)
+	}
+}
+
+func (c *Cache) remove(e *list.Element) error {
+	entry := e.Value.(*entry)
+
+	c.lru.Remove(e)
+	delete(c.files, entry.key)
+	c.size -= entry.size
+
+	if err := os.Remove(c.path(entry.key)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove asset")
+	}
+
+	return nil
+}
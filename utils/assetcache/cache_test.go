@@ -0,0 +1,102 @@
+package assetcache
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	var hits int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(strings.Repeat("a", 10)))
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "assetcache")
+	if err != nil {
+		t.Fatal("Failed to create directory:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := New(dir, 25)
+	if err != nil {
+		t.Fatal("Failed to create cache:", err)
+	}
+
+	ctx := context.Background()
+
+	urls := []string{srv.URL + "/1.png", srv.URL + "/2.png", srv.URL + "/3.png"}
+
+	for _, url := range urls {
+		p, err := c.Path(ctx, url)
+		if err != nil {
+			t.Fatal("Failed to get asset:", err)
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil || len(b) != 10 {
+			t.Fatal("Unexpected cached file:", len(b), err)
+		}
+	}
+
+	if size := c.Size(); size != 20 {
+		t.Fatal("Unexpected cache size:", size)
+	}
+
+	// The first asset should've been evicted, but not the last.
+	if _, err := os.Stat(c.path(Key(urls[0]))); !os.IsNotExist(err) {
+		t.Fatal("Least recently used asset was not evicted:", err)
+	}
+
+	if _, err := c.Path(ctx, urls[2]); err != nil {
+		t.Fatal("Failed to get asset:", err)
+	}
+
+	if hits := atomic.LoadInt32(&hits); hits != 3 {
+		t.Fatal("Unexpected number of downloads:", hits)
+	}
+
+	// Reopening the cache should pick up the existing files.
+	c, err = New(dir, 25)
+	if err != nil {
+		t.Fatal("Failed to reopen cache:", err)
+	}
+
+	if size := c.Size(); size != 20 {
+		t.Fatal("Unexpected size of reopened cache:", size)
+	}
+}
+
+func TestCacheTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "assetcache")
+	if err != nil {
+		t.Fatal("Failed to create directory:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := New(dir, 50)
+	if err != nil {
+		t.Fatal("Failed to create cache:", err)
+	}
+
+	if _, err := c.Path(context.Background(), srv.URL); err != ErrTooLarge {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if infos, _ := ioutil.ReadDir(dir); len(infos) != 0 {
+		t.Fatal("Unexpected leftover files:", len(infos))
+	}
+}
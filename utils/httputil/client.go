@@ -6,7 +6,12 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
+	"math/rand"
 	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -28,21 +33,84 @@ type Client struct {
 	// OnRequest, if not nil, will be copied and prefixed on each Request.
 	OnRequest []RequestOption
 
+	// OnSend is called right before every Do() call, after OnRequest and the
+	// request's own options are applied. Unlike with those, OnResponse is
+	// always called if OnSend succeeds, so it's where resources that are
+	// released in OnResponse should be acquired, such as rate limits.
+	OnSend []RequestOption
+
 	// OnResponse is called after every Do() call. Response might be nil if Do()
-	// errors out. The error returned will override Do's if it's not nil.
+	// errors out. All functions are called, and the first error returned will
+	// override Do's if it's not nil.
 	OnResponse []ResponseFunc
 
 	// Default to the global Retries variable (5).
 	Retries uint
 
+	// Backoff returns the duration to wait before retrying a request that
+	// failed because of a network error or a 5xx status. The attempt starts
+	// at 0. Requests that are rate limited are not delayed by Backoff, as the
+	// rate limiter already waits for them, unless the 429 response says
+	// neither in its headers nor its body how long to wait. Defaults to
+	// DefaultBackoff; a nil Backoff retries immediately.
+	Backoff func(attempt uint) time.Duration
+
+	// Logger, if not nil, is called after every attempt of every request.
+	// Bodies are only logged if they're JSON, and the response is only passed
+	// to the Logger after being fully read.
+	Logger func(RequestLog)
+	// Redact removes secrets from a RequestLog before it's given to the
+	// Logger. Defaults to DefaultRedact if nil. Custom functions can call
+	// DefaultRedact to extend it.
+	Redact func(*RequestLog)
+
+	// JSON is the driver that decodes responses. It defaults to json.Default
+	// if nil. Refer to JSONDriver.
+	JSON json.Driver
+
 	context context.Context
 }
 
+// JSONDriver returns the JSON driver of the client, which is json.Default if
+// JSON is nil.
+func (c *Client) JSONDriver() json.Driver {
+	if c.JSON != nil {
+		return c.JSON
+	}
+	return json.Default
+}
+
+// DefaultBackoff is the default Backoff of new clients.
+var DefaultBackoff = ExponentialBackoff(250*time.Millisecond, 10*time.Second)
+
+// ExponentialBackoff returns a Backoff that doubles the delay after each
+// attempt, starting at base and capped at max. A random jitter of up to half
+// the delay is subtracted, so that many clients failing at the same time don't
+// retry at the same time.
+func ExponentialBackoff(base, max time.Duration) func(attempt uint) time.Duration {
+	return func(attempt uint) time.Duration {
+		var d = max
+		// Avoid overflowing the shift.
+		if attempt < 32 {
+			if shifted := base << attempt; shifted > 0 && shifted < max {
+				d = shifted
+			}
+		}
+
+		if half := int64(d / 2); half > 0 {
+			d -= time.Duration(rand.Int63n(half))
+		}
+
+		return d
+	}
+}
+
 func NewClient() *Client {
 	return &Client{
 		Client:        httpdriver.NewClient(),
 		SchemaEncoder: &DefaultSchema{},
 		Retries:       Retries,
+		Backoff:       DefaultBackoff,
 		context:       context.Background(),
 	}
 }
@@ -61,6 +129,47 @@ func (c *Client) WithContext(ctx context.Context) *Client {
 	return c
 }
 
+// WithRequestOptions returns a client copy of the client with the given
+// options appended to OnRequest. The original client is not affected, which
+// makes this useful for scoped hooks, such as logging the calls of a single
+// command.
+func (c *Client) WithRequestOptions(opts ...RequestOption) *Client {
+	c = c.Copy()
+	c.OnRequest = append(append([]RequestOption(nil), c.OnRequest...), opts...)
+	return c
+}
+
+// WithResponseFuncs returns a client copy of the client with the given
+// functions appended to OnResponse. The original client is not affected.
+func (c *Client) WithResponseFuncs(fns ...ResponseFunc) *Client {
+	c = c.Copy()
+	c.OnResponse = append(append([]ResponseFunc(nil), c.OnResponse...), fns...)
+	return c
+}
+
+// WithRetries returns a client copy of the client with the given number of
+// retries. If retries is smaller than 1, requests will retry forever.
+func (c *Client) WithRetries(retries uint) *Client {
+	c = c.Copy()
+	c.Retries = retries
+	return c
+}
+
+// WithJSON returns a client copy of the client that decodes responses with the
+// given JSON driver.
+func (c *Client) WithJSON(driver json.Driver) *Client {
+	c = c.Copy()
+	c.JSON = driver
+	return c
+}
+
+// WithBackoff returns a client copy of the client with the given Backoff.
+func (c *Client) WithBackoff(backoff func(attempt uint) time.Duration) *Client {
+	c = c.Copy()
+	c.Backoff = backoff
+	return c
+}
+
 // Context is a shared context for all future calls. It's Background by
 // default.
 func (c *Client) Context() context.Context {
@@ -145,7 +254,7 @@ func (c *Client) RequestJSON(to interface{}, method, url string, opts ...Request
 		return nil
 	}
 
-	if err := json.DecodeStream(body, to); err != nil {
+	if err := c.JSONDriver().DecodeStream(body, to); err != nil {
 		return JSONError{err}
 	}
 
@@ -158,26 +267,76 @@ func (c *Client) Request(method, url string, opts ...RequestOption) (httpdriver.
 	var r httpdriver.Response
 	var status int
 
+	// limited is true if the last response was a 429 that said how long to
+	// wait, in which case the rate limiter waits for it instead of Backoff.
+	var limited bool
+
 	for i := uint(0); c.Retries < 1 || i < c.Retries; i++ {
+		// Back off before retrying network errors, server errors and 429s
+		// without a retry time. Other 429s are delayed by the rate limiter.
+		if i > 0 && !limited && c.Backoff != nil {
+			select {
+			case <-time.After(c.Backoff(i - 1)):
+			case <-c.context.Done():
+				return nil, RequestError{c.context.Err()}
+			}
+		}
+
 		q, err := c.Client.NewRequest(c.context, method, url)
 		if err != nil {
 			return nil, RequestError{err}
 		}
 
-		if err := c.applyOptions(q, opts); err != nil {
+		// Record the options applied to the request if it's logged.
+		var rec *requestRecorder
+		var req = q
+		if c.Logger != nil {
+			rec = newRequestRecorder(q, method, url, i)
+			req = rec
+		}
+
+		if err := c.applyOptions(req, opts); err != nil {
 			return nil, errors.Wrap(err, "failed to apply options")
 		}
 
+		for _, fn := range c.OnSend {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+		}
+
+		var start = time.Now()
+
 		r, doErr = c.Client.Do(q)
 
-		// Call OnResponse() even if the request failed.
+		if rec != nil {
+			r = c.logRequest(rec, r, doErr, time.Since(start))
+		}
+
+		limited = false
+		if doErr == nil && r.GetStatus() == StatusTooManyRequests {
+			r = c.readRateLimit(r)
+			limited = r.GetHeader().Get("Retry-After") != ""
+		}
+
+		// Call OnResponse() even if the request failed. All of them are
+		// called, as they may release what was acquired in OnSend.
+		var respErr error
 		for _, fn := range c.OnResponse {
-			if err := fn(q, r); err != nil {
-				return nil, err
+			if err := fn(q, r); err != nil && respErr == nil {
+				respErr = err
 			}
 		}
+		if respErr != nil {
+			return nil, respErr
+		}
 
 		if doErr != nil {
+			// Don't retry if the request was canceled.
+			if c.context.Err() != nil {
+				break
+			}
+			status = 0
 			continue
 		}
 
@@ -209,10 +368,59 @@ func (c *Client) Request(method, url string, opts ...RequestOption) (httpdriver.
 		}
 
 		// Optionally unmarshal the error.
-		json.Unmarshal(httpErr.Body, &httpErr)
+		c.JSONDriver().Unmarshal(httpErr.Body, &httpErr)
+		httpErr.Errors = parseFieldErrors(httpErr.RawErrors)
 
 		return nil, httpErr
 	}
 
 	return r, nil
 }
+
+// rateLimitBody is the JSON body of a 429 response.
+type rateLimitBody struct {
+	Message string `json:"message"`
+	// RetryAfter is the number of seconds to wait before retrying.
+	RetryAfter float64 `json:"retry_after"`
+	// Global is true if the rate limit applies to all requests.
+	Global bool `json:"global"`
+}
+
+// readRateLimit reads the body of a 429 response and copies its retry time and
+// global flag to the Retry-After and X-RateLimit-Global headers if they're
+// missing, so that rate limiters, which only see the headers, respect them.
+// The returned response must be used instead.
+func (c *Client) readRateLimit(r httpdriver.Response) httpdriver.Response {
+	body := r.GetBody()
+	b, _ := ioutil.ReadAll(body)
+	body.Close()
+
+	var header = r.GetHeader().Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	var limit rateLimitBody
+	if c.JSONDriver().Unmarshal(b, &limit) == nil {
+		if header.Get("Retry-After") == "" && limit.RetryAfter > 0 {
+			header.Set("Retry-After", strconv.FormatFloat(limit.RetryAfter, 'f', -1, 64))
+		}
+		if header.Get("X-RateLimit-Global") == "" && limit.Global {
+			header.Set("X-RateLimit-Global", "true")
+		}
+	}
+
+	return rateLimitedResponse{
+		bufferedResponse{r, ioutil.NopCloser(bytes.NewReader(b))},
+		header,
+	}
+}
+
+type rateLimitedResponse struct {
+	bufferedResponse
+	header http.Header
+}
+
+func (r rateLimitedResponse) GetHeader() http.Header {
+	return r.header
+}
@@ -0,0 +1,90 @@
+package httputil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/utils/httputil/httpdriver"
+	jsonutil "github.com/diamondburned/arikawa/utils/json"
+)
+
+func TestClientJSONDriver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"1337"}`))
+	}))
+	defer srv.Close()
+
+	var unmarshals int
+
+	c := NewClient().WithJSON(jsonutil.CompatDriver{
+		MarshalFunc: json.Marshal,
+		UnmarshalFunc: func(data []byte, v interface{}) error {
+			unmarshals++
+			return json.Unmarshal(data, v)
+		},
+	})
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.RequestJSON(&resp, "GET", srv.URL); err != nil {
+		t.Fatal("Failed to request:", err)
+	}
+
+	if resp.ID != "1337" || unmarshals != 1 {
+		t.Fatalf("Response %+v was not decoded by the driver (%d calls)", resp, unmarshals)
+	}
+}
+
+func TestClientRateLimitBody(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		switch attempts {
+		case 1:
+			// No headers, so only the body tells the retry time.
+			w.WriteHeader(StatusTooManyRequests)
+			w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.5,"global":true}`))
+		case 2:
+			// No retry time at all.
+			w.WriteHeader(StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	var headers []http.Header
+	var backoffs []uint
+
+	c := NewClient()
+	c.OnResponse = append(c.OnResponse, func(_ httpdriver.Request, r httpdriver.Response) error {
+		headers = append(headers, httpdriver.OptHeader(r))
+		return nil
+	})
+	c.Backoff = func(attempt uint) time.Duration {
+		backoffs = append(backoffs, attempt)
+		return 0
+	}
+
+	if err := c.FastRequest("GET", srv.URL); err != nil {
+		t.Fatal("Failed to request:", err)
+	}
+
+	if len(headers) != 3 {
+		t.Fatal("Unexpected number of attempts:", len(headers))
+	}
+	if h := headers[0]; h.Get("Retry-After") != "0.5" || h.Get("X-RateLimit-Global") != "true" {
+		t.Fatal("Rate limit body was not copied to the headers:", h)
+	}
+
+	// Only the 429 without a retry time should be backed off.
+	if len(backoffs) != 1 || backoffs[0] != 1 {
+		t.Fatal("Unexpected backoffs:", backoffs)
+	}
+}
@@ -2,7 +2,12 @@ package httputil
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/utils/json"
 )
 
 type JSONError struct {
@@ -35,10 +40,78 @@ type HTTPError struct {
 
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message,omitempty"`
+
+	// RawErrors is the nested errors object that Discord returns for invalid
+	// form bodies. Errors contains the same errors flattened.
+	RawErrors json.Raw `json:"errors,omitempty"`
+	// Errors contains every error in RawErrors, if any.
+	Errors []FieldError `json:"-"`
+}
+
+// FieldError is an error of a single field in the request body.
+type FieldError struct {
+	// Field is the dot-separated path to the field, such as
+	// "embed.fields.0.name".
+	Field   string `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err FieldError) Error() string {
+	return err.Field + ": " + err.Message
+}
+
+// parseFieldErrors flattens Discord's nested errors object, in which each
+// object is keyed by field name or array index, and the errors of a field are
+// stored in its "_errors" key.
+func parseFieldErrors(raw json.Raw) []FieldError {
+	var errs []FieldError
+	walkFieldErrors(raw, "", &errs)
+	return errs
+}
+
+func walkFieldErrors(raw json.Raw, path string, errs *[]FieldError) {
+	var fields map[string]json.Raw
+	if err := raw.UnmarshalTo(&fields); err != nil {
+		return
+	}
+
+	// Sort the keys, so that the order of the errors is stable.
+	var keys = make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if k == "_errors" {
+			var fieldErrs []FieldError
+			if err := fields[k].UnmarshalTo(&fieldErrs); err != nil {
+				continue
+			}
+
+			for _, fieldErr := range fieldErrs {
+				fieldErr.Field = path
+				*errs = append(*errs, fieldErr)
+			}
+
+			continue
+		}
+
+		var child = k
+		if path != "" {
+			child = path + "." + k
+		}
+
+		walkFieldErrors(fields[k], child, errs)
+	}
 }
 
 func (err HTTPError) Error() string {
 	switch {
+	case err.Message != "" && len(err.Errors) > 0:
+		return "Discord error: " + err.Message + ": " + err.Errors[0].Error()
+
 	case err.Message != "":
 		return "Discord error: " + err.Message
 
@@ -56,3 +129,53 @@ func (err HTTPError) Error() string {
 }
 
 type ErrorCode uint
+
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	ErrUnknownAccount ErrorCode = 10001
+	ErrUnknownChannel ErrorCode = 10003
+	ErrUnknownGuild   ErrorCode = 10004
+	ErrUnknownInvite  ErrorCode = 10006
+	ErrUnknownMember  ErrorCode = 10007
+	ErrUnknownMessage ErrorCode = 10008
+	ErrUnknownRole    ErrorCode = 10011
+	ErrUnknownUser    ErrorCode = 10013
+	ErrUnknownEmoji   ErrorCode = 10014
+	ErrUnknownWebhook ErrorCode = 10015
+	ErrUnknownBan     ErrorCode = 10026
+
+	ErrMaxGuilds    ErrorCode = 30001
+	ErrMaxPins      ErrorCode = 30003
+	ErrMaxRoles     ErrorCode = 30005
+	ErrMaxWebhooks  ErrorCode = 30007
+	ErrMaxEmojis    ErrorCode = 30008
+	ErrMaxReactions ErrorCode = 30010
+	ErrMaxChannels  ErrorCode = 30013
+
+	ErrMissingAccess ErrorCode = 50001
+	// ErrCannotEditOthersMessage is returned when editing a message authored
+	// by another user.
+	ErrCannotEditOthersMessage ErrorCode = 50005
+	// ErrCannotMessageUser is returned when a message can't be sent to a user,
+	// usually because they have DMs from server members disabled.
+	ErrCannotMessageUser  ErrorCode = 50007
+	ErrMissingPermissions ErrorCode = 50013
+	// ErrTooOldToBulkDelete is returned when bulk deleting messages older than
+	// 2 weeks.
+	ErrTooOldToBulkDelete ErrorCode = 50034
+	// ErrInvalidFormBody is returned when the request body is invalid. The
+	// fields of the error are in the HTTPError's Errors.
+	ErrInvalidFormBody ErrorCode = 50035
+
+	ErrReactionBlocked ErrorCode = 90001
+)
+
+// ErrCode returns the Discord error code of err, or 0 if err is not an
+// *HTTPError or doesn't have a code.
+func ErrCode(err error) ErrorCode {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return 0
+}
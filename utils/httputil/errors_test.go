@@ -0,0 +1,61 @@
+package httputil
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/diamondburned/arikawa/utils/json"
+)
+
+func TestHTTPErrorFields(t *testing.T) {
+	const body = `{
+		"code": 50035,
+		"message": "Invalid Form Body",
+		"errors": {
+			"embed": {
+				"fields": {
+					"0": {
+						"name": {
+							"_errors": [{
+								"code": "BASE_TYPE_REQUIRED",
+								"message": "This field is required"
+							}]
+						}
+					}
+				}
+			},
+			"content": {
+				"_errors": [{
+					"code": "BASE_TYPE_MAX_LENGTH",
+					"message": "Must be 2000 or fewer in length."
+				}]
+			}
+		}
+	}`
+
+	var httpErr = &HTTPError{Status: 400}
+	if err := json.Unmarshal([]byte(body), &httpErr); err != nil {
+		t.Fatal("Failed to unmarshal:", err)
+	}
+	httpErr.Errors = parseFieldErrors(httpErr.RawErrors)
+
+	var expect = []FieldError{
+		{"content", "BASE_TYPE_MAX_LENGTH", "Must be 2000 or fewer in length."},
+		{"embed.fields.0.name", "BASE_TYPE_REQUIRED", "This field is required"},
+	}
+
+	if len(httpErr.Errors) != len(expect) {
+		t.Fatal("Unexpected errors:", httpErr.Errors)
+	}
+
+	for i, err := range expect {
+		if httpErr.Errors[i] != err {
+			t.Fatalf("Expected %#v, got %#v", err, httpErr.Errors[i])
+		}
+	}
+
+	if code := ErrCode(errors.Wrap(httpErr, "failed")); code != ErrInvalidFormBody {
+		t.Fatal("Unexpected code:", code)
+	}
+}
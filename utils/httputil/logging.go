@@ -0,0 +1,298 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/utils/httputil/httpdriver"
+)
+
+// Redacted replaces secrets in logged requests.
+const Redacted = "[REDACTED]"
+
+// MaxLoggedBody is the maximum size of a request or response body that is
+// included in a RequestLog. Larger bodies are left out.
+var MaxLoggedBody int64 = 64 * 1024
+
+// RequestLog is a request made by a Client and its response, given to the
+// Client's Logger after being redacted.
+type RequestLog struct {
+	Method string
+	// URL is the full URL of the request, including the query.
+	URL    string
+	Header http.Header
+	// Body is the request body. It is only logged for JSON bodies.
+	Body []byte
+
+	// Status is the response status, or 0 if the request failed.
+	Status int
+	// Response is the response body. It is only logged for JSON bodies.
+	Response []byte
+	// Error is the error returned by the HTTP client, if any.
+	Error error
+
+	Duration time.Duration
+	// Attempt is the number of retries before this request, starting at 0.
+	Attempt uint
+}
+
+func (l RequestLog) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", l.Method, l.URL)
+
+	if l.Error != nil {
+		fmt.Fprintf(&b, " failed: %v", l.Error)
+	} else {
+		fmt.Fprintf(&b, " %d", l.Status)
+	}
+
+	fmt.Fprintf(&b, " (%v)", l.Duration)
+	return b.String()
+}
+
+// RedactedHeaders are the headers that DefaultRedact replaces.
+var RedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// RedactedFields are the JSON object keys whose values DefaultRedact replaces
+// in request and response bodies. For example, access_token is sent with
+// AddRecipient and AddMember, and token is returned with new webhooks.
+var RedactedFields = []string{
+	"access_token", "refresh_token", "token", "client_secret", "password",
+}
+
+var webhookTokenRegex = regexp.MustCompile(`(/webhooks/\d+/)[^/?#]+`)
+
+// DefaultRedact redacts the RedactedHeaders, webhook tokens in the URL and the
+// error, and the RedactedFields in JSON bodies.
+func DefaultRedact(l *RequestLog) {
+	if l.Header != nil {
+		l.Header = l.Header.Clone()
+		for _, key := range RedactedHeaders {
+			if _, ok := l.Header[http.CanonicalHeaderKey(key)]; ok {
+				l.Header.Set(key, Redacted)
+			}
+		}
+	}
+
+	l.URL = redactURL(l.URL)
+	l.Body = redactJSON(l.Body)
+	l.Response = redactJSON(l.Response)
+
+	// Errors from the HTTP client usually contain the URL.
+	if l.Error != nil {
+		if msg := redactURL(l.Error.Error()); msg != l.Error.Error() {
+			l.Error = redactedError{l.Error, msg}
+		}
+	}
+}
+
+func redactURL(s string) string {
+	return webhookTokenRegex.ReplaceAllString(s, "${1}"+Redacted)
+}
+
+// redactedError replaces the message of an error, while still allowing the
+// original error to be unwrapped.
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (err redactedError) Error() string { return err.msg }
+func (err redactedError) Unwrap() error { return err.err }
+
+// redactJSON replaces the values of RedactedFields in the given JSON. Invalid
+// JSON is dropped entirely, as it can't be redacted.
+func redactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	// Numbers are kept as-is, so that snowflakes aren't rounded.
+	var dec = json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil
+	}
+
+	if !redactValue(v) {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redactValue returns true if anything was redacted.
+func redactValue(v interface{}) (redacted bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isRedactedField(key) {
+				if s, ok := value.(string); ok && s != "" {
+					v[key] = Redacted
+					redacted = true
+				}
+				continue
+			}
+			if redactValue(value) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if redactValue(value) {
+				redacted = true
+			}
+		}
+	}
+
+	return
+}
+
+func isRedactedField(key string) bool {
+	for _, field := range RedactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLogger returns a client copy of the client that logs every request to
+// the given function. Requests are passed through the client's Redact first.
+func (c *Client) WithLogger(logger func(RequestLog)) *Client {
+	c = c.Copy()
+	c.Logger = logger
+	return c
+}
+
+// requestRecorder wraps a Request to record what's added to it by the
+// options.
+type requestRecorder struct {
+	httpdriver.Request
+	log RequestLog
+	url string
+
+	query url.Values
+}
+
+func newRequestRecorder(q httpdriver.Request, method, rawurl string, attempt uint) *requestRecorder {
+	return &requestRecorder{
+		Request: q,
+		log: RequestLog{
+			Method:  method,
+			Header:  http.Header{},
+			Attempt: attempt,
+		},
+		url:   rawurl,
+		query: url.Values{},
+	}
+}
+
+func (r *requestRecorder) AddHeader(header http.Header) {
+	for key, values := range header {
+		r.log.Header[key] = append(r.log.Header[key], values...)
+	}
+	r.Request.AddHeader(header)
+}
+
+func (r *requestRecorder) AddQuery(values url.Values) {
+	for key, v := range values {
+		r.query[key] = append(r.query[key], v...)
+	}
+	r.Request.AddQuery(values)
+}
+
+func (r *requestRecorder) WithBody(body io.ReadCloser) {
+	if isJSON(r.log.Header) {
+		b, err := readLimited(body)
+		body.Close()
+
+		// Pass whatever was read, so the request fails as it would have.
+		r.Request.WithBody(ioutil.NopCloser(bytes.NewReader(b)))
+		if err == nil {
+			r.log.Body = b
+		}
+		return
+	}
+
+	r.Request.WithBody(body)
+}
+
+// logRequest logs the request with its response. The response body is
+// buffered if it is logged, so the returned response must be used instead.
+func (c *Client) logRequest(
+	r *requestRecorder, resp httpdriver.Response, err error, d time.Duration) httpdriver.Response {
+
+	var l = r.log
+	l.URL = r.url
+	if len(r.query) > 0 {
+		l.URL += "?" + r.query.Encode()
+	}
+	l.Error = err
+	l.Duration = d
+
+	if resp != nil {
+		l.Status = resp.GetStatus()
+
+		if isJSON(resp.GetHeader()) {
+			body := resp.GetBody()
+			b, readErr := readLimited(body)
+			body.Close()
+
+			if readErr == nil {
+				l.Response = b
+			}
+
+			resp = bufferedResponse{resp, ioutil.NopCloser(bytes.NewReader(b))}
+		}
+	}
+
+	var redact = c.Redact
+	if redact == nil {
+		redact = DefaultRedact
+	}
+	redact(&l)
+
+	c.Logger(l)
+	return resp
+}
+
+type bufferedResponse struct {
+	httpdriver.Response
+	body io.ReadCloser
+}
+
+func (r bufferedResponse) GetBody() io.ReadCloser {
+	return r.body
+}
+
+func isJSON(h http.Header) bool {
+	return strings.HasPrefix(h.Get("Content-Type"), "application/json")
+}
+
+// readLimited reads the whole body. It returns an error if the body is larger
+// than MaxLoggedBody, but the returned bytes are still the whole body.
+func readLimited(r io.Reader) ([]byte, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return b, err
+	}
+	if int64(len(b)) > MaxLoggedBody {
+		return b, fmt.Errorf("body is larger than %d bytes", MaxLoggedBody)
+	}
+	return b, nil
+}
@@ -0,0 +1,89 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerRedacts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"170132746042081280","token":"webhooksecret"}`))
+	}))
+	defer srv.Close()
+
+	var logs []RequestLog
+
+	c := NewClient().WithLogger(func(l RequestLog) { logs = append(logs, l) })
+	c.Retries = 1
+	c.OnRequest = append(c.OnRequest, WithHeaders(http.Header{
+		"Authorization": {"Bot secret"},
+	}))
+
+	var resp struct {
+		ID    string `json:"id"`
+		Token string `json:"token"`
+	}
+
+	err := c.RequestJSON(
+		&resp, "POST", srv.URL+"/webhooks/1/urlsecret",
+		WithJSONBody(map[string]string{"access_token": "oauthsecret", "nick": "a"}),
+	)
+	if err != nil {
+		t.Fatal("Failed to request:", err)
+	}
+
+	// The caller still gets the real response.
+	if resp.Token != "webhooksecret" {
+		t.Fatalf("Unexpected token %q", resp.Token)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 log, got %d", len(logs))
+	}
+
+	var l = logs[0]
+	var logged = l.URL + l.Header.Get("Authorization") + string(l.Body) + string(l.Response)
+
+	for _, secret := range []string{"secret", "urlsecret", "oauthsecret", "webhooksecret"} {
+		if strings.Contains(logged, secret) {
+			t.Fatalf("Log contains %q: %s", secret, logged)
+		}
+	}
+
+	if l.Status != 200 {
+		t.Fatalf("Unexpected status %d", l.Status)
+	}
+	if !strings.Contains(string(l.Body), `"nick":"a"`) {
+		t.Fatalf("Body is missing unredacted fields: %s", l.Body)
+	}
+	if !strings.Contains(string(l.Response), `"170132746042081280"`) {
+		t.Fatalf("Response is missing the ID: %s", l.Response)
+	}
+}
+
+func TestLoggerCustomRedact(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var logged string
+
+	c := NewClient().WithLogger(func(l RequestLog) { logged = l.URL })
+	c.Retries = 1
+	c.Redact = func(l *RequestLog) {
+		DefaultRedact(l)
+		l.URL = strings.Replace(l.URL, "private", Redacted, -1)
+	}
+
+	if err := c.FastRequest("DELETE", srv.URL+"/private"); err != nil {
+		t.Fatal("Failed to request:", err)
+	}
+
+	if logged != srv.URL+"/"+Redacted {
+		t.Fatalf("Unexpected URL %q", logged)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/diamondburned/arikawa/utils/httputil/httpdriver"
 	"github.com/diamondburned/arikawa/utils/json"
@@ -40,6 +41,17 @@ func WithHeaders(headers http.Header) RequestOption {
 	}
 }
 
+// WithAuditLogReason sets the reason shown in the guild's audit log for the
+// request. It's ignored for requests that don't create audit log entries.
+func WithAuditLogReason(reason string) RequestOption {
+	return func(r httpdriver.Request) error {
+		r.AddHeader(http.Header{
+			"X-Audit-Log-Reason": {url.PathEscape(reason)},
+		})
+		return nil
+	}
+}
+
 func WithContentType(ctype string) RequestOption {
 	return func(r httpdriver.Request) error {
 		r.AddHeader(http.Header{
@@ -104,3 +116,39 @@ func WithJSONBody(v interface{}) RequestOption {
 		return nil
 	}
 }
+
+// ResponseMeta is the metadata of a response, stored by StoreResponseMeta.
+type ResponseMeta struct {
+	Status int
+	Header http.Header
+}
+
+// RayID returns the Cloudflare ray ID of the response, which Discord support
+// asks for when debugging a request.
+func (m ResponseMeta) RayID() string {
+	return m.Header.Get("CF-Ray")
+}
+
+// Date returns the time of the response according to Discord, or a zero time
+// if it's unknown.
+func (m ResponseMeta) Date() time.Time {
+	t, _ := http.ParseTime(m.Header.Get("Date"))
+	return t
+}
+
+// StoreResponseMeta returns a ResponseFunc that stores the status and headers
+// of every response into meta, overriding the previous one, so that meta holds
+// the last response once the request is done. Requests that fail without a
+// response leave meta as-is.
+//
+// As meta is written to without synchronization, a client using this function
+// should not be used concurrently.
+func StoreResponseMeta(meta *ResponseMeta) ResponseFunc {
+	return func(_ httpdriver.Request, r httpdriver.Response) error {
+		if r != nil {
+			meta.Status = r.GetStatus()
+			meta.Header = r.GetHeader()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStoreResponseMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Ray", "5f1d2e3c4b5a6978-AMS")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var meta ResponseMeta
+
+	c := NewClient().WithResponseFuncs(StoreResponseMeta(&meta))
+	if err := c.FastRequest("DELETE", srv.URL); err != nil {
+		t.Fatal("Failed to request:", err)
+	}
+
+	if meta.Status != http.StatusNoContent {
+		t.Fatalf("Unexpected status %d", meta.Status)
+	}
+	if meta.RayID() != "5f1d2e3c4b5a6978-AMS" {
+		t.Fatalf("Unexpected ray ID %q", meta.RayID())
+	}
+	if meta.Date().IsZero() {
+		t.Fatal("Date is missing")
+	}
+}
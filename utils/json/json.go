@@ -5,6 +5,7 @@ package json
 import (
 	"encoding/json"
 	"io"
+	"io/ioutil"
 )
 
 type Driver interface {
@@ -33,9 +34,52 @@ func (d DefaultDriver) EncodeStream(w io.Writer, v interface{}) error {
 	return json.NewEncoder(w).Encode(v)
 }
 
-// Default is the default JSON driver, which uses encoding/json.
+// Default is the default JSON driver, which uses encoding/json. Clients and
+// gateways without their own driver use it, so replacing it before creating
+// them changes the driver everywhere:
+//
+//    json.Default = json.CompatDriver{
+//        MarshalFunc:   jsoniter.ConfigFastest.Marshal,
+//        UnmarshalFunc: jsoniter.ConfigFastest.Unmarshal,
+//    }
 var Default Driver = DefaultDriver{}
 
+// CompatDriver is a Driver made of the Marshal and Unmarshal functions of a
+// package compatible with encoding/json, such as jsoniter or segmentio's
+// encoding/json, which are usually faster. Streams are read fully before being
+// unmarshaled.
+type CompatDriver struct {
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+func (d CompatDriver) Marshal(v interface{}) ([]byte, error) {
+	return d.MarshalFunc(v)
+}
+
+func (d CompatDriver) Unmarshal(data []byte, v interface{}) error {
+	return d.UnmarshalFunc(data, v)
+}
+
+func (d CompatDriver) DecodeStream(r io.Reader, v interface{}) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalFunc(b, v)
+}
+
+func (d CompatDriver) EncodeStream(w io.Writer, v interface{}) error {
+	b, err := d.MarshalFunc(v)
+	if err != nil {
+		return err
+	}
+
+	// Add the newline like encoding/json's Encoder does.
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
 // Marshal uses the default driver.
 func Marshal(v interface{}) ([]byte, error) {
 	return Default.Marshal(v)
@@ -68,3 +68,44 @@ func (i *NullableColorData) UnmarshalJSON(json []byte) error {
 
 	return err
 }
+
+// ================================ NullableSnowflake ================================
+
+// NullableSnowflake is a nullable version of discord.Snowflake. Unlike a plain
+// snowflake with omitempty, it can tell an omitted field apart from an explicit
+// null, which the API uses to clear a field.
+type NullableSnowflake = *NullableSnowflakeData
+
+type NullableSnowflakeData struct {
+	Val  discord.Snowflake
+	Init bool
+}
+
+// NullSnowflake serializes to JSON null.
+var NullSnowflake = &NullableSnowflakeData{}
+
+// NewNullableSnowflake creates a new non-null NullableSnowflake using the value
+// of the passed discord.Snowflake.
+func NewNullableSnowflake(v discord.Snowflake) NullableSnowflake {
+	return &NullableSnowflakeData{
+		Val:  v,
+		Init: true,
+	}
+}
+
+func (s NullableSnowflakeData) MarshalJSON() ([]byte, error) {
+	if !s.Init {
+		return []byte("null"), nil
+	}
+	return s.Val.MarshalJSON()
+}
+
+func (s *NullableSnowflakeData) UnmarshalJSON(json []byte) error {
+	if string(json) == "null" {
+		s.Init = false
+		return nil
+	}
+
+	s.Init = true
+	return s.Val.UnmarshalJSON(json)
+}
@@ -44,11 +44,34 @@ type Connection interface {
 
 // Conn is the default Websocket connection. It compresses all payloads using
 // zlib.
+// DefaultDialer is the dialer that new Conns copy. Changing it changes how all
+// connections made afterwards are dialed, including the voice ones, such as
+// to go through a proxy or to trust another certificate authority:
+//
+//    proxy, _ := url.Parse("http://proxy.example.com:3128")
+//    wsutil.DefaultDialer.Proxy = http.ProxyURL(proxy)
+//    wsutil.DefaultDialer.TLSClientConfig = &tls.Config{RootCAs: pool}
+//
+// To only change one connection, such as a Gateway's before it's opened, give
+// it its own Conn:
+//
+//    g.WS.Conn = wsutil.NewConnWithDialer(json.Default, &dialer)
+var DefaultDialer = websocket.Dialer{
+	Proxy:             http.ProxyFromEnvironment,
+	HandshakeTimeout:  WSTimeout,
+	EnableCompression: true,
+}
+
 type Conn struct {
 	Conn *websocket.Conn
 	json.Driver
 
-	dialer *websocket.Dialer
+	// Dialer dials the connection. It's a copy of DefaultDialer by default.
+	Dialer *websocket.Dialer
+	// Header is the extra headers sent with the handshake, such as the
+	// authorization of a gateway proxy.
+	Header http.Header
+
 	events chan Event
 
 	// write channels
@@ -60,6 +83,9 @@ type Conn struct {
 
 	// nil until Dial().
 	closeOnce *sync.Once
+	// closeCode is the code of the close frame sent on Close. No frame is sent
+	// if it's 0.
+	closeCode int
 
 	// zlib *zlib.Inflator // zlib.NewReader
 	// buf  []byte         // io.Copy buffer
@@ -72,13 +98,17 @@ func NewConn() *Conn {
 }
 
 func NewConnWithDriver(driver json.Driver) *Conn {
+	var dialer = DefaultDialer
+
+	return NewConnWithDialer(driver, &dialer)
+}
+
+// NewConnWithDialer creates a Conn that dials with the given dialer, which can
+// have its own proxy, TLS configuration or network dialer.
+func NewConnWithDialer(driver json.Driver, dialer *websocket.Dialer) *Conn {
 	return &Conn{
 		Driver: driver,
-		dialer: &websocket.Dialer{
-			Proxy:             http.ProxyFromEnvironment,
-			HandshakeTimeout:  WSTimeout,
-			EnableCompression: true,
-		},
+		Dialer: dialer,
 		// zlib:   zlib.NewInflator(),
 		// buf:    make([]byte, CopyBufferSize),
 	}
@@ -87,8 +117,12 @@ func NewConnWithDriver(driver json.Driver) *Conn {
 func (c *Conn) Dial(ctx context.Context, addr string) error {
 	var err error
 
-	// Enable compression:
 	headers := http.Header{}
+	for k, v := range c.Header {
+		headers[k] = v
+	}
+
+	// Enable compression:
 	headers.Set("Accept-Encoding", "zlib")
 
 	// BUG: https://github.com/golang/go/issues/31514
@@ -97,7 +131,7 @@ func (c *Conn) Dial(ctx context.Context, addr string) error {
 	// 	"compress": {"zlib-stream"},
 	// })
 
-	c.Conn, _, err = c.dialer.DialContext(ctx, addr, headers)
+	c.Conn, _, err = c.Dialer.DialContext(ctx, addr, headers)
 	if err != nil {
 		return errors.Wrap(err, "failed to dial WS")
 	}
@@ -115,6 +149,15 @@ func (c *Conn) Dial(ctx context.Context, addr string) error {
 	return err
 }
 
+// CloseCode returns the close code of the close frame that caused the error, or
+// -1 if the error wasn't caused by one.
+func CloseCode(err error) int {
+	if closeErr, ok := errors.Cause(err).(*websocket.CloseError); ok {
+		return closeErr.Code
+	}
+	return -1
+}
+
 func (c *Conn) Listen() <-chan Event {
 	return c.events
 }
@@ -166,12 +209,12 @@ func (c *Conn) writeLoop() {
 	// Quick deadline:
 	deadline := time.Now().Add(CloseDeadline)
 
-	// Make a closure message:
-	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
-
-	// Send a close message before closing the connection. We're not error
-	// checking this because it's not important.
-	c.Conn.WriteControl(websocket.TextMessage, msg, deadline)
+	// Send a close message before closing the connection if there's a code.
+	// We're not error checking this because it's not important.
+	if c.closeCode != 0 {
+		msg := websocket.FormatCloseMessage(c.closeCode, "")
+		c.Conn.WriteControl(websocket.CloseMessage, msg, deadline)
+	}
 
 	// Safe to close now.
 	c.errors <- c.Conn.Close()
@@ -253,10 +296,24 @@ func (c *Conn) Send(ctx context.Context, b []byte) error {
 	}
 }
 
-func (c *Conn) Close() (err error) {
+func (c *Conn) Close() error {
+	return c.close(0)
+}
+
+// CloseWithCode closes the connection like Close, but sends a close frame with
+// the given code first.
+func (c *Conn) CloseWithCode(code int) error {
+	return c.close(code)
+}
+
+func (c *Conn) close(code int) (err error) {
 	// Use a sync.Once to guarantee that other Close() calls block until the
 	// main call is done. It also prevents future calls.
 	c.closeOnce.Do(func() {
+		// Only the first call decides the code. The write loop reads it after
+		// c.writes is closed.
+		c.closeCode = code
+
 		// Close c.writes. This should trigger the websocket to close itself.
 		close(c.writes)
 		// Mark c.writes as empty.
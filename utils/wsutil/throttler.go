@@ -6,9 +6,26 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// The Discord gateway disconnects connections that send more than SendLimit
+// payloads within SendLimitInterval. HeartbeatReserve of them are left out of
+// the SendLimiter for heartbeats, which are sent with SendUnlimited, so that
+// a burst of commands can't delay a heartbeat and get the connection closed.
+const (
+	SendLimit         = 120
+	SendLimitInterval = time.Minute
+	HeartbeatReserve  = 5
+)
+
+// sendBurst is how many commands can be sent at once.
+const sendBurst = 5
+
+// NewSendLimiter returns a limiter that allows SendLimit commands, minus the
+// HeartbeatReserve, within every SendLimitInterval.
 func NewSendLimiter() *rate.Limiter {
-	// return rate.NewLimiter(rate.Every(time.Minute), 120)
-	return rate.NewLimiter(rate.Every(time.Second), 2)
+	// The burst is allowed on top of the rate, so it's taken out of the budget
+	// as well.
+	var perInterval = SendLimit - HeartbeatReserve - sendBurst
+	return rate.NewLimiter(rate.Every(SendLimitInterval/time.Duration(perInterval)), sendBurst)
 }
 
 func NewDialLimiter() *rate.Limiter {
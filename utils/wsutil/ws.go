@@ -105,10 +105,26 @@ func (ws *Websocket) SendContext(ctx context.Context, b []byte) error {
 	return ws.Conn.Send(ctx, b)
 }
 
+// SendUnlimited sends the payload without waiting for the SendLimiter. It's
+// meant for payloads that have their own budget, such as heartbeats, which
+// HeartbeatReserve is kept for.
+func (ws *Websocket) SendUnlimited(ctx context.Context, b []byte) error {
+	return ws.Conn.Send(ctx, b)
+}
+
 func (ws *Websocket) Close() error {
 	return ws.Conn.Close()
 }
 
+// CloseWithCode closes the connection with a close frame of the given code, if
+// the Connection supports it. Otherwise, it's the same as Close.
+func (ws *Websocket) CloseWithCode(code int) error {
+	if conn, ok := ws.Conn.(interface{ CloseWithCode(int) error }); ok {
+		return conn.CloseWithCode(code)
+	}
+	return ws.Conn.Close()
+}
+
 func InjectValues(rawurl string, values url.Values) string {
 	u, err := url.Parse(rawurl)
 	if err != nil {
@@ -1,6 +1,7 @@
 package voice
 
 import (
+	"io"
 	"sync"
 
 	"github.com/diamondburned/arikawa/discord"
@@ -42,10 +43,18 @@ type Session struct {
 
 	muted    bool
 	deafened bool
-	speaking bool
+	speaking voicegateway.SpeakingFlag
+
+	// secret is the key used to decrypt incoming packets.
+	secret [32]byte
+
+	// ssrcs maps the SSRC of incoming packets to users. It is filled by
+	// Speaking events.
+	ssrcMut sync.RWMutex
+	ssrcs   map[uint32]discord.UserID
 }
 
-func NewSession(ses *session.Session, userID discord.Snowflake) *Session {
+func NewSession(ses *session.Session, userID discord.UserID) *Session {
 	return &Session{
 		session: ses,
 		state: voicegateway.State{
@@ -53,6 +62,7 @@ func NewSession(ses *session.Session, userID discord.Snowflake) *Session {
 		},
 		ErrorLog: func(err error) {},
 		incoming: make(chan struct{}),
+		ssrcs:    make(map[uint32]discord.UserID),
 	}
 }
 
@@ -66,15 +76,33 @@ func (s *Session) UpdateServer(ev *gateway.VoiceServerUpdateEvent) {
 		return
 	}
 
-	// Reconnect.
+	// Reconnect. Callers of Write will block until the new connection is
+	// ready, so the audio writer is preserved across region moves.
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
 	s.state.Endpoint = ev.Endpoint
 	s.state.Token = ev.Token
 
+	// A null endpoint means that the voice server is unavailable. Discord
+	// will send another event once a new server is allocated. The current
+	// connection is kept until then, so that writes don't fail in between.
+	if ev.Endpoint == "" {
+		return
+	}
+
+	// The current connection is only replaced once the new one is ready, and
+	// it's kept if the new one fails.
 	if err := s.reconnect(); err != nil {
 		s.ErrorLog(errors.Wrap(err, "failed to reconnect after voice server update"))
+		return
+	}
+
+	// Restore the speaking state, as the new server doesn't know about it.
+	if s.speaking != 0 {
+		if err := s.gateway.Speaking(s.speaking); err != nil {
+			s.ErrorLog(errors.Wrap(err, "failed to restore speaking state"))
+		}
 	}
 }
 
@@ -92,9 +120,18 @@ func (s *Session) UpdateState(ev *gateway.VoiceStateUpdateEvent) {
 		s.incoming <- struct{}{}
 		return
 	}
+
+	// We've been moved into another channel. The session stays the same, so
+	// only the state needs to be updated.
+	s.mut.Lock()
+	s.state.SessionID = ev.SessionID
+	s.state.ChannelID = ev.ChannelID
+	s.mut.Unlock()
 }
 
-func (s *Session) JoinChannel(gID, cID discord.Snowflake, muted, deafened bool) error {
+func (s *Session) JoinChannel(
+	gID discord.GuildID, cID discord.ChannelID, muted, deafened bool) error {
+
 	// Acquire the mutex during join, locking during IO as well.
 	s.mut.Lock()
 	defer s.mut.Unlock()
@@ -112,10 +149,10 @@ func (s *Session) JoinChannel(gID, cID discord.Snowflake, muted, deafened bool)
 
 	s.muted = muted
 	s.deafened = deafened
-	s.speaking = false
+	s.speaking = 0
 
 	// Ensure that if `cID` is zero that it passes null to the update event.
-	var channelID discord.Snowflake = -1
+	var channelID = discord.NullChannelID
 	if cID.Valid() {
 		channelID = cID
 	}
@@ -142,52 +179,135 @@ func (s *Session) JoinChannel(gID, cID discord.Snowflake, muted, deafened bool)
 	return s.reconnect()
 }
 
-// reconnect uses the current state to reconnect to a new gateway and UDP
-// connection.
-func (s *Session) reconnect() (err error) {
-	s.gateway = voicegateway.New(s.state)
+// reconnect uses the current state to connect to a new gateway and UDP
+// connection, then replaces the current ones with them. The current ones are
+// left as-is if connecting fails. The mutex must be held.
+func (s *Session) reconnect() error {
+	gw := voicegateway.New(s.state)
+	gw.OnSpeaking = s.onSpeaking
 
 	// Open the voice gateway. The function will block until Ready is received.
-	if err := s.gateway.Open(); err != nil {
+	if err := gw.Open(); err != nil {
 		return errors.Wrap(err, "failed to open voice gateway")
 	}
 
 	// Get the Ready event.
-	voiceReady := s.gateway.Ready()
+	voiceReady := gw.Ready()
 
 	// Prepare the UDP voice connection.
-	s.voiceUDP, err = udp.DialConnection(voiceReady.Addr(), voiceReady.SSRC)
+	conn, err := udp.DialConnection(voiceReady.Addr(), voiceReady.SSRC)
 	if err != nil {
+		gw.Close()
 		return errors.Wrap(err, "failed to open voice UDP connection")
 	}
 
 	// Get the session description from the voice gateway.
-	d, err := s.gateway.SessionDescription(voicegateway.SelectProtocol{
+	d, err := gw.SessionDescription(voicegateway.SelectProtocol{
 		Protocol: "udp",
 		Data: voicegateway.SelectProtocolData{
-			Address: s.voiceUDP.GatewayIP,
-			Port:    s.voiceUDP.GatewayPort,
+			Address: conn.GatewayIP,
+			Port:    conn.GatewayPort,
 			Mode:    Protocol,
 		},
 	})
 	if err != nil {
+		conn.Close()
+		gw.Close()
 		return errors.Wrap(err, "failed to select protocol")
 	}
 
+	// Swap the connections. Writers hold the read lock, so none of them is
+	// writing into the old connection while it's closed.
+	s.ensureClosed()
+	s.gateway = gw
+	s.voiceUDP = conn
+	s.secret = d.SecretKey
+
+	// The SSRCs of other users are only valid on the server that sent them.
+	s.ssrcMut.Lock()
+	s.ssrcs = make(map[uint32]discord.UserID)
+	s.ssrcMut.Unlock()
+
 	// Start the UDP loop.
-	go s.voiceUDP.Start(&d.SecretKey)
+	go conn.Start(&d.SecretKey)
 
 	return nil
 }
 
+// onSpeaking keeps track of the user behind each SSRC, then sends the event to
+// the session's handler.
+func (s *Session) onSpeaking(ev *voicegateway.SpeakingEvent) {
+	s.ssrcMut.Lock()
+	s.ssrcs[ev.SSRC] = ev.UserID
+	s.ssrcMut.Unlock()
+
+	s.session.Handler.Call(ev)
+}
+
+// UserFromSSRC returns the ID of the user behind the given SSRC. It returns
+// false if no Speaking event has been received for the SSRC yet.
+func (s *Session) UserFromSSRC(ssrc uint32) (discord.UserID, bool) {
+	s.ssrcMut.RLock()
+	defer s.ssrcMut.RUnlock()
+
+	userID, ok := s.ssrcs[ssrc]
+	return userID, ok
+}
+
+// ReadPacket reads the next decrypted voice packet sent by any user in the
+// channel. Packets can be demultiplexed per user using their SSRC with
+// UserFromSSRC. Non-voice packets are skipped. This method should only be
+// called from a single goroutine.
+func (s *Session) ReadPacket() (*udp.Packet, error) {
+	s.mut.RLock()
+	conn, secret := s.voiceUDP, s.secret
+	s.mut.RUnlock()
+
+	if conn == nil {
+		return nil, ErrCannotReceive
+	}
+
+	for {
+		p, err := conn.ReadPacket(&secret)
+		if err == udp.ErrUnknownPacket {
+			continue
+		}
+
+		// Keep reading from the new connection if the old one was replaced
+		// after a voice server update.
+		if err != nil {
+			s.mut.RLock()
+			newConn, newSecret := s.voiceUDP, s.secret
+			s.mut.RUnlock()
+
+			if newConn != nil && newConn != conn {
+				conn, secret = newConn, newSecret
+				continue
+			}
+		}
+
+		return p, err
+	}
+}
+
 // Speaking tells Discord we're speaking. This calls
 // (*voicegateway.Gateway).Speaking().
 func (s *Session) Speaking(flag voicegateway.SpeakingFlag) error {
 	// TODO: maybe we don't need to mutex protect IO.
-	s.mut.RLock()
-	defer s.mut.RUnlock()
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if s.gateway == nil {
+		return ErrCannotSend
+	}
+
+	if err := s.gateway.Speaking(flag); err != nil {
+		return err
+	}
 
-	return s.gateway.Speaking(flag)
+	// Remember the flag, so it can be restored after a reconnection.
+	s.speaking = flag
+	return nil
 }
 
 func (s *Session) StopSpeaking() error {
@@ -200,6 +320,11 @@ func (s *Session) StopSpeaking() error {
 	return nil
 }
 
+var _ io.Writer = (*Session)(nil)
+
+// Write sends an Opus frame into the voice UDP connection. Speaking should be
+// called before writing for Discord to play the audio. It implements
+// io.Writer, so an Opus encoder may write its frames directly into a Session.
 func (s *Session) Write(b []byte) (int, error) {
 	s.mut.RLock()
 	defer s.mut.RUnlock()
@@ -225,7 +350,7 @@ func (s *Session) Disconnect() error {
 
 	err := s.session.Gateway.UpdateVoiceState(gateway.UpdateVoiceStateData{
 		GuildID:   s.state.GuildID,
-		ChannelID: discord.NullSnowflake,
+		ChannelID: discord.NullChannelID,
 		SelfMute:  true,
 		SelfDeaf:  true,
 	})
@@ -102,20 +102,13 @@ func (c *Connection) Start(secret *[32]byte) {
 	defer frequency.Stop()
 
 	var b []byte
-	var ok bool
 
-	// Close these channels at the end so Write() doesn't block.
-	defer func() {
-		close(c.send)
-		close(c.closed)
-	}()
+	// Close this channel at the end so Write() doesn't block.
+	defer close(c.closed)
 
 	for {
 		select {
-		case b, ok = <-c.send:
-			if !ok {
-				return
-			}
+		case b = <-c.send:
 		case <-c.close:
 			return
 		}
@@ -152,9 +145,80 @@ func (c *Connection) Close() error {
 	return c.conn.Close()
 }
 
-// Write sends bytes into the voice UDP connection.
+// Packet is a decrypted voice packet received from the UDP connection.
+type Packet struct {
+	// SSRC is the synchronization source of the packet, which identifies the
+	// user who sent it. The SSRC is mapped to a user by Speaking events.
+	SSRC      uint32
+	Sequence  uint16
+	Timestamp uint32
+	// Opus is the decrypted Opus frame.
+	Opus []byte
+}
+
+// ErrUnknownPacket is returned by ReadPacket when the received packet is not an
+// RTP voice packet, such as an RTCP packet. These packets can be skipped.
+var ErrUnknownPacket = errors.New("received non-voice packet")
+
+// ReadPacket reads and decrypts a single voice packet from the UDP connection.
+// The secret must be the same key given to Start. This method is safe to be
+// called concurrently with Write, but not with itself.
+func (c *Connection) ReadPacket(secret *[32]byte) (*Packet, error) {
+	// The maximum size of an Opus frame, plus the RTP header and overhead.
+	var buf [1400]byte
+
+	n, err := c.conn.Read(buf[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read packet")
+	}
+
+	// The fixed RTP header is 12 bytes, and the payload type is masked with
+	// the marker bit.
+	if n < 12 || buf[0]&0xC0 != 0x80 || buf[1]&0x7F != 0x78 {
+		return nil, ErrUnknownPacket
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], buf[:12])
+
+	opus, ok := secretbox.Open(nil, buf[12:n], &nonce, secret)
+	if !ok {
+		return nil, errors.New("failed to decrypt packet")
+	}
+
+	// Skip the header extension, which Discord encrypts along with the Opus
+	// frame.
+	if buf[0]&0x10 != 0 && len(opus) >= 4 {
+		skip := 4 + 4*int(binary.BigEndian.Uint16(opus[2:4]))
+		if skip > len(opus) {
+			return nil, errors.New("invalid header extension length")
+		}
+		opus = opus[skip:]
+	}
+
+	return &Packet{
+		SSRC:      binary.BigEndian.Uint32(buf[8:12]),
+		Sequence:  binary.BigEndian.Uint16(buf[2:4]),
+		Timestamp: binary.BigEndian.Uint32(buf[4:8]),
+		Opus:      opus,
+	}, nil
+}
+
+// ErrClosed is returned by Write when the connection has been closed.
+var ErrClosed = errors.New("voice UDP connection is closed")
+
+var _ io.Writer = (*Connection)(nil)
+
+// Write encrypts and sends an Opus frame into the voice UDP connection. Each
+// call should contain exactly one 20ms frame, as Write paces itself to send 50
+// frames per second.
 func (c *Connection) Write(b []byte) (int, error) {
-	c.send <- b
+	select {
+	case c.send <- b:
+	case <-c.closed:
+		return 0, ErrClosed
+	}
+
 	if err := <-c.reply; err != nil {
 		return 0, err
 	}
@@ -5,6 +5,7 @@
 package voice
 
 import (
+	"context"
 	"log"
 	"strconv"
 	"sync"
@@ -21,6 +22,9 @@ var (
 
 	// ErrCannotSend is an error when audio is sent to a closed channel.
 	ErrCannotSend = errors.New("cannot send audio to closed channel")
+
+	// ErrCannotReceive is an error when audio is read from a closed channel.
+	ErrCannotReceive = errors.New("cannot receive audio from closed channel")
 )
 
 // Voice represents a Voice Repository used for managing voice sessions.
@@ -29,7 +33,7 @@ type Voice struct {
 
 	// Session holds all of the active voice sessions.
 	mapmutex sync.Mutex
-	sessions map[discord.Snowflake]*Session // guildID:Session
+	sessions map[discord.GuildID]*Session // guildID:Session
 
 	// ErrorLog will be called when an error occurs (defaults to log.Println)
 	ErrorLog func(err error)
@@ -39,7 +43,7 @@ type Voice struct {
 func NewVoice(s *state.State) *Voice {
 	v := &Voice{
 		State:    s,
-		sessions: make(map[discord.Snowflake]*Session),
+		sessions: make(map[discord.GuildID]*Session),
 		ErrorLog: defaultErrorHandler,
 	}
 
@@ -47,6 +51,14 @@ func NewVoice(s *state.State) *Voice {
 	s.AddHandler(v.onVoiceStateUpdate)
 	s.AddHandler(v.onVoiceServerUpdate)
 
+	// Leave the voice channels before a graceful close closes the gateway.
+	s.AddCloseHook(func(context.Context) error {
+		if err := v.disconnect(); err.HasError() {
+			return err
+		}
+		return nil
+	})
+
 	return v
 }
 
@@ -94,7 +106,7 @@ func (v *Voice) onVoiceServerUpdate(e *gateway.VoiceServerUpdateEvent) {
 }
 
 // GetSession gets a session for a guild with a read lock.
-func (v *Voice) GetSession(guildID discord.Snowflake) (*Session, bool) {
+func (v *Voice) GetSession(guildID discord.GuildID) (*Session, bool) {
 	v.mapmutex.Lock()
 	defer v.mapmutex.Unlock()
 
@@ -104,7 +116,7 @@ func (v *Voice) GetSession(guildID discord.Snowflake) (*Session, bool) {
 }
 
 // RemoveSession removes a session.
-func (v *Voice) RemoveSession(guildID discord.Snowflake) {
+func (v *Voice) RemoveSession(guildID discord.GuildID) {
 	v.mapmutex.Lock()
 	defer v.mapmutex.Unlock()
 
@@ -117,7 +129,9 @@ func (v *Voice) RemoveSession(guildID discord.Snowflake) {
 }
 
 // JoinChannel joins the specified channel in the specified guild.
-func (v *Voice) JoinChannel(gID, cID discord.Snowflake, muted, deafened bool) (*Session, error) {
+func (v *Voice) JoinChannel(
+	gID discord.GuildID, cID discord.ChannelID, muted, deafened bool) (*Session, error) {
+
 	// Get the stored voice session for the given guild.
 	conn, ok := v.GetSession(gID)
 
@@ -140,7 +154,7 @@ func (v *Voice) JoinChannel(gID, cID discord.Snowflake, muted, deafened bool) (*
 }
 
 type CloseError struct {
-	SessionErrors map[discord.Snowflake]error
+	SessionErrors map[discord.GuildID]error
 	StateErr      error
 }
 
@@ -165,8 +179,20 @@ func (e *CloseError) Error() string {
 }
 
 func (v *Voice) Close() error {
+	err := v.disconnect()
+
+	err.StateErr = v.State.Close()
+	if err.HasError() {
+		return err
+	}
+
+	return nil
+}
+
+// disconnect disconnects all voice sessions.
+func (v *Voice) disconnect() *CloseError {
 	err := &CloseError{
-		SessionErrors: make(map[discord.Snowflake]error),
+		SessionErrors: make(map[discord.GuildID]error),
 	}
 
 	v.mapmutex.Lock()
@@ -178,10 +204,5 @@ func (v *Voice) Close() error {
 		}
 	}
 
-	err.StateErr = v.State.Close()
-	if err.HasError() {
-		return err
-	}
-
-	return nil
+	return err
 }
@@ -35,7 +35,12 @@ type SessionDescriptionEvent struct {
 }
 
 // OPCode 5
-type SpeakingEvent SpeakingData
+// https://discord.com/developers/docs/topics/voice-connections#speaking
+type SpeakingEvent struct {
+	Speaking SpeakingFlag   `json:"speaking"`
+	SSRC     uint32         `json:"ssrc"`
+	UserID   discord.UserID `json:"user_id"`
+}
 
 // OPCode 6
 // https://discordapp.com/developers/docs/topics/voice-connections#heartbeating-example-heartbeat-ack-payload
@@ -34,9 +34,9 @@ var (
 
 // State contains state information of a voice gateway.
 type State struct {
-	GuildID   discord.Snowflake
-	ChannelID discord.Snowflake
-	UserID    discord.Snowflake
+	GuildID   discord.GuildID
+	ChannelID discord.ChannelID
+	UserID    discord.UserID
 
 	SessionID string
 	Token     string
@@ -63,6 +63,9 @@ type Gateway struct {
 	// called even when the Gateway is gracefully closed. It's used mainly for
 	// reconnections or any type of connection interruptions. (defaults to noop)
 	AfterClose func(err error)
+	// OnSpeaking is called when a user starts or stops speaking. The event
+	// maps the user's SSRC to their user ID. (defaults to noop)
+	OnSpeaking func(ev *SpeakingEvent)
 
 	// Filled by methods, internal use
 	waitGroup *sync.WaitGroup
@@ -74,6 +77,7 @@ func New(state State) *Gateway {
 		Timeout:    wsutil.WSTimeout,
 		ErrorLog:   wsutil.WSError,
 		AfterClose: func(error) {},
+		OnSpeaking: func(*SpeakingEvent) {},
 	}
 }
 
@@ -41,8 +41,11 @@ func (c *Gateway) HandleOP(op *wsutil.OP) error {
 
 	// Someone started or stopped speaking.
 	case SpeakingOP:
-		// ?
-		// TODO: handler in Session
+		var ev *SpeakingEvent
+		if err := json.Unmarshal(op.Data, &ev); err != nil {
+			return errors.Wrap(err, "failed to parse SPEAKING event")
+		}
+		c.OnSpeaking(ev)
 
 	// Heartbeat response from the server
 	case HeartbeatAckOP: